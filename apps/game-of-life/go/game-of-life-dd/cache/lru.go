@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+)
+
+// EvictionReason is why a response was evicted from an LRU cache
+type EvictionReason string
+
+const (
+	// EvictedCapacity is the reason of the least recently used response evicted to make room for a new one
+	EvictedCapacity EvictionReason = "capacity"
+	// EvictedExpired is the reason of a response evicted once it is older than the TTL
+	EvictedExpired EvictionReason = "expired"
+)
+
+// EvictionHook is called with the reason of every response evicted from an LRU cache
+type EvictionHook func(reason EvictionReason)
+
+// lruEntry is a response cached by an LRU cache
+type lruEntry struct {
+	key       string
+	response  *gameoflifepb.GameResponse
+	expiresAt time.Time
+}
+
+// LRU is an in-memory Cache holding up to a fixed number of responses for a fixed time,
+// evicting the least recently used response when it is full
+type LRU struct {
+	size         int
+	ttl          time.Duration
+	evictionHook EvictionHook
+	now          func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// order holds the entries from the most to the least recently used
+	order *list.List
+}
+
+// LRUOption is a function that alters an LRU cache
+type LRUOption func(*LRU)
+
+// WithEvictionHook sets the hook called for every response evicted from the cache
+func WithEvictionHook(hook EvictionHook) LRUOption {
+	return func(c *LRU) {
+		c.evictionHook = hook
+	}
+}
+
+// NewLRU Returns an LRU cache of up to size responses, each kept for ttl, or until evicted if ttl is 0
+func NewLRU(size int, ttl time.Duration, options ...LRUOption) *LRU {
+	c := &LRU{
+		size:         max(size, 1),
+		ttl:          ttl,
+		evictionHook: func(EvictionReason) {},
+		now:          time.Now,
+		entries:      make(map[string]*list.Element),
+		order:        list.New(),
+	}
+	for _, opt := range options {
+		opt(c)
+	}
+	return c
+}
+
+func (c *LRU) Get(_ context.Context, key string) (*gameoflifepb.GameResponse, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*lruEntry)
+	if c.ttl > 0 && !c.now().Before(entry.expiresAt) {
+		c.remove(element, EvictedExpired)
+		return nil, false, nil
+	}
+	c.order.MoveToFront(element)
+	return entry.response, true, nil
+}
+
+func (c *LRU) Set(_ context.Context, key string, response *gameoflifepb.GameResponse) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expiresAt := c.now().Add(c.ttl)
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.response, entry.expiresAt = response, expiresAt
+		c.order.MoveToFront(element)
+		return nil
+	}
+	if c.order.Len() == c.size {
+		c.remove(c.order.Back(), EvictedCapacity)
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, response: response, expiresAt: expiresAt})
+	return nil
+}
+
+// Len Returns the number of responses in the cache, including the expired responses not evicted yet
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// remove Evicts the entry of element for the given reason
+func (c *LRU) remove(element *list.Element, reason EvictionReason) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry).key)
+	c.evictionHook(reason)
+}
@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestKey(t *testing.T) {
+	var tests = []struct {
+		a     *gameoflifepb.GameRequest
+		b     *gameoflifepb.GameRequest
+		equal bool
+	}{
+		{&gameoflifepb.GameRequest{Board: "[[1]]", NumGens: 1}, &gameoflifepb.GameRequest{Board: "[[1]]", NumGens: 1}, true},
+		{&gameoflifepb.GameRequest{Board: "[[1]]", NumGens: 1}, &gameoflifepb.GameRequest{Board: "[[1]]", NumGens: 2}, false},
+		{&gameoflifepb.GameRequest{Board: "[[1]]", NumGens: 1}, &gameoflifepb.GameRequest{Board: "[[0]]", NumGens: 1}, false},
+		{&gameoflifepb.GameRequest{Board: "[[1]]", Rule: "B36/S23"}, &gameoflifepb.GameRequest{Board: "[[1]]"}, false},
+		{&gameoflifepb.GameRequest{Board: "[[1]]", Topology: gameoflifepb.Topology_TORUS}, &gameoflifepb.GameRequest{Board: "[[1]]"}, false},
+		{&gameoflifepb.GameRequest{Board: "[[1]]", Engine: gameoflifepb.Engine_HASHLIFE}, &gameoflifepb.GameRequest{Board: "[[1]]"}, false},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v,%v", tt.a, tt.b)
+		t.Run(testname, func(t *testing.T) {
+			a, err := Key(tt.a)
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			b, err := Key(tt.b)
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			if (a == b) != tt.equal {
+				t.Errorf("Got keys %v and %v, expected equal %v", a, b, tt.equal)
+			}
+		})
+	}
+}
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+	var evictions []EvictionReason
+	c := NewLRU(2, time.Minute, WithEvictionHook(func(reason EvictionReason) {
+		evictions = append(evictions, reason)
+	}))
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	responses := map[string]*gameoflifepb.GameResponse{
+		"a": {Board: "[[1]]"},
+		"b": {Board: "[[0]]"},
+		"c": {Board: "[[1,1]]"},
+	}
+	for _, key := range []string{"a", "b"} {
+		if err := c.Set(ctx, key, responses[key]); err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+	}
+	// Getting a makes b the least recently used response, evicted by c
+	if ans, ok, _ := c.Get(ctx, "a"); !ok || !proto.Equal(ans, responses["a"]) {
+		t.Errorf("Got %v %v, expected %v", ans, ok, responses["a"])
+	}
+	c.Set(ctx, "c", responses["c"])
+	var tests = []struct {
+		key string
+		ok  bool
+	}{
+		{"a", true},
+		{"b", false},
+		{"c", true},
+		{"d", false},
+	}
+	for _, tt := range tests {
+		if ans, ok, err := c.Get(ctx, tt.key); err != nil || ok != tt.ok || (ok && !proto.Equal(ans, responses[tt.key])) {
+			t.Errorf("Got %v %v %v for %v, expected %v", ans, ok, err, tt.key, tt.ok)
+		}
+	}
+	if len(evictions) != 1 || evictions[0] != EvictedCapacity {
+		t.Errorf("Got %v, expected [%v]", evictions, EvictedCapacity)
+	}
+
+	// Setting a key again refreshes its TTL
+	now = now.Add(30 * time.Second)
+	c.Set(ctx, "a", responses["a"])
+	now = now.Add(45 * time.Second)
+	if _, ok, _ := c.Get(ctx, "a"); !ok {
+		t.Errorf("Got expired a, expected a")
+	}
+	if _, ok, _ := c.Get(ctx, "c"); ok {
+		t.Errorf("Got c, expected c to be expired")
+	}
+	if c.Len() != 1 || len(evictions) != 2 || evictions[1] != EvictedExpired {
+		t.Errorf("Got %v responses and %v, expected 1 response and [%v %v]", c.Len(), evictions, EvictedCapacity, EvictedExpired)
+	}
+}
@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRedis(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	c := NewRedis(redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1}), time.Minute)
+
+	if ans, ok, err := c.Get(ctx, "a"); err != nil || ok {
+		t.Errorf("Got %v %v %v, expected a miss", ans, ok, err)
+	}
+	response := &gameoflifepb.GameResponse{Code: gameoflifepb.ResponseCode_OK, Board: "[[1]]", FinalGeneration: 3, Period: 1}
+	if err := c.Set(ctx, "a", response); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if ans, ok, err := c.Get(ctx, "a"); err != nil || !ok || !proto.Equal(ans, response) {
+		t.Errorf("Got %v %v %v, expected %v", ans, ok, err, response)
+	}
+
+	server.FastForward(time.Minute)
+	if ans, ok, err := c.Get(ctx, "a"); err != nil || ok {
+		t.Errorf("Got %v %v %v, expected the response to be expired", ans, ok, err)
+	}
+
+	server.Close()
+	if _, _, err := c.Get(ctx, "a"); err == nil {
+		t.Errorf("Error not found: %v", err)
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+	"io"
 	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-dd/logging"
@@ -13,8 +16,11 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 var logger *zap.Logger
@@ -23,6 +29,19 @@ var logger *zap.Logger
 
 type Client interface {
 	RunGame(ctx context.Context, in *gameoflifepb.GameRequest, opts ...grpc.CallOption) (*gameoflifepb.GameResponse, error)
+	RunGameStream(ctx context.Context, in *gameoflifepb.GameRequest, opts ...grpc.CallOption) (gameoflifepb.GameOfLife_RunGameStreamClient, error)
+	ListPatterns(ctx context.Context, in *gameoflifepb.ListPatternsRequest, opts ...grpc.CallOption) (*gameoflifepb.ListPatternsResponse, error)
+	GetPattern(ctx context.Context, in *gameoflifepb.GetPatternRequest, opts ...grpc.CallOption) (*gameoflifepb.Pattern, error)
+	RunGames(ctx context.Context, in *gameoflifepb.BatchGameRequest, opts ...grpc.CallOption) (*gameoflifepb.BatchGameResponse, error)
+	SubmitGame(ctx context.Context, in *gameoflifepb.GameRequest, opts ...grpc.CallOption) (*gameoflifepb.Job, error)
+	GetJob(ctx context.Context, in *gameoflifepb.GetJobRequest, opts ...grpc.CallOption) (*gameoflifepb.Job, error)
+	CancelJob(ctx context.Context, in *gameoflifepb.CancelJobRequest, opts ...grpc.CallOption) (*gameoflifepb.Job, error)
+	ListJobs(ctx context.Context, in *gameoflifepb.ListJobsRequest, opts ...grpc.CallOption) (*gameoflifepb.ListJobsResponse, error)
+	CreateSession(ctx context.Context, in *gameoflifepb.CreateSessionRequest, opts ...grpc.CallOption) (*gameoflifepb.Session, error)
+	Step(ctx context.Context, in *gameoflifepb.StepRequest, opts ...grpc.CallOption) (*gameoflifepb.Session, error)
+	GetSession(ctx context.Context, in *gameoflifepb.GetSessionRequest, opts ...grpc.CallOption) (*gameoflifepb.Session, error)
+	DeleteSession(ctx context.Context, in *gameoflifepb.DeleteSessionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CheckConnection(ctx context.Context) error
 	Close() error
 }
 
@@ -87,22 +106,299 @@ func (c *gameOfLifeClient) RunGame(ctx context.Context, gameRequest *gameoflifep
 	if err != nil {
 		logger.Error("Calling grpcClient.RunGame",
 			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
 		)
 		return r, err
 	}
 	return r, err
 }
 
+// RunGameStream opens a stream of every generation of the game. The stream is bound to ctx
+// instead of the query timeout, so callers must cancel ctx once they are done receiving.
+// The span of the call lasts until the stream ends, or ctx is cancelled.
+func (c *gameOfLifeClient) RunGameStream(ctx context.Context, gameRequest *gameoflifepb.GameRequest, opts ...grpc.CallOption) (gameoflifepb.GameOfLife_RunGameStreamClient, error) {
+	span, ctx := tracer.StartSpanFromContext(ctx, "RunGameStream")
+
+	ctx = withSourceMetadata(ctx, c.source)
+	stream, err := c.grpcClient.RunGameStream(ctx, gameRequest, opts...)
+	if err != nil {
+		logger.Error("Calling grpcClient.RunGameStream",
+			zap.Error(err),
+		)
+		span.Finish(tracer.WithError(err))
+		return nil, err
+	}
+	traced := &tracedStream{GameOfLife_RunGameStreamClient: stream, span: span}
+	context.AfterFunc(ctx, func() { traced.finish(ctx.Err()) })
+	return traced, nil
+}
+
+// tracedStream is a stream of generations finishing the span of its call once it ends
+type tracedStream struct {
+	gameoflifepb.GameOfLife_RunGameStreamClient
+	span      tracer.Span
+	numFrames atomic.Int64
+	once      sync.Once
+}
+
+func (s *tracedStream) Recv() (*gameoflifepb.GenerationFrame, error) {
+	frame, err := s.GameOfLife_RunGameStreamClient.Recv()
+	switch {
+	case err == io.EOF:
+		s.finish(nil)
+	case err != nil:
+		s.finish(err)
+	default:
+		s.numFrames.Add(1)
+	}
+	return frame, err
+}
+
+// finish Finishes the span with the number of frames received, only the first time the stream ends
+func (s *tracedStream) finish(err error) {
+	s.once.Do(func() {
+		s.span.SetTag("rungame_stream_client.response.num_frames", s.numFrames.Load())
+		s.span.Finish(tracer.WithError(err))
+	})
+}
+
+// ListPatterns lists the built-in patterns of the server
+func (c *gameOfLifeClient) ListPatterns(ctx context.Context, in *gameoflifepb.ListPatternsRequest, opts ...grpc.CallOption) (*gameoflifepb.ListPatternsResponse, error) {
+	span, ctx := tracer.StartSpanFromContext(ctx, "ListPatterns")
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+
+	r, err := c.grpcClient.ListPatterns(ctx, in, append(c.cfg.options(), opts...)...)
+	span.Finish(tracer.WithError(err))
+	if err != nil {
+		logger.Error("Calling grpcClient.ListPatterns",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetPattern gets the built-in pattern of the server with the given name
+func (c *gameOfLifeClient) GetPattern(ctx context.Context, in *gameoflifepb.GetPatternRequest, opts ...grpc.CallOption) (*gameoflifepb.Pattern, error) {
+	span, ctx := tracer.StartSpanFromContext(ctx, "GetPattern")
+	span.SetTag("getpattern_client.request.name", in.Name)
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+
+	r, err := c.grpcClient.GetPattern(ctx, in, append(c.cfg.options(), opts...)...)
+	span.Finish(tracer.WithError(err))
+	if err != nil {
+		logger.Error("Calling grpcClient.GetPattern",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+		)
+		return nil, err
+	}
+	return r, nil
+}
+
+// RunGames runs a batch of games, returning the result of every game
+func (c *gameOfLifeClient) RunGames(ctx context.Context, in *gameoflifepb.BatchGameRequest, opts ...grpc.CallOption) (*gameoflifepb.BatchGameResponse, error) {
+	span, ctx := tracer.StartSpanFromContext(ctx, "RunGames")
+	span.SetTag("rungames_client.request.num_games", len(in.Requests))
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+
+	r, err := c.grpcClient.RunGames(ctx, in, append(c.cfg.options(), opts...)...)
+	span.Finish(tracer.WithError(err))
+	if err != nil {
+		logger.Error("Calling grpcClient.RunGames",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+		)
+		return nil, err
+	}
+	return r, nil
+}
+
+// SubmitGame submits a game run in the background, returning its job
+func (c *gameOfLifeClient) SubmitGame(ctx context.Context, in *gameoflifepb.GameRequest, opts ...grpc.CallOption) (*gameoflifepb.Job, error) {
+	span, ctx := tracer.StartSpanFromContext(ctx, "SubmitGame")
+	span.SetTag("submitgame_client.request.num_gens", in.NumGens)
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+
+	r, err := c.grpcClient.SubmitGame(ctx, in, append(c.cfg.options(), opts...)...)
+	span.Finish(tracer.WithError(err))
+	if err != nil {
+		logger.Error("Calling grpcClient.SubmitGame",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+		)
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetJob gets the job of the server with the given id
+func (c *gameOfLifeClient) GetJob(ctx context.Context, in *gameoflifepb.GetJobRequest, opts ...grpc.CallOption) (*gameoflifepb.Job, error) {
+	span, ctx := tracer.StartSpanFromContext(ctx, "GetJob")
+	span.SetTag("getjob_client.request.id", in.Id)
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+
+	r, err := c.grpcClient.GetJob(ctx, in, append(c.cfg.options(), opts...)...)
+	span.Finish(tracer.WithError(err))
+	if err != nil {
+		logger.Error("Calling grpcClient.GetJob",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+		)
+		return nil, err
+	}
+	return r, nil
+}
+
+// CancelJob cancels the job of the server with the given id
+func (c *gameOfLifeClient) CancelJob(ctx context.Context, in *gameoflifepb.CancelJobRequest, opts ...grpc.CallOption) (*gameoflifepb.Job, error) {
+	span, ctx := tracer.StartSpanFromContext(ctx, "CancelJob")
+	span.SetTag("canceljob_client.request.id", in.Id)
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+
+	r, err := c.grpcClient.CancelJob(ctx, in, append(c.cfg.options(), opts...)...)
+	span.Finish(tracer.WithError(err))
+	if err != nil {
+		logger.Error("Calling grpcClient.CancelJob",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+		)
+		return nil, err
+	}
+	return r, nil
+}
+
+// ListJobs lists the jobs of the server
+func (c *gameOfLifeClient) ListJobs(ctx context.Context, in *gameoflifepb.ListJobsRequest, opts ...grpc.CallOption) (*gameoflifepb.ListJobsResponse, error) {
+	span, ctx := tracer.StartSpanFromContext(ctx, "ListJobs")
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+
+	r, err := c.grpcClient.ListJobs(ctx, in, append(c.cfg.options(), opts...)...)
+	span.Finish(tracer.WithError(err))
+	if err != nil {
+		logger.Error("Calling grpcClient.ListJobs",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+		)
+		return nil, err
+	}
+	return r, nil
+}
+
+// CreateSession creates a session holding the board of a game, returning it with its id
+func (c *gameOfLifeClient) CreateSession(ctx context.Context, in *gameoflifepb.CreateSessionRequest, opts ...grpc.CallOption) (*gameoflifepb.Session, error) {
+	span, ctx := tracer.StartSpanFromContext(ctx, "CreateSession")
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+
+	r, err := c.grpcClient.CreateSession(ctx, in, append(c.cfg.options(), opts...)...)
+	span.Finish(tracer.WithError(err))
+	if err != nil {
+		logger.Error("Calling grpcClient.CreateSession",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+		)
+		return nil, err
+	}
+	return r, nil
+}
+
+// Step advances the board of the session with the given id
+func (c *gameOfLifeClient) Step(ctx context.Context, in *gameoflifepb.StepRequest, opts ...grpc.CallOption) (*gameoflifepb.Session, error) {
+	span, ctx := tracer.StartSpanFromContext(ctx, "Step")
+	span.SetTag("step_client.request.session_id", in.SessionId)
+	span.SetTag("step_client.request.num_gens", in.NumGens)
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+
+	r, err := c.grpcClient.Step(ctx, in, append(c.cfg.options(), opts...)...)
+	span.Finish(tracer.WithError(err))
+	if err != nil {
+		logger.Error("Calling grpcClient.Step",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+		)
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetSession gets the session of the server with the given id
+func (c *gameOfLifeClient) GetSession(ctx context.Context, in *gameoflifepb.GetSessionRequest, opts ...grpc.CallOption) (*gameoflifepb.Session, error) {
+	span, ctx := tracer.StartSpanFromContext(ctx, "GetSession")
+	span.SetTag("getsession_client.request.session_id", in.SessionId)
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+
+	r, err := c.grpcClient.GetSession(ctx, in, append(c.cfg.options(), opts...)...)
+	span.Finish(tracer.WithError(err))
+	if err != nil {
+		logger.Error("Calling grpcClient.GetSession",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+		)
+		return nil, err
+	}
+	return r, nil
+}
+
+// DeleteSession deletes the session of the server with the given id
+func (c *gameOfLifeClient) DeleteSession(ctx context.Context, in *gameoflifepb.DeleteSessionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	span, ctx := tracer.StartSpanFromContext(ctx, "DeleteSession")
+	span.SetTag("deletesession_client.request.session_id", in.SessionId)
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+
+	r, err := c.grpcClient.DeleteSession(ctx, in, append(c.cfg.options(), opts...)...)
+	span.Finish(tracer.WithError(err))
+	if err != nil {
+		logger.Error("Calling grpcClient.DeleteSession",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+		)
+		return nil, err
+	}
+	return r, nil
+}
+
+// CheckConnection Returns nil once the connection to the server is ready, connecting it if it is idle, or an
+// error with the state of the connection if it isn't ready before ctx is done
+func (c *gameOfLifeClient) CheckConnection(ctx context.Context) error {
+	state := c.conn.GetState()
+	if state == connectivity.Idle {
+		c.conn.Connect()
+	}
+	for state != connectivity.Ready {
+		if !c.conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("connection to %s is %s", c.conn.Target(), state)
+		}
+		state = c.conn.GetState()
+	}
+	return nil
+}
+
 func (c *gameOfLifeClient) Close() error {
 	return c.conn.Close()
 }
 
 // prepareContext adds timeouts and source metadata to the context
 func prepareContext(ctx context.Context, source string, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(withSourceMetadata(ctx, source), timeout)
+}
+
+// withSourceMetadata adds source metadata to the outgoing context
+func withSourceMetadata(ctx context.Context, source string) context.Context {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		md = metadata.New(nil)
 	}
 	md.Append("source", source)
-	return context.WithTimeout(metadata.NewOutgoingContext(ctx, md), timeout)
+	return metadata.NewOutgoingContext(ctx, md)
 }
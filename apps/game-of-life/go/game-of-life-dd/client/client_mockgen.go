@@ -11,6 +11,7 @@ import (
 	gameoflife "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
 	gomock "github.com/golang/mock/gomock"
 	grpc "google.golang.org/grpc"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // MockClient is a mock of Client interface.
@@ -36,6 +37,40 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// CancelJob mocks base method.
+func (m *MockClient) CancelJob(ctx context.Context, in *gameoflife.CancelJobRequest, opts ...grpc.CallOption) (*gameoflife.Job, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelJob", varargs...)
+	ret0, _ := ret[0].(*gameoflife.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelJob indicates an expected call of CancelJob.
+func (mr *MockClientMockRecorder) CancelJob(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelJob", reflect.TypeOf((*MockClient)(nil).CancelJob), varargs...)
+}
+
+// CheckConnection mocks base method.
+func (m *MockClient) CheckConnection(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckConnection", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckConnection indicates an expected call of CheckConnection.
+func (mr *MockClientMockRecorder) CheckConnection(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckConnection", reflect.TypeOf((*MockClient)(nil).CheckConnection), ctx)
+}
+
 // Close mocks base method.
 func (m *MockClient) Close() error {
 	m.ctrl.T.Helper()
@@ -50,6 +85,146 @@ func (mr *MockClientMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockClient)(nil).Close))
 }
 
+// CreateSession mocks base method.
+func (m *MockClient) CreateSession(ctx context.Context, in *gameoflife.CreateSessionRequest, opts ...grpc.CallOption) (*gameoflife.Session, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateSession", varargs...)
+	ret0, _ := ret[0].(*gameoflife.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockClientMockRecorder) CreateSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockClient)(nil).CreateSession), varargs...)
+}
+
+// DeleteSession mocks base method.
+func (m *MockClient) DeleteSession(ctx context.Context, in *gameoflife.DeleteSessionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteSession", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockClientMockRecorder) DeleteSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockClient)(nil).DeleteSession), varargs...)
+}
+
+// GetJob mocks base method.
+func (m *MockClient) GetJob(ctx context.Context, in *gameoflife.GetJobRequest, opts ...grpc.CallOption) (*gameoflife.Job, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetJob", varargs...)
+	ret0, _ := ret[0].(*gameoflife.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockClientMockRecorder) GetJob(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockClient)(nil).GetJob), varargs...)
+}
+
+// GetPattern mocks base method.
+func (m *MockClient) GetPattern(ctx context.Context, in *gameoflife.GetPatternRequest, opts ...grpc.CallOption) (*gameoflife.Pattern, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPattern", varargs...)
+	ret0, _ := ret[0].(*gameoflife.Pattern)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPattern indicates an expected call of GetPattern.
+func (mr *MockClientMockRecorder) GetPattern(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPattern", reflect.TypeOf((*MockClient)(nil).GetPattern), varargs...)
+}
+
+// GetSession mocks base method.
+func (m *MockClient) GetSession(ctx context.Context, in *gameoflife.GetSessionRequest, opts ...grpc.CallOption) (*gameoflife.Session, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetSession", varargs...)
+	ret0, _ := ret[0].(*gameoflife.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockClientMockRecorder) GetSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockClient)(nil).GetSession), varargs...)
+}
+
+// ListJobs mocks base method.
+func (m *MockClient) ListJobs(ctx context.Context, in *gameoflife.ListJobsRequest, opts ...grpc.CallOption) (*gameoflife.ListJobsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListJobs", varargs...)
+	ret0, _ := ret[0].(*gameoflife.ListJobsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobs indicates an expected call of ListJobs.
+func (mr *MockClientMockRecorder) ListJobs(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobs", reflect.TypeOf((*MockClient)(nil).ListJobs), varargs...)
+}
+
+// ListPatterns mocks base method.
+func (m *MockClient) ListPatterns(ctx context.Context, in *gameoflife.ListPatternsRequest, opts ...grpc.CallOption) (*gameoflife.ListPatternsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListPatterns", varargs...)
+	ret0, _ := ret[0].(*gameoflife.ListPatternsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPatterns indicates an expected call of ListPatterns.
+func (mr *MockClientMockRecorder) ListPatterns(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPatterns", reflect.TypeOf((*MockClient)(nil).ListPatterns), varargs...)
+}
+
 // RunGame mocks base method.
 func (m *MockClient) RunGame(ctx context.Context, in *gameoflife.GameRequest, opts ...grpc.CallOption) (*gameoflife.GameResponse, error) {
 	m.ctrl.T.Helper()
@@ -69,3 +244,83 @@ func (mr *MockClientMockRecorder) RunGame(ctx, in interface{}, opts ...interface
 	varargs := append([]interface{}{ctx, in}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunGame", reflect.TypeOf((*MockClient)(nil).RunGame), varargs...)
 }
+
+// RunGameStream mocks base method.
+func (m *MockClient) RunGameStream(ctx context.Context, in *gameoflife.GameRequest, opts ...grpc.CallOption) (gameoflife.GameOfLife_RunGameStreamClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RunGameStream", varargs...)
+	ret0, _ := ret[0].(gameoflife.GameOfLife_RunGameStreamClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunGameStream indicates an expected call of RunGameStream.
+func (mr *MockClientMockRecorder) RunGameStream(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunGameStream", reflect.TypeOf((*MockClient)(nil).RunGameStream), varargs...)
+}
+
+// RunGames mocks base method.
+func (m *MockClient) RunGames(ctx context.Context, in *gameoflife.BatchGameRequest, opts ...grpc.CallOption) (*gameoflife.BatchGameResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RunGames", varargs...)
+	ret0, _ := ret[0].(*gameoflife.BatchGameResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunGames indicates an expected call of RunGames.
+func (mr *MockClientMockRecorder) RunGames(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunGames", reflect.TypeOf((*MockClient)(nil).RunGames), varargs...)
+}
+
+// Step mocks base method.
+func (m *MockClient) Step(ctx context.Context, in *gameoflife.StepRequest, opts ...grpc.CallOption) (*gameoflife.Session, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Step", varargs...)
+	ret0, _ := ret[0].(*gameoflife.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Step indicates an expected call of Step.
+func (mr *MockClientMockRecorder) Step(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Step", reflect.TypeOf((*MockClient)(nil).Step), varargs...)
+}
+
+// SubmitGame mocks base method.
+func (m *MockClient) SubmitGame(ctx context.Context, in *gameoflife.GameRequest, opts ...grpc.CallOption) (*gameoflife.Job, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SubmitGame", varargs...)
+	ret0, _ := ret[0].(*gameoflife.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitGame indicates an expected call of SubmitGame.
+func (mr *MockClientMockRecorder) SubmitGame(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitGame", reflect.TypeOf((*MockClient)(nil).SubmitGame), varargs...)
+}
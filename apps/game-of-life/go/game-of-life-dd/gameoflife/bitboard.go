@@ -0,0 +1,307 @@
+package gameoflife
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+)
+
+const wordSize = 64
+
+// bitBoard is a rows x cols board packed one bit per cell into 64-bit words.
+// Each row starts on a new word, and the padding bits past the last column are always 0.
+type bitBoard struct {
+	rows   int
+	cols   int
+	stride int
+	words  []uint64
+}
+
+// newBitBoard Returns an empty rows x cols board
+func newBitBoard(rows int, cols int) *bitBoard {
+	stride := (cols + wordSize - 1) / wordSize
+	return &bitBoard{
+		rows:   rows,
+		cols:   cols,
+		stride: stride,
+		words:  make([]uint64, rows*stride),
+	}
+}
+
+// bitBoardFromCells Returns a packed copy of the given board
+func bitBoardFromCells(board [][]int) *bitBoard {
+	b := newBitBoard(len(board), len(board[0]))
+	for i, row := range board {
+		for j, cell := range row {
+			b.set(i, j, cell == 1)
+		}
+	}
+	return b
+}
+
+// row Returns the words of the given row
+func (b *bitBoard) row(row int) []uint64 {
+	return b.words[row*b.stride : (row+1)*b.stride]
+}
+
+func (b *bitBoard) get(row int, col int) bool {
+	return b.words[row*b.stride+col/wordSize]&(1<<(col%wordSize)) != 0
+}
+
+func (b *bitBoard) set(row int, col int, alive bool) {
+	if alive {
+		b.words[row*b.stride+col/wordSize] |= 1 << (col % wordSize)
+	} else {
+		b.words[row*b.stride+col/wordSize] &^= 1 << (col % wordSize)
+	}
+}
+
+// population Returns the number of live cells
+func (b *bitBoard) population() int {
+	sum := 0
+	for _, w := range b.words {
+		sum += bits.OnesCount64(w)
+	}
+	return sum
+}
+
+// appendCells Appends the cells of the board to buf, as the bytes of its words
+func (b *bitBoard) appendCells(buf []byte) []byte {
+	for _, w := range b.words {
+		buf = binary.LittleEndian.AppendUint64(buf, w)
+	}
+	return buf
+}
+
+// boundingBox Returns the smallest rectangle holding all live cells, or nil if the board is empty
+func (b *bitBoard) boundingBox() *gameoflifepb.BoundingBox {
+	var box *gameoflifepb.BoundingBox
+	for i := 0; i < b.rows; i++ {
+		words := b.row(i)
+		first, last := -1, -1
+		for k, w := range words {
+			if w == 0 {
+				continue
+			}
+			if first < 0 {
+				first = k*wordSize + bits.TrailingZeros64(w)
+			}
+			last = k*wordSize + wordSize - 1 - bits.LeadingZeros64(w)
+		}
+		if first < 0 {
+			continue
+		}
+		if box == nil {
+			box = &gameoflifepb.BoundingBox{MinRow: int32(i), MinCol: int32(first), MaxCol: int32(last)}
+		}
+		box.MaxRow = int32(i)
+		box.MinCol = min(box.MinCol, int32(first))
+		box.MaxCol = max(box.MaxCol, int32(last))
+	}
+	return box
+}
+
+// cells Returns the board as a 2D int slice
+func (b *bitBoard) cells() [][]int {
+	board := make([][]int, b.rows)
+	for i := range board {
+		board[i] = make([]int, b.cols)
+		for j := range board[i] {
+			if b.get(i, j) {
+				board[i][j] = 1
+			}
+		}
+	}
+	return board
+}
+
+// appendJSON Appends the board to buf as a JSON 2D array, e.g. [[0,1],[1,0]]
+func (b *bitBoard) appendJSON(buf []byte) []byte {
+	buf = append(buf, '[')
+	for i := 0; i < b.rows; i++ {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, '[')
+		for j := 0; j < b.cols; j++ {
+			if j > 0 {
+				buf = append(buf, ',')
+			}
+			if b.get(i, j) {
+				buf = append(buf, '1')
+			} else {
+				buf = append(buf, '0')
+			}
+		}
+		buf = append(buf, ']')
+	}
+	return append(buf, ']')
+}
+
+// String Returns the board as a JSON 2D array, e.g. [[0,1],[1,0]]
+func (b *bitBoard) String() string {
+	return string(b.appendJSON(make([]byte, 0, b.rows*(2*b.cols+2)+2)))
+}
+
+// bitStepper advances a bitBoard by double buffering between two boards,
+// so stepping does not allocate.
+type bitStepper struct {
+	cur      *bitBoard
+	next     *bitBoard
+	topology gameoflifepb.Topology
+	// survive[n] and birth[n] are all ones if a cell with n live neighbors survives or is born
+	survive [9]uint64
+	birth   [9]uint64
+	// Scratch rows used for the missing neighbors of edge rows
+	zeroRow     []uint64
+	mirroredTop []uint64
+	mirroredBot []uint64
+	// Mask of the valid bits in the last word of each row
+	lastMask uint64
+}
+
+// newBitStepper Returns a stepper starting from the given board
+func newBitStepper(board *bitBoard, rule Rule, topology gameoflifepb.Topology) *bitStepper {
+	s := &bitStepper{
+		cur:         board,
+		next:        newBitBoard(board.rows, board.cols),
+		topology:    topology,
+		zeroRow:     make([]uint64, board.stride),
+		mirroredTop: make([]uint64, board.stride),
+		mirroredBot: make([]uint64, board.stride),
+		lastMask:    ^uint64(0),
+	}
+	if rem := board.cols % wordSize; rem != 0 {
+		s.lastMask = 1<<rem - 1
+	}
+	for n := 0; n <= 8; n++ {
+		if rule.Survive[n] {
+			s.survive[n] = ^uint64(0)
+		}
+		if rule.Birth[n] {
+			s.birth[n] = ^uint64(0)
+		}
+	}
+	return s
+}
+
+// mirrorRow Writes the given row of the current board into dst with the columns reversed
+func (s *bitStepper) mirrorRow(dst []uint64, row int) {
+	clear(dst)
+	b := s.cur
+	for j := 0; j < b.cols; j++ {
+		if b.get(row, j) {
+			c := b.cols - 1 - j
+			dst[c/wordSize] |= 1 << (c % wordSize)
+		}
+	}
+}
+
+// neighborRow Returns the row above (offset -1) or below (offset 1) the given row,
+// taking the topology into account
+func (s *bitStepper) neighborRow(row int, offset int) []uint64 {
+	r := row + offset
+	if r >= 0 && r < s.cur.rows {
+		return s.cur.row(r)
+	}
+	switch s.topology {
+	case gameoflifepb.Topology_TORUS:
+		return s.cur.row((r + s.cur.rows) % s.cur.rows)
+	case gameoflifepb.Topology_KLEIN_BOTTLE:
+		if r < 0 {
+			return s.mirroredBot
+		}
+		return s.mirroredTop
+	}
+	return s.zeroRow
+}
+
+// wrapsColumns Returns true if the left and right edges of the board are joined
+func (s *bitStepper) wrapsColumns() bool {
+	return s.topology != gameoflifepb.Topology_BOUNDED
+}
+
+// shifted Returns the words of a row shifted so that each cell holds its west and east neighbor
+func (s *bitStepper) shifted(row []uint64, k int) (west uint64, east uint64) {
+	last := len(row) - 1
+	w := row[k]
+	west = w << 1
+	if k > 0 {
+		west |= row[k-1] >> (wordSize - 1)
+	} else if s.wrapsColumns() {
+		c := s.cur.cols - 1
+		west |= (row[last] >> (c % wordSize)) & 1
+	}
+	east = w >> 1
+	if k < last {
+		east |= row[k+1] << (wordSize - 1)
+	} else if s.wrapsColumns() {
+		east |= (row[0] & 1) << ((s.cur.cols - 1) % wordSize)
+	}
+	return west, east
+}
+
+// step Advances the current board by one generation
+func (s *bitStepper) step() {
+	s.prepare()
+	for i := 0; i < s.cur.rows; i++ {
+		s.stepRow(i)
+	}
+	s.swap()
+}
+
+// prepare Fills the scratch rows needed to step the current board
+func (s *bitStepper) prepare() {
+	if s.topology == gameoflifepb.Topology_KLEIN_BOTTLE {
+		s.mirrorRow(s.mirroredTop, 0)
+		s.mirrorRow(s.mirroredBot, s.cur.rows-1)
+	}
+}
+
+// swap Makes the next board current once all of its rows are stepped
+func (s *bitStepper) swap() {
+	s.cur, s.next = s.next, s.cur
+}
+
+// stepRow Computes the given row of the next board from the current board
+func (s *bitStepper) stepRow(i int) {
+	up, mid, down := s.neighborRow(i, -1), s.cur.row(i), s.neighborRow(i, 1)
+	out := s.next.row(i)
+	for k := range out {
+		upW, upE := s.shifted(up, k)
+		midW, midE := s.shifted(mid, k)
+		downW, downE := s.shifted(down, k)
+
+		// Bit-sliced counters c0..c3 hold the number of live neighbors of the 64 cells in this word
+		var c0, c1, c2, c3 uint64
+		for _, x := range [8]uint64{upW, up[k], upE, midW, midE, downW, down[k], downE} {
+			carry := c0 & x
+			c0 ^= x
+			x, carry = carry, c1&carry
+			c1 ^= x
+			x, carry = carry, c2&carry
+			c2 ^= x
+			c3 |= carry
+		}
+
+		alive := mid[k]
+		var result uint64
+		for n := 0; n <= 8; n++ {
+			eq := sliceMask(c0, n&1) & sliceMask(c1, n&2) & sliceMask(c2, n&4) & sliceMask(c3, n&8)
+			result |= eq & (alive&s.survive[n] | ^alive&s.birth[n])
+		}
+		if k == len(out)-1 {
+			result &= s.lastMask
+		}
+		out[k] = result
+	}
+}
+
+// sliceMask Returns the bit slice c if bit is set and its complement otherwise
+func sliceMask(c uint64, bit int) uint64 {
+	if bit != 0 {
+		return c
+	}
+	return ^c
+}
@@ -0,0 +1,279 @@
+package gameoflife
+
+import (
+	"bytes"
+	"context"
+	"hash/maphash"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+)
+
+// engine advances a board by whole generations
+type engine interface {
+	// advance Advances the board by the given number of generations, or Returns an error if the engine can't
+	advance(generations int) error
+	// board Returns the current board
+	board() *bitBoard
+	String() string
+	// population Returns the number of live cells
+	population() int
+	// appendState Appends the whole state of the engine to buf, or Returns false if the engine can't be compared
+	// between generations
+	appendState(buf []byte) ([]byte, bool)
+	// close Releases the resources of the engine
+	close()
+}
+
+// bitEngine steps a bit-packed board one generation at a time, optionally with a pool of workers
+type bitEngine struct {
+	ctx        context.Context
+	stepper    *bitStepper
+	pool       *stripePool
+	generation int
+}
+
+func newBitEngine(ctx context.Context, board *bitBoard, rule Rule, topology gameoflifepb.Topology, cfg *runConfig) *bitEngine {
+	e := &bitEngine{
+		ctx:     ctx,
+		stepper: newBitStepper(board, rule, topology),
+	}
+	if cfg.workers > 1 {
+		e.pool = newStripePool(e.stepper, cfg.workers, cfg.stripeHook)
+	}
+	return e
+}
+
+func (e *bitEngine) advance(generations int) error {
+	for i := 0; i < generations; i++ {
+		e.generation++
+		if e.pool != nil {
+			e.pool.step(e.ctx, e.generation)
+		} else {
+			e.stepper.step()
+		}
+	}
+	return nil
+}
+
+func (e *bitEngine) board() *bitBoard {
+	return e.stepper.cur
+}
+
+func (e *bitEngine) String() string {
+	return e.stepper.cur.String()
+}
+
+func (e *bitEngine) population() int {
+	return e.stepper.cur.population()
+}
+
+func (e *bitEngine) appendState(buf []byte) ([]byte, bool) {
+	return e.stepper.cur.appendCells(buf), true
+}
+
+func (e *bitEngine) close() {
+	if e.pool != nil {
+		e.pool.close()
+	}
+}
+
+// hashLifeEngine advances an unbounded plane with HashLife, and shows the window of the plane covered by the initial board
+type hashLifeEngine struct {
+	plane *hashLife
+	rows  int
+	cols  int
+	stats *HashLifeStats
+}
+
+func newHashLifeEngine(board *bitBoard, rule Rule, cfg *runConfig) *hashLifeEngine {
+	plane := newHashLife(board, rule)
+	plane.maxNodes = cfg.maxHashLifeNodes
+	return &hashLifeEngine{
+		plane: plane,
+		rows:  board.rows,
+		cols:  board.cols,
+		stats: cfg.hashLifeStats,
+	}
+}
+
+func (e *hashLifeEngine) advance(generations int) error {
+	return e.plane.advance(uint64(generations))
+}
+
+func (e *hashLifeEngine) board() *bitBoard {
+	return bitBoardFromCells(e.plane.cells(0, 0, e.rows, e.cols))
+}
+
+func (e *hashLifeEngine) String() string {
+	return e.board().String()
+}
+
+func (e *hashLifeEngine) population() int {
+	return e.plane.population()
+}
+
+// appendState Returns false, as generations are not compared while HashLife advances by many generations at once
+func (e *hashLifeEngine) appendState(buf []byte) ([]byte, bool) {
+	return buf, false
+}
+
+func (e *hashLifeEngine) close() {
+	if e.stats != nil {
+		*e.stats = e.plane.stats
+	}
+}
+
+// stateEngine steps a stateBoard one generation at a time, for the rules that can't be run on a bitBoard.
+// The live neighbors of a cell are counted from the prefix sums of the live cells of each row of the board,
+// padded by the range of the rule on every side with the cells across the edges of the topology.
+type stateEngine struct {
+	cur      *stateBoard
+	next     *stateBoard
+	rule     stateRule
+	topology gameoflifepb.Topology
+	// widths[d] is the number of columns on each side of a cell counted in the row d rows away from it
+	widths []int
+	// sums holds the prefix sums of the padded rows, with a leading 0 for each row
+	sums []int32
+}
+
+func newStateEngine(board *stateBoard, rule stateRule, topology gameoflifepb.Topology) *stateEngine {
+	r := rule.radius
+	e := &stateEngine{
+		cur:      board,
+		next:     newStateBoard(board.rows, board.cols),
+		rule:     rule,
+		topology: topology,
+		widths:   make([]int, r+1),
+		sums:     make([]int32, (board.rows+2*r)*(board.cols+2*r+1)),
+	}
+	for d := range e.widths {
+		e.widths[d] = r
+		if rule.neighborhood == vonNeumannNeighborhood {
+			e.widths[d] = r - d
+		}
+	}
+	return e
+}
+
+// sumRows Fills the prefix sums of the live cells of the padded rows of the current board
+func (e *stateEngine) sumRows() {
+	b, r := e.cur, e.rule.radius
+	stride := b.cols + 2*r + 1
+	for i := 0; i < b.rows+2*r; i++ {
+		sums := e.sums[i*stride : (i+1)*stride]
+		for j := 0; j < b.cols+2*r; j++ {
+			var live int32
+			if row, col, ok := wrapCoordinates(i-r, j-r, b.rows, b.cols, e.topology); ok && b.get(row, col) == 1 {
+				live = 1
+			}
+			sums[j+1] = sums[j] + live
+		}
+	}
+}
+
+// step Advances the current board by one generation
+func (e *stateEngine) step() {
+	e.sumRows()
+	b, r := e.cur, e.rule.radius
+	stride := b.cols + 2*r + 1
+	for i := 0; i < b.rows; i++ {
+		for j := 0; j < b.cols; j++ {
+			n := 0
+			for d := -r; d <= r; d++ {
+				w := e.widths[max(d, -d)]
+				row := e.sums[(i+r+d)*stride:]
+				n += int(row[j+r+w+1] - row[j+r-w])
+			}
+			state := b.get(i, j)
+			if state == 1 && !e.rule.middle {
+				n--
+			}
+			e.next.set(i, j, e.rule.next(state, n))
+		}
+	}
+	e.cur, e.next = e.next, e.cur
+}
+
+func (e *stateEngine) advance(generations int) error {
+	for i := 0; i < generations; i++ {
+		e.step()
+	}
+	return nil
+}
+
+// board Returns the board of the live cells
+func (e *stateEngine) board() *bitBoard {
+	return e.cur.live()
+}
+
+// cells Returns the current board, with the state of every cell
+func (e *stateEngine) cells() *stateBoard {
+	return e.cur
+}
+
+func (e *stateEngine) String() string {
+	return e.cur.String()
+}
+
+func (e *stateEngine) population() int {
+	n := 0
+	for _, state := range e.cur.cells {
+		if state == 1 {
+			n++
+		}
+	}
+	return n
+}
+
+func (e *stateEngine) appendState(buf []byte) ([]byte, bool) {
+	return e.cur.appendCells(buf), true
+}
+
+func (e *stateEngine) close() {}
+
+// maxCycleBytes is the largest size of the states kept by a cycleFinder. The generations past it aren't recorded,
+// so a cycle returning to one of them isn't found and the game runs every generation instead.
+const maxCycleBytes = 64 << 20
+
+// recordedState is the state of the engine at a generation
+type recordedState struct {
+	generation int
+	state      []byte
+}
+
+// cycleFinder finds the first generation of a game repeating an earlier generation. The states are looked up
+// by their hash, and the whole states with the same hash are compared, so that a collision isn't a cycle.
+type cycleFinder struct {
+	seed maphash.Seed
+	seen map[uint64][]recordedState
+	// size is the number of bytes of the recorded states
+	size int
+	// buf holds the state of the current generation
+	buf []byte
+}
+
+func newCycleFinder() *cycleFinder {
+	return &cycleFinder{seed: maphash.MakeSeed(), seen: map[uint64][]recordedState{}}
+}
+
+// find Returns the earlier generation with the same state as the engine, or else records the state of the engine
+// at generation while the recorded states fit in maxCycleBytes
+func (f *cycleFinder) find(eng engine, generation int) (int, bool) {
+	state, ok := eng.appendState(f.buf[:0])
+	if !ok {
+		return 0, false
+	}
+	f.buf = state
+	h := maphash.Bytes(f.seed, state)
+	for _, recorded := range f.seen[h] {
+		if bytes.Equal(recorded.state, state) {
+			return recorded.generation, true
+		}
+	}
+	if f.size+len(state) <= maxCycleBytes {
+		f.seen[h] = append(f.seen[h], recordedState{generation: generation, state: bytes.Clone(state)})
+		f.size += len(state)
+	}
+	return 0, false
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
@@ -13,41 +14,65 @@ import (
 )
 
 // calcLiveNeighbors Calculates number of live neighbors for the cell at (row, col) on the given board
-func calcLiveNeighbors(row int, col int, board [][]int) int {
+func calcLiveNeighbors(row int, col int, board [][]int, topology gameoflifepb.Topology) int {
 	sum := 0
 	for i := row - 1; i <= row+1; i++ {
 		for j := col - 1; j <= col+1; j++ {
-			if i >= 0 && i < len(board) && j >= 0 && j < len(board[0]) && !(i == row && j == col) {
-				sum += board[i][j]
+			if i == row && j == col {
+				continue
+			}
+			if r, c, ok := wrapCoordinates(i, j, len(board), len(board[0]), topology); ok {
+				sum += board[r][c]
 			}
 		}
 	}
 	return sum
 }
 
-// executeRules Returns board resulting from executing rules on the given board
-func executeRules(fromBoard [][]int) [][]int {
+// wrapCoordinates Maps (row, col) onto a rows x cols board with the given topology.
+// Returns false if the coordinates fall off the edge of the board.
+func wrapCoordinates(row int, col int, rows int, cols int, topology gameoflifepb.Topology) (int, int, bool) {
+	if topology != gameoflifepb.Topology_BOUNDED {
+		col = (col%cols + cols) % cols
+	}
+	switch topology {
+	case gameoflifepb.Topology_TORUS:
+		row = (row%rows + rows) % rows
+	case gameoflifepb.Topology_KLEIN_BOTTLE:
+		if row < 0 || row >= rows {
+			// Crossing the top or bottom edge mirrors the columns
+			row = (row%rows + rows) % rows
+			col = cols - 1 - col
+		}
+	}
+	return row, col, row >= 0 && row < rows && col >= 0 && col < cols
+}
+
+// executeRules Returns board resulting from executing the given rule on the given board.
+// This is the reference implementation of the bitStepper used by Run.
+func executeRules(fromBoard [][]int, rule Rule, topology gameoflifepb.Topology) [][]int {
 	toBoard := copyBoard(fromBoard)
 	for i := 0; i < len(fromBoard); i++ {
 		for j := 0; j < len(fromBoard[0]); j++ {
-			liveNeighbors := calcLiveNeighbors(i, j, fromBoard)
-			fromCell := fromBoard[i][j]
-			if fromCell == 1 {
-				if liveNeighbors < 2 {
-					toBoard[i][j] = 0
-				} else if liveNeighbors <= 3 {
-					toBoard[i][j] = 1
-				} else {
-					toBoard[i][j] = 0
-				}
-			} else if fromCell == 0 && liveNeighbors == 3 {
-				toBoard[i][j] = 1
+			liveNeighbors := calcLiveNeighbors(i, j, fromBoard, topology)
+			if fromBoard[i][j] == 1 {
+				toBoard[i][j] = boolToCell(rule.Survive[liveNeighbors])
+			} else {
+				toBoard[i][j] = boolToCell(rule.Birth[liveNeighbors])
 			}
 		}
 	}
 	return toBoard
 }
 
+// boolToCell Returns 1 for a live cell and 0 for a dead one
+func boolToCell(alive bool) int {
+	if alive {
+		return 1
+	}
+	return 0
+}
+
 // copyBoard Returns deep copy of the given board
 func copyBoard(board [][]int) [][]int {
 	result := make([][]int, len(board))
@@ -58,24 +83,132 @@ func copyBoard(board [][]int) [][]int {
 	return result
 }
 
-// validateBoard Returns an error if the given board is not valid
-func validateBoard(board [][]int) error {
+// ValidationError is the error returned by Run when a field of the request is invalid
+type ValidationError struct {
+	// Field is the path of the invalid field in the request, e.g. board[1][2] for the cell in column 2 of row 1
+	Field string
+	Err   error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// invalidField Returns err as a ValidationError of the given field, unless it already is one
+func invalidField(field string, err error) error {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return err
+	}
+	return &ValidationError{Field: field, Err: err}
+}
+
+// validateBoard Returns a ValidationError of the first invalid row or cell if the given board is not valid
+// for a rule with the given number of states
+func validateBoard(board [][]int, states int) error {
 	if len(board) < 1 || len(board[0]) < 1 {
-		return errors.New("board size must be at least 1x1")
+		return &ValidationError{Field: "board", Err: errors.New("board size must be at least 1x1")}
 	}
-	for _, row := range board {
+	for i, row := range board {
 		if len(row) != len(board[0]) {
-			return errors.New("board rows must be equal length")
+			return &ValidationError{
+				Field: fmt.Sprintf("board[%d]", i),
+				Err:   fmt.Errorf("board rows must be equal length, row %d has %d cells instead of %d", i, len(row), len(board[0])),
+			}
 		}
-		for _, cell := range row {
-			if cell != 0 && cell != 1 {
-				return errors.New("cells can only be 0's or 1's")
+		for j, cell := range row {
+			if cell < 0 || cell >= states {
+				err := fmt.Errorf("cells can only be 0's or 1's, cell (%d, %d) is %d", i, j, cell)
+				if states > 2 {
+					err = fmt.Errorf("cells can only be states 0 to %d, cell (%d, %d) is %d", states-1, i, j, cell)
+				}
+				return &ValidationError{Field: fmt.Sprintf("board[%d][%d]", i, j), Err: err}
 			}
 		}
 	}
 	return nil
 }
 
+// validateTopology Returns an error if the given topology is not known
+func validateTopology(topology gameoflifepb.Topology) error {
+	if _, ok := gameoflifepb.Topology_name[int32(topology)]; !ok {
+		return fmt.Errorf("unknown topology %d", topology)
+	}
+	return nil
+}
+
+// Rule is a Life-like rule: a dead cell is born if its number of live neighbors is in Birth,
+// and a live cell survives if its number of live neighbors is in Survive
+type Rule struct {
+	Birth   [9]bool
+	Survive [9]bool
+}
+
+// ConwayRule is the rule of Conway's Game of Life, B3/S23
+var ConwayRule = Rule{
+	Birth:   [9]bool{3: true},
+	Survive: [9]bool{2: true, 3: true},
+}
+
+// String Returns the rule in B/S notation
+func (r Rule) String() string {
+	var sb strings.Builder
+	sb.WriteString("B")
+	for n, born := range r.Birth {
+		if born {
+			sb.WriteString(strconv.Itoa(n))
+		}
+	}
+	sb.WriteString("/S")
+	for n, survives := range r.Survive {
+		if survives {
+			sb.WriteString(strconv.Itoa(n))
+		}
+	}
+	return sb.String()
+}
+
+// parseRule Parses a rulestring in B/S notation (e.g. B36/S23) or S/B notation (e.g. 23/36).
+// The empty string is Conway's Game of Life.
+func parseRule(rulestring string) (Rule, error) {
+	rulestring = strings.ToUpper(strings.TrimSpace(rulestring))
+	if rulestring == "" {
+		return ConwayRule, nil
+	}
+	parts := strings.Split(rulestring, "/")
+	if len(parts) != 2 {
+		return Rule{}, fmt.Errorf("rule %q must have the form B{digits}/S{digits}", rulestring)
+	}
+	if !strings.HasPrefix(parts[0], "B") && !strings.HasPrefix(parts[0], "S") {
+		// S/B notation without letters, e.g. 23/3
+		parts[0], parts[1] = "S"+parts[0], "B"+parts[1]
+	}
+
+	var rule Rule
+	seen := map[byte]bool{}
+	for _, part := range parts {
+		if part == "" || (part[0] != 'B' && part[0] != 'S') || seen[part[0]] {
+			return Rule{}, fmt.Errorf("rule %q must have the form B{digits}/S{digits}", rulestring)
+		}
+		seen[part[0]] = true
+		counts := &rule.Birth
+		if part[0] == 'S' {
+			counts = &rule.Survive
+		}
+		for _, c := range part[1:] {
+			if c < '0' || c > '8' {
+				return Rule{}, fmt.Errorf("rule %q has invalid neighbor count %q", rulestring, c)
+			}
+			counts[c-'0'] = true
+		}
+	}
+	return rule, nil
+}
+
 // parseBoard Parses board from given string and return 2D int slice
 func parseBoard(data string, logger *zap.Logger) ([][]int, error) {
 	board := make([][]int, 1)
@@ -86,46 +219,586 @@ func parseBoard(data string, logger *zap.Logger) ([][]int, error) {
 	return board, nil
 }
 
-func Run(ctx context.Context, gameRequest *gameoflifepb.GameRequest, logger *zap.Logger) (*gameoflifepb.GameResponse, error) {
-	fromBoard, err := parseBoard(gameRequest.Board, logger)
+// RequestRule Returns the rule the game request is run with. The rule of the request takes precedence
+// over the rule of the RLE header of its board or named pattern, and the empty string is Conway's Life.
+func RequestRule(gameRequest *gameoflifepb.GameRequest) string {
+	if gameRequest.Rule != "" {
+		return gameRequest.Rule
+	}
+	return headerRule(gameRequest)
+}
+
+// headerRule Returns the rule of the RLE header of the board or named pattern of the request,
+// or the empty string if it has none
+func headerRule(gameRequest *gameoflifepb.GameRequest) string {
+	var data string
+	switch {
+	case gameRequest.StructuredBoard != nil:
+		return ""
+	case gameRequest.PatternName != "":
+		p, ok := findPattern(gameRequest.PatternName)
+		if !ok {
+			return ""
+		}
+		data = p.rle
+	case gameRequest.RandomBoard != nil:
+		return ""
+	case gameRequest.Format == gameoflifepb.BoardFormat_RLE:
+		data = gameRequest.Board
+	default:
+		return ""
+	}
+	// Invalid headers are reported once the board is read
+	lines := strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+	i, err := rleHeaderLine(lines)
+	if err != nil {
+		return ""
+	}
+	_, _, rule, err := parseRLEHeader(lines[i])
+	if err != nil {
+		return ""
+	}
+	return rule
+}
+
+// readBoard Returns the board of the request for a rule with the given number of states, taken from the structured board
+// if it is set, placed from the named pattern or generated from the random board if they are set, and parsed from
+// the board in the format of the request otherwise. Boards of rules with more than 2 states are returned as a stateBoard
+// if their cells can have other states, and all other boards as a bitBoard.
+func readBoard(gameRequest *gameoflifepb.GameRequest, states int, logger *zap.Logger) (*bitBoard, *stateBoard, *gameoflifepb.GameResponse, error) {
+	if gameRequest.StructuredBoard != nil {
+		var board *bitBoard
+		var cells *stateBoard
+		var err error
+		if states > 2 {
+			cells, err = stateBoardFromProto(gameRequest.StructuredBoard, states)
+		} else {
+			board, err = bitBoardFromProto(gameRequest.StructuredBoard)
+		}
+		if err != nil {
+			logger.Error("Invalid structured board",
+				zap.Int32("width", gameRequest.StructuredBoard.GetWidth()),
+				zap.Int32("height", gameRequest.StructuredBoard.GetHeight()),
+				zap.Error(err),
+			)
+			return nil, nil, &gameoflifepb.GameResponse{
+				Code:         gameoflifepb.ResponseCode_BAD_REQUEST,
+				ErrorMessage: fmt.Sprintf("Invalid structured board: %v", err),
+			}, invalidField("structured_board", err)
+		}
+		return board, cells, nil, nil
+	}
+
+	err := validateFormat(gameRequest.Format)
+	if err == nil && states > 2 && gameRequest.Format == gameoflifepb.BoardFormat_PLAINTEXT {
+		err = errors.New("the plaintext format only supports rules with 2 states")
+	}
+	if err != nil {
+		logger.Error("Invalid format",
+			zap.Stringer("format", gameRequest.Format),
+			zap.Error(err),
+		)
+		return nil, nil, &gameoflifepb.GameResponse{
+			Code:         gameoflifepb.ResponseCode_BAD_REQUEST,
+			ErrorMessage: fmt.Sprintf("Invalid format: %v", gameRequest.Format),
+		}, invalidField("format", err)
+	}
+	if gameRequest.PatternName != "" {
+		board, _, err := placePattern(gameRequest.PatternName, gameRequest.Placement)
+		if err != nil {
+			logger.Error("Invalid pattern",
+				zap.String("patternName", gameRequest.PatternName),
+				zap.Any("placement", gameRequest.Placement),
+				zap.Error(err),
+			)
+			return nil, nil, &gameoflifepb.GameResponse{
+				Code:         gameoflifepb.ResponseCode_BAD_REQUEST,
+				ErrorMessage: fmt.Sprintf("Invalid pattern: %v", err),
+			}, invalidField("pattern_name", err)
+		}
+		return board, nil, nil, nil
+	}
+	if gameRequest.RandomBoard != nil {
+		board, err := randomBoard(gameRequest.RandomBoard)
+		if err != nil {
+			logger.Error("Invalid random board",
+				zap.Any("randomBoard", gameRequest.RandomBoard),
+				zap.Error(err),
+			)
+			return nil, nil, &gameoflifepb.GameResponse{
+				Code:         gameoflifepb.ResponseCode_BAD_REQUEST,
+				ErrorMessage: fmt.Sprintf("Invalid random board: %v", err),
+			}, invalidField("random_board", err)
+		}
+		return board, nil, nil, nil
+	}
+	if gameRequest.Format != gameoflifepb.BoardFormat_JSON {
+		var board *bitBoard
+		var cells *stateBoard
+		var err error
+		switch {
+		case gameRequest.Format == gameoflifepb.BoardFormat_RLE && states > 2:
+			cells, _, err = parseStateRLE(gameRequest.Board, states)
+		case gameRequest.Format == gameoflifepb.BoardFormat_RLE:
+			board, _, err = parseRLE(gameRequest.Board)
+		default:
+			board, err = parsePlaintext(gameRequest.Board)
+		}
+		if err != nil {
+			logger.Error("Failed to parse board",
+				zap.String("board", gameRequest.Board),
+				zap.Stringer("format", gameRequest.Format),
+				zap.Error(err),
+			)
+			return nil, nil, &gameoflifepb.GameResponse{
+				Code:         gameoflifepb.ResponseCode_BAD_REQUEST,
+				ErrorMessage: fmt.Sprintf("Failed to parse %v board: %v", gameRequest.Format, err),
+			}, invalidField("board", err)
+		}
+		return board, cells, nil, nil
+	}
+
+	cells, err := parseBoard(gameRequest.Board, logger)
 	if err != nil {
 		logger.Error("Failed to parse board",
 			zap.String("board", gameRequest.Board),
 			zap.Error(err),
 		)
-		return &gameoflifepb.GameResponse{
+		return nil, nil, &gameoflifepb.GameResponse{
 			Code:         gameoflifepb.ResponseCode_BAD_REQUEST,
 			ErrorMessage: fmt.Sprintf("Failed to parse: %v", gameRequest.Board),
-		}, err
+		}, invalidField("board", err)
 	}
-	err = validateBoard(fromBoard)
+	err = validateBoard(cells, states)
 	if err != nil {
 		logger.Error("Invalid board",
-			zap.Any("board", fromBoard),
+			zap.Any("board", cells),
 			zap.Error(err),
 		)
-		return &gameoflifepb.GameResponse{
+		return nil, nil, &gameoflifepb.GameResponse{
 			Code:         gameoflifepb.ResponseCode_BAD_REQUEST,
 			ErrorMessage: fmt.Sprintf("Invalid board: %v", gameRequest.Board),
 		}, err
 	}
-	var toBoard [][]int
+	if states > 2 {
+		return nil, stateBoardFromCells(cells), nil, nil
+	}
+	return bitBoardFromCells(cells), nil, nil, nil
+}
+
+// BoardSize Returns the number of rows and columns of the board of the request without building it,
+// so that the size of the board can be limited before running the game. Only the header of RLE patterns is read.
+func BoardSize(gameRequest *gameoflifepb.GameRequest) (rows int, cols int, err error) {
+	if board := gameRequest.StructuredBoard; board != nil {
+		return int(board.GetHeight()), int(board.GetWidth()), nil
+	}
+	if gameRequest.PatternName != "" {
+		p, ok := findPattern(gameRequest.PatternName)
+		if !ok {
+			return 0, 0, fmt.Errorf("unknown pattern %q", gameRequest.PatternName)
+		}
+		pattern, _, err := parseRLE(p.rle)
+		if err != nil {
+			return 0, 0, err
+		}
+		rows, cols := placementSize(pattern.rows, pattern.cols, gameRequest.Placement)
+		return rows, cols, nil
+	}
+	if random := gameRequest.RandomBoard; random != nil {
+		return int(random.GetHeight()), int(random.GetWidth()), nil
+	}
+	switch gameRequest.Format {
+	case gameoflifepb.BoardFormat_RLE:
+		lines := strings.Split(strings.ReplaceAll(gameRequest.Board, "\r\n", "\n"), "\n")
+		i, err := rleHeaderLine(lines)
+		if err != nil {
+			return 0, 0, err
+		}
+		cols, rows, _, err := parseRLEHeader(lines[i])
+		return rows, cols, err
+	case gameoflifepb.BoardFormat_PLAINTEXT:
+		lines, cols := plaintextRows(gameRequest.Board)
+		return len(lines), cols, nil
+	case gameoflifepb.BoardFormat_JSON:
+		var cells [][]int
+		if err := json.Unmarshal([]byte(gameRequest.Board), &cells); err != nil {
+			return 0, 0, err
+		}
+		for _, row := range cells {
+			cols = max(cols, len(row))
+		}
+		return len(cells), cols, nil
+	}
+	return 0, 0, validateFormat(gameRequest.Format)
+}
+
+// BoardCells Returns the state of every cell of a board returned by a game, in board in the given format or in structured
+// if it is set, so that clients can draw the board without parsing every format. Rows shorter than the longest row
+// are padded with dead cells.
+func BoardCells(board string, structured *gameoflifepb.Board, format gameoflifepb.BoardFormat) ([][]int, error) {
+	if structured != nil {
+		if structured.GetNumStates() > 2 {
+			b, err := stateBoardFromProto(structured, int(structured.GetNumStates()))
+			if err != nil {
+				return nil, err
+			}
+			return b.states(), nil
+		}
+		b, err := bitBoardFromProto(structured)
+		if err != nil {
+			return nil, err
+		}
+		return b.cells(), nil
+	}
+	switch format {
+	case gameoflifepb.BoardFormat_RLE:
+		b, _, err := parseStateRLE(board, maxStates)
+		if err != nil {
+			return nil, err
+		}
+		return b.states(), nil
+	case gameoflifepb.BoardFormat_PLAINTEXT:
+		b, err := parsePlaintext(board)
+		if err != nil {
+			return nil, err
+		}
+		return b.cells(), nil
+	case gameoflifepb.BoardFormat_JSON:
+		var cells [][]int
+		if err := json.Unmarshal([]byte(board), &cells); err != nil {
+			return nil, err
+		}
+		cols := 0
+		for _, row := range cells {
+			cols = max(cols, len(row))
+		}
+		for i, row := range cells {
+			cells[i] = append(row, make([]int, cols-len(row))...)
+		}
+		return cells, nil
+	}
+	return nil, validateFormat(format)
+}
+
+// GenerationFunc is called with every generation computed by RunStream
+type GenerationFunc func(frame *gameoflifepb.GenerationFrame) error
+
+// ProgressHook is called with every generation reached by a game, so it must be cheap
+type ProgressHook func(generation int)
+
+// SummaryHook is called with the statistics of the last generation reached by a game, whose births and deaths
+// are counted since generation 0
+type SummaryHook func(stats *gameoflifepb.GenerationStats)
+
+// runConfig holds configurations for running a game
+type runConfig struct {
+	workers       int
+	stripeHook    StripeHook
+	hashLifeStats *HashLifeStats
+	progressHook  ProgressHook
+	summaryHook   SummaryHook
+	// maxHashLifeNodes is the limit of nodes of a game run with the HASHLIFE engine, 0 for no limit
+	maxHashLifeNodes int
+}
+
+// Option is a function that alters the run config.
+type Option func(*runConfig)
+
+// WithWorkers steps every generation with n workers, each stepping a stripe of rows in parallel
+func WithWorkers(n int) Option {
+	return func(rc *runConfig) {
+		if n > 0 {
+			rc.workers = n
+		}
+	}
+}
+
+// WithStripeHook sets the hook called around every stripe stepped by a worker (only used with more than one worker)
+func WithStripeHook(hook StripeHook) Option {
+	return func(rc *runConfig) {
+		rc.stripeHook = hook
+	}
+}
+
+// WithHashLifeStats fills stats with the memoization statistics of games run with the HASHLIFE engine
+func WithHashLifeStats(stats *HashLifeStats) Option {
+	return func(rc *runConfig) {
+		rc.hashLifeStats = stats
+	}
+}
+
+// WithMaxHashLifeNodes limits the nodes of the quadtree of a game run with the HASHLIFE engine to n, which bounds
+// its memory. A game that needs more nodes fails with ErrNodeLimit.
+func WithMaxHashLifeNodes(n int) Option {
+	return func(rc *runConfig) {
+		rc.maxHashLifeNodes = n
+	}
+}
+
+// WithProgressHook sets the hook called with every generation reached by the game. With the HASHLIFE engine,
+// it is only called after every jump of a power of two generations unless the game is streamed.
+func WithProgressHook(hook ProgressHook) Option {
+	return func(rc *runConfig) {
+		rc.progressHook = hook
+	}
+}
+
+// WithSummaryHook sets the hook called with the statistics of the last generation reached by the game, whose births
+// and deaths are counted since generation 0 whether or not the request set generation_stats
+func WithSummaryHook(hook SummaryHook) Option {
+	return func(rc *runConfig) {
+		rc.summaryHook = hook
+	}
+}
+
+func Run(ctx context.Context, gameRequest *gameoflifepb.GameRequest, logger *zap.Logger, options ...Option) (*gameoflifepb.GameResponse, error) {
+	return run(ctx, gameRequest, logger, nil, options)
+}
+
+// RunStream Runs the game like Run, passing every generation to send, starting with the initial board at generation 0
+func RunStream(ctx context.Context, gameRequest *gameoflifepb.GameRequest, logger *zap.Logger, send GenerationFunc, options ...Option) (*gameoflifepb.GameResponse, error) {
+	return run(ctx, gameRequest, logger, send, options)
+}
+
+func run(ctx context.Context, gameRequest *gameoflifepb.GameRequest, logger *zap.Logger, send GenerationFunc, options []Option) (*gameoflifepb.GameResponse, error) {
+	cfg := &runConfig{workers: 1, progressHook: func(int) {}, summaryHook: func(*gameoflifepb.GenerationStats) {}}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	rulestring := RequestRule(gameRequest)
+	rule, err := parseStateRule(rulestring)
+	if err != nil {
+		logger.Error("Invalid rule",
+			zap.String("rule", rulestring),
+			zap.Error(err),
+		)
+		return &gameoflifepb.GameResponse{
+			Code:         gameoflifepb.ResponseCode_BAD_REQUEST,
+			ErrorMessage: fmt.Sprintf("Invalid rule: %v", rulestring),
+		}, invalidField("rule", err)
+	}
+	fromBoard, fromStates, errResponse, err := readBoard(gameRequest, rule.states, logger)
+	if err != nil {
+		return errResponse, err
+	}
+	err = validateTopology(gameRequest.Topology)
+	if err != nil {
+		logger.Error("Invalid topology",
+			zap.Stringer("topology", gameRequest.Topology),
+			zap.Error(err),
+		)
+		return &gameoflifepb.GameResponse{
+			Code:         gameoflifepb.ResponseCode_BAD_REQUEST,
+			ErrorMessage: fmt.Sprintf("Invalid topology: %v", gameRequest.Topology),
+		}, invalidField("topology", err)
+	}
+	// Life-like rules are run on bit-packed boards, and the other rules cell by cell
+	life, lifeLike := rule.lifeLike()
+	var eng engine
+	var cellEngine *stateEngine
+	switch gameRequest.Engine {
+	case gameoflifepb.Engine_STANDARD:
+		if lifeLike {
+			eng = newBitEngine(ctx, fromBoard, life, gameRequest.Topology, cfg)
+			break
+		}
+		if fromStates == nil {
+			fromStates = stateBoardFromBits(fromBoard)
+		}
+		cellEngine = newStateEngine(fromStates, rule, gameRequest.Topology)
+		eng = cellEngine
+	case gameoflifepb.Engine_HASHLIFE:
+		if !lifeLike {
+			err = errors.New("the hashlife engine only supports Life-like rules")
+		} else {
+			err = validateHashLife(life, gameRequest.Topology)
+		}
+		if err == nil {
+			eng = newHashLifeEngine(fromBoard, life, cfg)
+		}
+	default:
+		err = fmt.Errorf("unknown engine %d", gameRequest.Engine)
+	}
+	if err != nil {
+		logger.Error("Invalid engine",
+			zap.Stringer("engine", gameRequest.Engine),
+			zap.Error(err),
+		)
+		return &gameoflifepb.GameResponse{
+			Code:         gameoflifepb.ResponseCode_BAD_REQUEST,
+			ErrorMessage: fmt.Sprintf("Invalid engine: %v", err),
+		}, invalidField("engine", err)
+	}
+	defer eng.close()
+	numGens := int(gameRequest.NumGens)
+	keepStats := gameRequest.GenerationStats
+	if keepStats && numGens > maxGenerationStats {
+		err = fmt.Errorf("the stats of every generation are only kept for up to %d generations, the game has %d", maxGenerationStats, numGens)
+		logger.Error("Invalid generation stats", zap.Error(err))
+		return &gameoflifepb.GameResponse{
+			Code:         gameoflifepb.ResponseCode_BAD_REQUEST,
+			ErrorMessage: fmt.Sprintf("Invalid generation stats: %v", err),
+		}, invalidField("generation_stats", err)
+	}
+	// The response and frames hold the board in the same form as the request
+	structured := gameRequest.StructuredBoard != nil
+	sparse := len(gameRequest.StructuredBoard.GetLiveCells()) > 0
+	// protoBoard and appendCurrent Return the board in the form of the request, with the state of every cell
+	// for the rules run cell by cell
+	protoBoard := func(board *bitBoard) *gameoflifepb.Board {
+		if cellEngine != nil {
+			return cellEngine.cells().proto(sparse, rule.states)
+		}
+		return board.proto(sparse)
+	}
+	appendCurrent := func(buf []byte, board *bitBoard) []byte {
+		if cellEngine != nil {
+			return appendStateBoard(buf, cellEngine.cells(), gameRequest.Format, rule)
+		}
+		return appendBoard(buf, board, gameRequest.Format, life)
+	}
+	// buf is reused to format every frame of the stream
+	var buf []byte
+	newFrame := func(board *bitBoard, stats *gameoflifepb.GenerationStats) *gameoflifepb.GenerationFrame {
+		frame := &gameoflifepb.GenerationFrame{Generation: stats.Generation, Stats: stats}
+		if structured {
+			frame.StructuredBoard = protoBoard(board)
+		} else {
+			buf = appendCurrent(buf[:0], board)
+			frame.Board = string(buf)
+		}
+		return frame
+	}
+	initial := fromBoard
+	var initialString fmt.Stringer = fromBoard
+	if cellEngine != nil {
+		initial, initialString = cellEngine.board(), cellEngine
+	}
+	// recorder records the stats of the generations that are streamed or kept, and summary those of the last
+	// generation, with the births and deaths since generation 0
+	recorder, summary := &statsRecorder{}, &statsRecorder{}
+	stats := []*gameoflifepb.GenerationStats{recorder.record(0, initial)}
+	summary.record(0, initial)
+	// finishStats Passes the stats of the last generation to the summary hook, and adds them to the response
+	// unless the stats of that generation already are
+	finishStats := func(generation int) {
+		last := summary.record(generation, eng.board())
+		cfg.summaryHook(last)
+		if int(stats[len(stats)-1].Generation) != generation {
+			stats = append(stats, last)
+		}
+	}
 
 	logger.Info("Current board",
 		zap.Int("generation", 0),
-		zap.Any("board", fromBoard),
+		zap.Stringer("board", initialString),
 	)
-	for i := 1; i <= int(gameRequest.NumGens); i++ {
-		toBoard = executeRules(fromBoard)
-		fromBoard = copyBoard(toBoard)
-		logger.Info("Current board",
+	if send != nil {
+		if err := send(newFrame(initial, stats[0])); err != nil {
+			return nil, err
+		}
+	}
+	// newResponse Returns the response holding the current board
+	newResponse := func(code gameoflifepb.ResponseCode, finalGeneration int, period int) *gameoflifepb.GameResponse {
+		response := &gameoflifepb.GameResponse{
+			Code:            code,
+			FinalGeneration: int32(finalGeneration),
+			Period:          int32(period),
+			Extinct:         eng.population() == 0,
+			Stats:           stats,
+		}
+		if structured {
+			response.StructuredBoard = protoBoard(eng.board())
+		} else {
+			response.Board = string(appendCurrent(nil, eng.board()))
+		}
+		return response
+	}
+	// cancelled Returns the partial response of a game stopped by ctx after the given generation
+	cancelled := func(generation int) (*gameoflifepb.GameResponse, error) {
+		err := ctx.Err()
+		code := gameoflifepb.ResponseCode_CANCELLED
+		if errors.Is(err, context.DeadlineExceeded) {
+			code = gameoflifepb.ResponseCode_DEADLINE_EXCEEDED
+		}
+		logger.Warn("Game stopped before the last generation",
+			zap.Int("generation", generation),
+			zap.Stringer("code", code),
+			zap.Error(err),
+		)
+		finishStats(generation)
+		return newResponse(code, generation, 0), err
+	}
+
+	generation := 0
+	if send == nil && gameRequest.Engine == gameoflifepb.Engine_HASHLIFE {
+		// HashLife advances by all generations at once unless every generation is streamed,
+		// so ctx is checked between the power of two jumps making up the generations
+		for generation < numGens {
+			if ctx.Err() != nil {
+				return cancelled(generation)
+			}
+			jump := (numGens - generation) & -(numGens - generation)
+			if err := eng.advance(jump); err != nil {
+				logger.Error("Advancing the board", zap.Int("generation", generation), zap.Error(err))
+				return nil, err
+			}
+			generation += jump
+			cfg.progressHook(generation)
+		}
+	}
+	cycles := newCycleFinder()
+	cycles.find(eng, generation)
+	finalGeneration, period := numGens, 0
+	for i := generation + 1; i <= numGens; i++ {
+		if ctx.Err() != nil {
+			return cancelled(i - 1)
+		}
+		if err := eng.advance(1); err != nil {
+			logger.Error("Advancing the board", zap.Int("generation", i-1), zap.Error(err))
+			return nil, err
+		}
+		cfg.progressHook(i)
+		// Boards are only formatted when debug logging is enabled
+		logger.Debug("Current board",
 			zap.Int("generation", i),
-			zap.Any("board", toBoard),
+			zap.Stringer("board", eng),
 		)
+		if send != nil || keepStats {
+			board := eng.board()
+			current := recorder.record(i, board)
+			if keepStats {
+				stats = append(stats, current)
+			}
+			if send != nil {
+				if err := send(newFrame(board, current)); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if first, found := cycles.find(eng, i); found {
+			finalGeneration, period = i, i-first
+			logger.Info("Found repeated board",
+				zap.Int("generation", i),
+				zap.Int("firstGeneration", first),
+				zap.Int("period", period),
+			)
+			break
+		}
 	}
+	finishStats(finalGeneration)
+	if period > 0 {
+		// The board repeats every period generations, so the remaining generations only move it along the cycle
+		if err := eng.advance((numGens - finalGeneration) % period); err != nil {
+			logger.Error("Advancing the board", zap.Int("generation", finalGeneration), zap.Error(err))
+			return nil, err
+		}
+	}
+	logger.Info("Final board",
+		zap.Int32("generation", gameRequest.NumGens),
+		zap.Stringer("board", eng),
+	)
 
-	return &gameoflifepb.GameResponse{
-		Code:  gameoflifepb.ResponseCode_OK,
-		Board: strings.Join(strings.Fields(fmt.Sprint(toBoard)), ","),
-	}, nil
+	return newResponse(gameoflifepb.ResponseCode_OK, finalGeneration, period), nil
 }
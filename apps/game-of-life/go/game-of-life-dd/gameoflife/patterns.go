@@ -0,0 +1,117 @@
+package gameoflife
+
+import (
+	"errors"
+	"fmt"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+)
+
+// builtinPattern is a pattern of the catalog, in RLE format
+type builtinPattern struct {
+	name        string
+	description string
+	rle         string
+}
+
+// builtinPatterns is the catalog of patterns that can be run by name, sorted by name
+var builtinPatterns = []builtinPattern{
+	{"blinker", "Period 2 oscillator, the smallest and most common oscillator",
+		"x = 3, y = 1, rule = B3/S23\n3o!\n"},
+	{"glider", "Smallest spaceship, moving one cell down and to the right every 4 generations",
+		"x = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n"},
+	{"gosper-glider-gun", "First known gun, emitting a glider every 30 generations",
+		"x = 36, y = 9, rule = B3/S23\n24bo$22bobo$12b2o6b2o12b2o$11bo3bo4b2o12b2o$2o8bo5bo3b2o$2o8bo3bob2o4bobo$10bo5bo7bo$11bo3bo$12b2o!\n"},
+	{"lwss", "Lightweight spaceship, moving two cells to the left every 4 generations",
+		"x = 5, y = 4, rule = B3/S23\nbo2bo$o4b$o3bo$4o!\n"},
+	{"pulsar", "Period 3 oscillator with four-fold symmetry",
+		"x = 13, y = 13, rule = B3/S23\n2b3o3b3o2$o4bobo4bo$o4bobo4bo$o4bobo4bo$2b3o3b3o2$2b3o3b3o$o4bobo4bo$o4bobo4bo$o4bobo4bo2$2b3o3b3o!\n"},
+	{"r-pentomino", "Methuselah of 5 cells that stabilizes after 1103 generations",
+		"x = 3, y = 3, rule = B3/S23\nb2o$2o$bo!\n"},
+}
+
+// findPattern Returns the built-in pattern with the given name, and false if there is none
+func findPattern(name string) (builtinPattern, bool) {
+	for _, p := range builtinPatterns {
+		if p.name == name {
+			return p, true
+		}
+	}
+	return builtinPattern{}, false
+}
+
+// proto Returns the pattern as a Pattern message
+func (p builtinPattern) proto() *gameoflifepb.Pattern {
+	board, _, err := parseRLE(p.rle)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in pattern %s: %v", p.name, err))
+	}
+	return &gameoflifepb.Pattern{
+		Name:        p.name,
+		Description: p.description,
+		Rle:         p.rle,
+		Board:       board.proto(true),
+	}
+}
+
+// Patterns Returns the built-in patterns, sorted by name
+func Patterns() []*gameoflifepb.Pattern {
+	patterns := make([]*gameoflifepb.Pattern, 0, len(builtinPatterns))
+	for _, p := range builtinPatterns {
+		patterns = append(patterns, p.proto())
+	}
+	return patterns
+}
+
+// GetPattern Returns the built-in pattern with the given name, and false if there is none
+func GetPattern(name string) (*gameoflifepb.Pattern, bool) {
+	p, ok := findPattern(name)
+	if !ok {
+		return nil, false
+	}
+	return p.proto(), true
+}
+
+// placementSize Returns the size of the board of a pattern of rows x cols cells placed by placement
+func placementSize(rows int, cols int, placement *gameoflifepb.PatternPlacement) (int, int) {
+	height, width := int(placement.GetHeight()), int(placement.GetWidth())
+	if height == 0 {
+		height = rows + 2*int(placement.GetRow())
+	}
+	if width == 0 {
+		width = cols + 2*int(placement.GetCol())
+	}
+	return height, width
+}
+
+// placePattern Returns the board of the named pattern placed by placement, along with the rule of the pattern
+func placePattern(name string, placement *gameoflifepb.PatternPlacement) (*bitBoard, string, error) {
+	p, ok := findPattern(name)
+	if !ok {
+		return nil, "", &ValidationError{Field: "pattern_name", Err: fmt.Errorf("unknown pattern %q", name)}
+	}
+	pattern, rule, err := parseRLE(p.rle)
+	if err != nil {
+		return nil, "", err
+	}
+	row, col := int(placement.GetRow()), int(placement.GetCol())
+	if row < 0 || col < 0 || placement.GetWidth() < 0 || placement.GetHeight() < 0 {
+		return nil, "", &ValidationError{Field: "placement", Err: errors.New("placement offsets and size must not be negative")}
+	}
+	rows, cols := placementSize(pattern.rows, pattern.cols, placement)
+	if row+pattern.rows > rows || col+pattern.cols > cols {
+		return nil, "", &ValidationError{
+			Field: "placement",
+			Err:   fmt.Errorf("pattern %s of %dx%d cells at (%d, %d) does not fit on a %dx%d board", name, pattern.cols, pattern.rows, row, col, cols, rows),
+		}
+	}
+	board := newBitBoard(rows, cols)
+	for i := 0; i < pattern.rows; i++ {
+		for j := 0; j < pattern.cols; j++ {
+			if pattern.get(i, j) {
+				board.set(row+i, col+j, true)
+			}
+		}
+	}
+	return board, rule, nil
+}
@@ -0,0 +1,137 @@
+package gameoflife
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPatterns(t *testing.T) {
+	patterns := Patterns()
+	var names []string
+	for _, p := range patterns {
+		names = append(names, p.Name)
+		if ans, ok := GetPattern(p.Name); !ok || !proto.Equal(ans, p) {
+			t.Errorf("Got %v %v, expected %v", ans, ok, p)
+		}
+	}
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("Got %v, expected sorted names", names)
+	}
+	if ans, ok := GetPattern("unknown"); ok {
+		t.Errorf("Got %v, expected no pattern", ans)
+	}
+
+	// The patterns behave as described once placed with enough room around them
+	var tests = []struct {
+		name       string
+		placement  *gameoflifepb.PatternPlacement
+		numGens    int32
+		population int32
+		period     int32
+	}{
+		{"blinker", &gameoflifepb.PatternPlacement{Row: 1, Col: 1}, 10, 3, 2},
+		{"glider", &gameoflifepb.PatternPlacement{Width: 10, Height: 10}, 4, 5, 0},
+		// The spaceship moves to the left
+		{"lwss", &gameoflifepb.PatternPlacement{Row: 2, Col: 10, Width: 20}, 4, 9, 0},
+		{"pulsar", &gameoflifepb.PatternPlacement{Row: 2, Col: 2}, 10, 48, 3},
+		// The gun emits its first glider of 5 cells after 30 generations
+		{"gosper-glider-gun", &gameoflifepb.PatternPlacement{Row: 1, Col: 1, Width: 50, Height: 30}, 30, 41, 0},
+		{"r-pentomino", &gameoflifepb.PatternPlacement{Row: 10, Col: 10}, 1, 6, 0},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%+v", &tt)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
+				PatternName: tt.name,
+				Placement:   tt.placement,
+				NumGens:     tt.numGens,
+			}, zaptest.NewLogger(t))
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			if last := ans.Stats[len(ans.Stats)-1]; last.Population != tt.population || ans.Period != tt.period {
+				t.Errorf("Got population %v and period %v, expected %v and %v", last.Population, ans.Period, tt.population, tt.period)
+			}
+		})
+	}
+}
+
+func TestPlacePattern(t *testing.T) {
+	var tests = []struct {
+		placement *gameoflifepb.PatternPlacement
+		cells     [][]int
+	}{
+		{nil, [][]int{{0, 1, 0}, {0, 0, 1}, {1, 1, 1}}},
+		{&gameoflifepb.PatternPlacement{Row: 1}, [][]int{{0, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, 1, 1}, {0, 0, 0}}},
+		{&gameoflifepb.PatternPlacement{Col: 1, Width: 4, Height: 4}, [][]int{{0, 0, 1, 0}, {0, 0, 0, 1}, {0, 1, 1, 1}, {0, 0, 0, 0}}},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v", tt.placement)
+		t.Run(testname, func(t *testing.T) {
+			b, rule, err := placePattern("glider", tt.placement)
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.cells, b.cells()) || rule != "B3/S23" {
+				t.Errorf("Got %v %v, expected %v B3/S23", b.cells(), rule, tt.cells)
+			}
+			rows, cols, err := BoardSize(&gameoflifepb.GameRequest{PatternName: "glider", Placement: tt.placement})
+			if err != nil || rows != len(tt.cells) || cols != len(tt.cells[0]) {
+				t.Errorf("Got %vx%v %v, expected %vx%v", rows, cols, err, len(tt.cells), len(tt.cells[0]))
+			}
+		})
+	}
+
+	var errorTests = []struct {
+		name      string
+		placement *gameoflifepb.PatternPlacement
+		field     string
+	}{
+		{"unknown", nil, "pattern_name"},
+		{"glider", &gameoflifepb.PatternPlacement{Row: -1}, "placement"},
+		{"glider", &gameoflifepb.PatternPlacement{Width: -1}, "placement"},
+		{"glider", &gameoflifepb.PatternPlacement{Col: 1, Width: 3}, "placement"},
+	}
+	for _, tt := range errorTests {
+		testname := fmt.Sprintf("%+v", &tt)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
+				PatternName: tt.name,
+				Placement:   tt.placement,
+				NumGens:     1,
+			}, zaptest.NewLogger(t))
+			var validationErr *ValidationError
+			if err == nil {
+				t.Errorf("Error not found: %v", err)
+			} else if ans.Code != gameoflifepb.ResponseCode_BAD_REQUEST {
+				t.Errorf("Got %v, expected %v", ans.Code, gameoflifepb.ResponseCode_BAD_REQUEST)
+			} else if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Errorf("Got %v, expected a ValidationError of %v", err, tt.field)
+			}
+		})
+	}
+
+	// The response board is in the format of the request, and the rule of the request takes precedence
+	ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
+		PatternName: "blinker",
+		Placement:   &gameoflifepb.PatternPlacement{Row: 1},
+		Format:      gameoflifepb.BoardFormat_RLE,
+		Rule:        "B36/S23",
+		NumGens:     1,
+	}, zaptest.NewLogger(t))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if expected := "x = 3, y = 3, rule = B36/S23\nbo$bo$bo!\n"; ans.Board != expected {
+		t.Errorf("Got %q, expected %q", ans.Board, expected)
+	}
+}
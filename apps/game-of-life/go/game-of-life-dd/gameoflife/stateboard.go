@@ -0,0 +1,208 @@
+package gameoflife
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+)
+
+// stateBoard is a rows x cols board of cells of any number of states, one byte per cell.
+// State 0 is dead, 1 is alive, and the higher states are the dying states of Generations rules.
+type stateBoard struct {
+	rows  int
+	cols  int
+	cells []uint8
+}
+
+// newStateBoard Returns an empty rows x cols board
+func newStateBoard(rows int, cols int) *stateBoard {
+	return &stateBoard{
+		rows:  rows,
+		cols:  cols,
+		cells: make([]uint8, rows*cols),
+	}
+}
+
+// stateBoardFromBits Returns a copy of the given board, with its live cells in state 1
+func stateBoardFromBits(b *bitBoard) *stateBoard {
+	s := newStateBoard(b.rows, b.cols)
+	for i := 0; i < b.rows; i++ {
+		for j := 0; j < b.cols; j++ {
+			if b.get(i, j) {
+				s.set(i, j, 1)
+			}
+		}
+	}
+	return s
+}
+
+// stateBoardFromCells Returns a copy of the given board, whose cells must be valid states
+func stateBoardFromCells(board [][]int) *stateBoard {
+	s := newStateBoard(len(board), len(board[0]))
+	for i, row := range board {
+		for j, cell := range row {
+			s.set(i, j, uint8(cell))
+		}
+	}
+	return s
+}
+
+// stateBoardFromProto Returns a copy of the given structured board, whose cells can have the given number of states
+func stateBoardFromProto(board *gameoflifepb.Board, states int) (*stateBoard, error) {
+	rows, cols := int(board.GetHeight()), int(board.GetWidth())
+	if rows < 1 || cols < 1 {
+		return nil, errors.New("board size must be at least 1x1")
+	}
+	set := 0
+	for _, n := range []int{len(board.GetRows()), len(board.GetLiveCells()), len(board.GetStates())} {
+		if n > 0 {
+			set++
+		}
+	}
+	if set > 1 {
+		return nil, errors.New("board can only have one of rows, live cells and states")
+	}
+	if len(board.GetRows()) > 0 {
+		b, err := bitBoardFromProto(board)
+		if err != nil {
+			return nil, err
+		}
+		return stateBoardFromBits(b), nil
+	}
+	s := newStateBoard(rows, cols)
+	for _, cell := range board.GetLiveCells() {
+		row, col, state := int(cell.GetRow()), int(cell.GetCol()), max(int(cell.GetState()), 1)
+		if row < 0 || row >= rows || col < 0 || col >= cols {
+			return nil, fmt.Errorf("live cell (%d, %d) is outside of the board", row, col)
+		}
+		if state >= states {
+			return nil, fmt.Errorf("cells can only be states 0 to %d, cell (%d, %d) is %d", states-1, row, col, state)
+		}
+		s.set(row, col, uint8(state))
+	}
+	if len(board.GetStates()) > 0 {
+		if len(board.GetStates()) != rows*cols {
+			return nil, fmt.Errorf("board has %d states, expected %d", len(board.GetStates()), rows*cols)
+		}
+		copy(s.cells, board.GetStates())
+		if err := s.validate(states); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (b *stateBoard) get(row int, col int) uint8 {
+	return b.cells[row*b.cols+col]
+}
+
+func (b *stateBoard) set(row int, col int, state uint8) {
+	b.cells[row*b.cols+col] = state
+}
+
+// validate Returns an error if a cell has a state past the given number of states
+func (b *stateBoard) validate(states int) error {
+	for k, state := range b.cells {
+		if int(state) >= states {
+			return fmt.Errorf("cells can only be states 0 to %d, cell (%d, %d) is %d", states-1, k/b.cols, k%b.cols, state)
+		}
+	}
+	return nil
+}
+
+// live Returns the board of the live cells, which are the cells in state 1
+func (b *stateBoard) live() *bitBoard {
+	l := newBitBoard(b.rows, b.cols)
+	for k, state := range b.cells {
+		if state == 1 {
+			l.set(k/b.cols, k%b.cols, true)
+		}
+	}
+	return l
+}
+
+// appendCells Appends the state of every cell of the board to buf
+func (b *stateBoard) appendCells(buf []byte) []byte {
+	return append(buf, b.cells...)
+}
+
+// proto Returns the board as a structured board of cells with the given number of states, with the non-dead cells
+// listed if sparse is true, packed rows for two-state boards, and the state of every cell otherwise
+func (b *stateBoard) proto(sparse bool, states int) *gameoflifepb.Board {
+	if states == 2 && !sparse {
+		return b.live().proto(false)
+	}
+	board := &gameoflifepb.Board{
+		Width:  int32(b.cols),
+		Height: int32(b.rows),
+	}
+	if states > 2 {
+		board.NumStates = int32(states)
+	}
+	if !sparse {
+		board.States = append([]byte(nil), b.cells...)
+		return board
+	}
+	board.LiveCells = []*gameoflifepb.Cell{}
+	for k, state := range b.cells {
+		if state == 0 {
+			continue
+		}
+		cell := &gameoflifepb.Cell{Row: int32(k / b.cols), Col: int32(k % b.cols)}
+		if state > 1 {
+			cell.State = int32(state)
+		}
+		board.LiveCells = append(board.LiveCells, cell)
+	}
+	return board
+}
+
+// states Returns the states of the cells as a 2D int slice
+func (b *stateBoard) states() [][]int {
+	board := make([][]int, b.rows)
+	for i := range board {
+		board[i] = make([]int, b.cols)
+		for j := range board[i] {
+			board[i][j] = int(b.get(i, j))
+		}
+	}
+	return board
+}
+
+// appendJSON Appends the board to buf as a JSON 2D array of the states of the cells, e.g. [[0,1],[2,0]]
+func (b *stateBoard) appendJSON(buf []byte) []byte {
+	buf = append(buf, '[')
+	for i := 0; i < b.rows; i++ {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, '[')
+		for j := 0; j < b.cols; j++ {
+			if j > 0 {
+				buf = append(buf, ',')
+			}
+			buf = strconv.AppendUint(buf, uint64(b.get(i, j)), 10)
+		}
+		buf = append(buf, ']')
+	}
+	return append(buf, ']')
+}
+
+// String Returns the board as a JSON 2D array of the states of the cells
+func (b *stateBoard) String() string {
+	return string(b.appendJSON(make([]byte, 0, b.rows*(2*b.cols+2)+2)))
+}
+
+// appendStateBoard Appends the board to buf in the given format. The rule is written in the header of RLE patterns,
+// and the plaintext format is only used for two-state rules.
+func appendStateBoard(buf []byte, b *stateBoard, format gameoflifepb.BoardFormat, rule stateRule) []byte {
+	switch format {
+	case gameoflifepb.BoardFormat_RLE:
+		return appendRLECells(buf, b.rows, b.cols, rule.String(), rule.states, b.get)
+	case gameoflifepb.BoardFormat_PLAINTEXT:
+		return appendPlaintext(buf, b.live())
+	}
+	return b.appendJSON(buf)
+}
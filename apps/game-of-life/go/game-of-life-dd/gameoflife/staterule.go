@@ -0,0 +1,285 @@
+package gameoflife
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxStates is the largest number of states of a rule, so that a cell fits in a byte
+	maxStates = 256
+	// maxRange is the largest range of the neighborhood of Larger than Life rules
+	maxRange = 50
+)
+
+// neighborhood is the shape of the cells around a cell that are counted as its neighbors
+type neighborhood int
+
+const (
+	// mooreNeighborhood is the square of cells within the range of the cell
+	mooreNeighborhood neighborhood = iota
+	// vonNeumannNeighborhood is the diamond of cells within a Manhattan distance of the range of the cell
+	vonNeumannNeighborhood
+)
+
+// stateRule is a rule of any supported family: Life-like, Generations or Larger than Life, with a Moore
+// or von Neumann neighborhood. Life-like rules are run on bitBoards, and all other rules on stateBoards.
+type stateRule struct {
+	// states is the number of states of the cells. A live cell (state 1) that does not survive goes through
+	// the dying states 2 to states-1 before it is dead (state 0). Dying cells are not counted as live neighbors.
+	states       int
+	radius       int
+	neighborhood neighborhood
+	// middle is true if a live cell counts itself as its own neighbor
+	middle bool
+	// birth[n] and survive[n] are true if a cell with n live neighbors is born or survives
+	birth   []bool
+	survive []bool
+}
+
+// newStateRule Returns a rule without births or survivals for the given neighborhood
+func newStateRule(states int, radius int, shape neighborhood, middle bool) stateRule {
+	r := stateRule{
+		states:       states,
+		radius:       radius,
+		neighborhood: shape,
+		middle:       middle,
+	}
+	r.birth = make([]bool, r.size()+1)
+	r.survive = make([]bool, r.size()+1)
+	return r
+}
+
+// size Returns the number of cells counted as neighbors of a cell, including the cell itself if middle is set
+func (r stateRule) size() int {
+	n := (2*r.radius+1)*(2*r.radius+1) - 1
+	if r.neighborhood == vonNeumannNeighborhood {
+		n = 2 * r.radius * (r.radius + 1)
+	}
+	if r.middle {
+		n++
+	}
+	return n
+}
+
+// lifeLike Returns the rule as a Life-like rule if it is one, so that it can be run on a bitBoard
+func (r stateRule) lifeLike() (Rule, bool) {
+	if r.states != 2 || r.radius != 1 || r.neighborhood != mooreNeighborhood || r.middle {
+		return Rule{}, false
+	}
+	var rule Rule
+	copy(rule.Birth[:], r.birth)
+	copy(rule.Survive[:], r.survive)
+	return rule, true
+}
+
+// String Returns the rule in the notation of its family, e.g. B3/S23, B2/S/C3 or R5,C0,M1,S34..58,B34..45,NM
+func (r stateRule) String() string {
+	if r.radius > 1 || r.middle {
+		states, middle, shape := r.states, 0, "M"
+		if states == 2 {
+			states = 0
+		}
+		if r.middle {
+			middle = 1
+		}
+		if r.neighborhood == vonNeumannNeighborhood {
+			shape = "N"
+		}
+		return fmt.Sprintf("R%d,C%d,M%d,S%s,B%s,N%s", r.radius, states, middle, countRange(r.survive), countRange(r.birth), shape)
+	}
+	var sb strings.Builder
+	sb.WriteString("B")
+	for n, born := range r.birth {
+		if born {
+			sb.WriteString(strconv.Itoa(n))
+		}
+	}
+	sb.WriteString("/S")
+	for n, survives := range r.survive {
+		if survives {
+			sb.WriteString(strconv.Itoa(n))
+		}
+	}
+	if r.states > 2 {
+		fmt.Fprintf(&sb, "/C%d", r.states)
+	}
+	if r.neighborhood == vonNeumannNeighborhood {
+		sb.WriteString("V")
+	}
+	return sb.String()
+}
+
+// countRange Returns the neighbor counts of a Larger than Life rule as a range, e.g. 34..58
+func countRange(counts []bool) string {
+	first, last := -1, -1
+	for n, ok := range counts {
+		if ok {
+			if first < 0 {
+				first = n
+			}
+			last = n
+		}
+	}
+	if first < 0 {
+		// No count is in the range
+		return "1..0"
+	}
+	return fmt.Sprintf("%d..%d", first, last)
+}
+
+// next Returns the state of a cell of the given state with n live neighbors in the next generation
+func (r stateRule) next(state uint8, n int) uint8 {
+	switch {
+	case state == 0:
+		if r.birth[n] {
+			return 1
+		}
+		return 0
+	case state == 1 && r.survive[n]:
+		return 1
+	case int(state)+1 < r.states:
+		return state + 1
+	}
+	return 0
+}
+
+// parseStateRule Parses a rulestring of any supported family:
+//   - Life-like rules, as parsed by parseRule
+//   - Generations rules in S/B/C notation, e.g. /2/3 for Brian's Brain, or B/S/C notation, e.g. B2/S/C3
+//   - Larger than Life rules, e.g. R5,C0,M1,S34..58,B34..45,NM for Bosco's rule
+//
+// Life-like and Generations rules use the von Neumann neighborhood if the rulestring ends with V, e.g. B2/S013V.
+// The empty string is Conway's Game of Life.
+func parseStateRule(rulestring string) (stateRule, error) {
+	rulestring = strings.ToUpper(strings.TrimSpace(rulestring))
+	if strings.HasPrefix(rulestring, "R") && strings.Contains(rulestring, ",") {
+		return parseLargerThanLife(rulestring)
+	}
+
+	shape := mooreNeighborhood
+	lifeLike := rulestring
+	if strings.HasSuffix(rulestring, "V") {
+		shape = vonNeumannNeighborhood
+		lifeLike = strings.TrimSuffix(rulestring, "V")
+	}
+	states := 2
+	if parts := strings.Split(lifeLike, "/"); len(parts) == 3 {
+		var err error
+		states, lifeLike, err = splitGenerations(parts)
+		if err != nil {
+			return stateRule{}, fmt.Errorf("rule %q: %w", rulestring, err)
+		}
+	}
+	life, err := parseRule(lifeLike)
+	if err != nil {
+		return stateRule{}, err
+	}
+
+	r := newStateRule(states, 1, shape, false)
+	for n := range life.Birth {
+		if (life.Birth[n] || life.Survive[n]) && n > r.size() {
+			return stateRule{}, fmt.Errorf("rule %q has %d neighbors, but the neighborhood only has %d cells", rulestring, n, r.size())
+		}
+		if n <= r.size() {
+			r.birth[n], r.survive[n] = life.Birth[n], life.Survive[n]
+		}
+	}
+	return r, nil
+}
+
+// splitGenerations Returns the number of states and the B/S part of a Generations rule split on its slashes
+func splitGenerations(parts []string) (int, string, error) {
+	var birth, survive, count string
+	if strings.IndexAny(parts[0]+parts[1]+parts[2], "BSCG") < 0 {
+		// S/B/C notation without letters, e.g. 345/2/4
+		survive, birth, count = parts[0], parts[1], parts[2]
+	} else {
+		seen := map[byte]bool{}
+		for _, part := range parts {
+			if part == "" || strings.IndexByte("BSCG", part[0]) < 0 {
+				return 0, "", errors.New("must have the form B{digits}/S{digits}/C{states}")
+			}
+			key := part[0]
+			if key == 'G' {
+				key = 'C'
+			}
+			if seen[key] {
+				return 0, "", errors.New("must have the form B{digits}/S{digits}/C{states}")
+			}
+			seen[key] = true
+			switch key {
+			case 'B':
+				birth = part[1:]
+			case 'S':
+				survive = part[1:]
+			case 'C':
+				count = part[1:]
+			}
+		}
+	}
+	states, err := strconv.Atoi(count)
+	if err != nil || states < 2 || states > maxStates {
+		return 0, "", fmt.Errorf("number of states %q must be between 2 and %d", count, maxStates)
+	}
+	return states, "B" + birth + "/S" + survive, nil
+}
+
+// parseLargerThanLife Parses a Larger than Life rule, e.g. R5,C0,M1,S34..58,B34..45,NM, where R is the range,
+// C the number of states (0 for 2 states), M1 counts a cell as its own neighbor, S and B are the ranges of live
+// neighbors for survivals and births, and N the neighborhood: M for Moore (the default) or N for von Neumann.
+func parseLargerThanLife(rulestring string) (stateRule, error) {
+	fields := map[byte]string{}
+	for _, field := range strings.Split(rulestring, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || strings.IndexByte("RCMSBN", field[0]) < 0 {
+			return stateRule{}, fmt.Errorf("rule %q has unknown field %q", rulestring, field)
+		}
+		if _, ok := fields[field[0]]; ok {
+			return stateRule{}, fmt.Errorf("rule %q has more than one %c field", rulestring, field[0])
+		}
+		fields[field[0]] = field[1:]
+	}
+	for _, key := range "RCMSB" {
+		if _, ok := fields[byte(key)]; !ok {
+			return stateRule{}, fmt.Errorf("rule %q must have the form R{range},C{states},M{0|1},S{min..max},B{min..max}", rulestring)
+		}
+	}
+
+	radius, err := strconv.Atoi(fields['R'])
+	if err != nil || radius < 1 || radius > maxRange {
+		return stateRule{}, fmt.Errorf("rule %q must have a range between 1 and %d", rulestring, maxRange)
+	}
+	states, err := strconv.Atoi(fields['C'])
+	if err != nil || states == 1 || states < 0 || states > maxStates {
+		return stateRule{}, fmt.Errorf("rule %q must have 0 or between 2 and %d states", rulestring, maxStates)
+	}
+	states = max(states, 2)
+	if fields['M'] != "0" && fields['M'] != "1" {
+		return stateRule{}, fmt.Errorf("rule %q must have M0 or M1", rulestring)
+	}
+	shape := mooreNeighborhood
+	switch fields['N'] {
+	case "", "M":
+	case "N":
+		shape = vonNeumannNeighborhood
+	default:
+		return stateRule{}, fmt.Errorf("rule %q has unknown neighborhood %q", rulestring, fields['N'])
+	}
+
+	r := newStateRule(states, radius, shape, fields['M'] == "1")
+	for key, counts := range map[byte][]bool{'S': r.survive, 'B': r.birth} {
+		low, high, ok := strings.Cut(fields[key], "..")
+		first, err1 := strconv.Atoi(low)
+		last, err2 := strconv.Atoi(high)
+		if !ok || err1 != nil || err2 != nil || first < 0 || last >= len(counts) {
+			return stateRule{}, fmt.Errorf("rule %q must have %c{min..max} with counts between 0 and %d", rulestring, key, r.size())
+		}
+		for n := first; n <= last; n++ {
+			counts[n] = true
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,265 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Check Returns an error when the component it checks is not healthy
+type Check func(ctx context.Context) error
+
+// Result is the outcome of a check
+type Result struct {
+	Name       string  `json:"name"`
+	Healthy    bool    `json:"healthy"`
+	Error      string  `json:"error,omitempty"`
+	DurationMs float64 `json:"duration_ms"`
+	// Liveness is set for the checks that are also part of the liveness of the service
+	Liveness bool `json:"liveness,omitempty"`
+}
+
+// Report is the outcome of all the checks of a probe, healthy when every check is
+type Report struct {
+	Healthy   bool      `json:"healthy"`
+	Checks    []Result  `json:"checks"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// namedCheck is a check registered under a name
+type namedCheck struct {
+	name     string
+	check    Check
+	liveness bool
+}
+
+// cachedReport is the last report of a probe, reused until it is older than the TTL of the registry
+type cachedReport struct {
+	mu     sync.Mutex
+	report *Report
+}
+
+// Registry holds the checks registered by the components of a service, and runs them for its readiness and
+// liveness probes. Every check is part of the readiness of the service, and the checks registered with
+// RegisterLiveness are also part of its liveness, so that a service is only restarted for failures a restart can fix.
+type Registry struct {
+	ttl     time.Duration
+	timeout time.Duration
+	now     func() time.Time
+
+	mu     sync.Mutex
+	checks []namedCheck
+
+	readiness cachedReport
+	liveness  cachedReport
+}
+
+// Option is a function that alters the registry
+type Option func(*Registry)
+
+// WithCacheTTL Sets the time a report is reused for before the checks are run again, 5s by default. Probes
+// of the same kind arriving together share a single run of the checks.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(r *Registry) {
+		r.ttl = ttl
+	}
+}
+
+// WithCheckTimeout Sets the time every check has to complete before it is unhealthy, 2s by default
+func WithCheckTimeout(timeout time.Duration) Option {
+	return func(r *Registry) {
+		if timeout > 0 {
+			r.timeout = timeout
+		}
+	}
+}
+
+// NewRegistry Returns a registry without checks, which is healthy until checks are registered
+func NewRegistry(options ...Option) *Registry {
+	r := &Registry{
+		ttl:     5 * time.Second,
+		timeout: 2 * time.Second,
+		now:     time.Now,
+	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// Register Adds a check of the readiness of the service
+func (r *Registry) Register(name string, check Check) {
+	r.register(namedCheck{name: name, check: check})
+}
+
+// RegisterLiveness Adds a check of both the readiness and the liveness of the service
+func (r *Registry) RegisterLiveness(name string, check Check) {
+	r.register(namedCheck{name: name, check: check, liveness: true})
+}
+
+func (r *Registry) register(check namedCheck) {
+	r.mu.Lock()
+	r.checks = append(r.checks, check)
+	r.mu.Unlock()
+	// The cached reports don't have the new check
+	r.readiness.invalidate()
+	r.liveness.invalidate()
+}
+
+// Readiness Returns the report of every check, run at most once per TTL
+func (r *Registry) Readiness(ctx context.Context) Report {
+	return r.readiness.get(ctx, r, false)
+}
+
+// Liveness Returns the report of the liveness checks, run at most once per TTL
+func (r *Registry) Liveness(ctx context.Context) Report {
+	return r.liveness.get(ctx, r, true)
+}
+
+func (c *cachedReport) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.report = nil
+}
+
+// get Returns the cached report while it is fresh, or else runs the checks. The lock is held while the checks
+// run so that concurrent probes wait for the same run, which isn't cancelled with the probe that started it.
+func (c *cachedReport) get(ctx context.Context, r *Registry, liveness bool) Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.report != nil && r.now().Sub(c.report.CheckedAt) < r.ttl {
+		return *c.report
+	}
+	report := r.run(context.WithoutCancel(ctx), liveness)
+	c.report = &report
+	return report
+}
+
+// run Runs the checks concurrently, only the liveness ones if liveness is set, each with the timeout of the registry
+func (r *Registry) run(ctx context.Context, liveness bool) Report {
+	r.mu.Lock()
+	var checks []namedCheck
+	for _, check := range r.checks {
+		if check.liveness || !liveness {
+			checks = append(checks, check)
+		}
+	}
+	r.mu.Unlock()
+
+	report := Report{Healthy: true, Checks: make([]Result, len(checks)), CheckedAt: r.now()}
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = r.runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+	for _, result := range report.Checks {
+		report.Healthy = report.Healthy && result.Healthy
+	}
+	return report
+}
+
+// runCheck Runs the check with the timeout of the registry. A check that doesn't return in time is unhealthy,
+// and is left to return in the background.
+func (r *Registry) runCheck(ctx context.Context, check namedCheck) Result {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.check(ctx)
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %v: %w", r.timeout, ctx.Err())
+	}
+	result := Result{
+		Name:       check.name,
+		Healthy:    err == nil,
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+		Liveness:   check.liveness,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// WriteReport Writes the report of a probe as JSON, with its overall result under key, such as readiness, and
+// the status code 200 if it is healthy and 503 otherwise
+func WriteReport(w http.ResponseWriter, key string, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	if report.Healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		key:          report.Healthy,
+		"checks":     report.Checks,
+		"checked_at": report.CheckedAt,
+	})
+}
+
+// ServingStatus Returns the gRPC health status of the report
+func ServingStatus(report Report) healthpb.HealthCheckResponse_ServingStatus {
+	if report.Healthy {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+// UpdateServingStatus Sets the serving status of the services of the gRPC health server, "" being the overall
+// status of the server, from the readiness of the registry every interval until ctx is done
+func (r *Registry) UpdateServingStatus(ctx context.Context, server *grpchealth.Server, interval time.Duration, services ...string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		status := ServingStatus(r.Readiness(ctx))
+		server.SetServingStatus("", status)
+		for _, service := range services {
+			server.SetServingStatus(service, status)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Recorder holds the result of the last run of a recurring operation, such as an export of telemetry, as a check
+type Recorder struct {
+	mu  sync.Mutex
+	err error
+	at  time.Time
+}
+
+// Record Records the result of a run of the operation
+func (r *Recorder) Record(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = err
+	r.at = time.Now()
+}
+
+// Check Returns the error of the last run of the operation, and is healthy until the operation first runs
+func (r *Recorder) Check(context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return fmt.Errorf("last run at %v failed: %w", r.at.Format(time.RFC3339), r.err)
+	}
+	return nil
+}
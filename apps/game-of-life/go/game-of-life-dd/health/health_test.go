@@ -0,0 +1,167 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	if report := r.Readiness(context.Background()); !report.Healthy || len(report.Checks) != 0 {
+		t.Errorf("Got %v, expected a healthy report without checks", report)
+	}
+
+	r.RegisterLiveness("engine", func(context.Context) error { return nil })
+	r.Register("server", func(context.Context) error { return errors.New("connection refused") })
+
+	readiness := r.Readiness(context.Background())
+	if readiness.Healthy || len(readiness.Checks) != 2 {
+		t.Fatalf("Got %v, expected an unhealthy report with 2 checks", readiness)
+	}
+	if got := readiness.Checks[0]; got.Name != "engine" || !got.Healthy || !got.Liveness {
+		t.Errorf("Got %v, expected a healthy liveness check", got)
+	}
+	if got := readiness.Checks[1]; got.Name != "server" || got.Healthy || got.Error != "connection refused" {
+		t.Errorf("Got %v, expected the error of the check", got)
+	}
+
+	// The liveness only has the checks registered for it
+	liveness := r.Liveness(context.Background())
+	if !liveness.Healthy || len(liveness.Checks) != 1 {
+		t.Errorf("Got %v, expected a healthy report with 1 check", liveness)
+	}
+}
+
+func TestRegistryCache(t *testing.T) {
+	now := time.Now()
+	r := NewRegistry(WithCacheTTL(time.Second))
+	r.now = func() time.Time { return now }
+	var runs atomic.Int32
+	r.Register("counter", func(context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	r.Readiness(context.Background())
+	now = now.Add(500 * time.Millisecond)
+	r.Readiness(context.Background())
+	if got := runs.Load(); got != 1 {
+		t.Errorf("Got %v runs, expected the report to be cached", got)
+	}
+	now = now.Add(time.Second)
+	r.Readiness(context.Background())
+	if got := runs.Load(); got != 2 {
+		t.Errorf("Got %v runs, expected the checks to run again after the TTL", got)
+	}
+
+	// Registering a check invalidates the cached report
+	r.Register("other", func(context.Context) error { return nil })
+	if report := r.Readiness(context.Background()); len(report.Checks) != 2 {
+		t.Errorf("Got %v, expected 2 checks", report)
+	}
+}
+
+func TestRegistryTimeout(t *testing.T) {
+	r := NewRegistry(WithCheckTimeout(10 * time.Millisecond))
+	block := make(chan struct{})
+	defer close(block)
+	r.Register("stuck", func(context.Context) error {
+		<-block
+		return nil
+	})
+	report := r.Readiness(context.Background())
+	if report.Healthy || report.Checks[0].Error == "" {
+		t.Errorf("Got %v, expected the check to time out", report)
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	var tests = []struct {
+		healthy bool
+		code    int
+	}{
+		{true, http.StatusOK},
+		{false, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		wr := httptest.NewRecorder()
+		WriteReport(wr, "readiness", Report{Healthy: tt.healthy, Checks: []Result{{Name: "check", Healthy: tt.healthy}}})
+		if wr.Code != tt.code {
+			t.Errorf("Got %v, expected %v", wr.Code, tt.code)
+		}
+		var resp struct {
+			Readiness bool     `json:"readiness"`
+			Checks    []Result `json:"checks"`
+		}
+		if err := json.Unmarshal(wr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		if resp.Readiness != tt.healthy || len(resp.Checks) != 1 {
+			t.Errorf("Got %v, expected readiness %v with 1 check", resp, tt.healthy)
+		}
+	}
+}
+
+func TestUpdateServingStatus(t *testing.T) {
+	r := NewRegistry(WithCacheTTL(0))
+	var healthy atomic.Bool
+	r.Register("toggle", func(context.Context) error {
+		if !healthy.Load() {
+			return errors.New("not ready")
+		}
+		return nil
+	})
+	server := grpchealth.NewServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.UpdateServingStatus(ctx, server, time.Millisecond, "gameoflifepb.GameOfLife")
+		close(done)
+	}()
+
+	waitForStatus := func(want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for {
+			resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "gameoflifepb.GameOfLife"})
+			if err == nil && resp.Status == want {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Got %v, %v, expected %v", resp, err, want)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	waitForStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	healthy.Store(true)
+	waitForStatus(healthpb.HealthCheckResponse_SERVING)
+
+	cancel()
+	<-done
+}
+
+func TestRecorder(t *testing.T) {
+	var r Recorder
+	if err := r.Check(context.Background()); err != nil {
+		t.Errorf("Got %v, expected no error before the first run", err)
+	}
+	exportErr := errors.New("connection refused")
+	r.Record(exportErr)
+	if err := r.Check(context.Background()); !errors.Is(err, exportErr) {
+		t.Errorf("Got %v, expected %v", err, exportErr)
+	}
+	r.Record(nil)
+	if err := r.Check(context.Background()); err != nil {
+		t.Errorf("Got %v, expected no error", err)
+	}
+}
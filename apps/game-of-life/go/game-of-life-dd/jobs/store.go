@@ -0,0 +1,220 @@
+package jobs
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrFull is returned by Create when the store already holds its maximum number of jobs
+var ErrFull = errors.New("too many jobs")
+
+// Job is a game run in the background, updated by the goroutine running it
+type Job struct {
+	id string
+	// seq orders the jobs by creation
+	seq        uint64
+	request    *gameoflifepb.GameRequest
+	cancel     context.CancelFunc
+	createTime time.Time
+	now        func() time.Time
+	// generation is the last generation computed, updated on every generation of the game
+	generation atomic.Int32
+
+	mu         sync.Mutex
+	state      gameoflifepb.JobState
+	result     *gameoflifepb.GameResponse
+	status     *status.Status
+	finishTime time.Time
+}
+
+// ID Returns the id of the job
+func (j *Job) ID() string {
+	return j.id
+}
+
+// Start Marks the job as running once it has a worker
+func (j *Job) Start() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.state == gameoflifepb.JobState_JOB_PENDING {
+		j.state = gameoflifepb.JobState_JOB_RUNNING
+	}
+}
+
+// SetGeneration Records the last generation computed by the game, and can be used as its gameoflife.ProgressHook
+func (j *Job) SetGeneration(generation int) {
+	j.generation.Store(int32(generation))
+}
+
+// Finish Records the response and the gRPC status error of the game. The job is cancelled if err is Canceled,
+// and failed for any other error.
+func (j *Job) Finish(result *gameoflifepb.GameResponse, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.result = result
+	j.status = status.Convert(err)
+	j.finishTime = j.now()
+	switch j.status.Code() {
+	case codes.OK:
+		j.state = gameoflifepb.JobState_JOB_SUCCEEDED
+		j.generation.Store(j.request.GetNumGens())
+	case codes.Canceled:
+		j.state = gameoflifepb.JobState_JOB_CANCELLED
+		j.generation.Store(result.GetFinalGeneration())
+	default:
+		j.state = gameoflifepb.JobState_JOB_FAILED
+	}
+}
+
+// Proto Returns the current state of the job
+func (j *Job) Proto() *gameoflifepb.Job {
+	return j.proto(false)
+}
+
+// finished Returns true once the job succeeded, failed or was cancelled. j.mu must be held.
+func (j *Job) finished() bool {
+	return !j.finishTime.IsZero()
+}
+
+// proto Returns the current state of the job, without its request and result if summary is true
+func (j *Job) proto(summary bool) *gameoflifepb.Job {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	job := &gameoflifepb.Job{
+		Id:         j.id,
+		State:      j.state,
+		Generation: j.generation.Load(),
+		CreateTime: timestamppb.New(j.createTime),
+	}
+	if !summary {
+		job.Request = j.request
+		job.Result = j.result
+	}
+	if j.finished() {
+		job.FinishTime = timestamppb.New(j.finishTime)
+		job.StatusCode = int32(j.status.Code())
+		job.ErrorMessage = j.status.Message()
+	}
+	return job
+}
+
+// Store is an in-memory store of jobs, keeping up to a fixed number of jobs, each for a fixed time once finished
+type Store struct {
+	maxJobs int
+	ttl     time.Duration
+	now     func() time.Time
+
+	mu      sync.Mutex
+	jobs    map[string]*Job
+	lastSeq uint64
+}
+
+// NewStore Returns a store of up to maxJobs jobs, or any number of jobs if maxJobs is 0, keeping every
+// finished job for ttl, or until the server stops if ttl is 0
+func NewStore(maxJobs int, ttl time.Duration) *Store {
+	return &Store{
+		maxJobs: maxJobs,
+		ttl:     ttl,
+		now:     time.Now,
+		jobs:    make(map[string]*Job),
+	}
+}
+
+// Create Adds a pending job running the game request, stopped by cancel, or Returns ErrFull if the store is full
+func (s *Store) Create(request *gameoflifepb.GameRequest, cancel context.CancelFunc) (*Job, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeExpired()
+	if s.maxJobs > 0 && len(s.jobs) >= s.maxJobs {
+		return nil, ErrFull
+	}
+	s.lastSeq++
+	job := &Job{
+		id:         hex.EncodeToString(id),
+		seq:        s.lastSeq,
+		request:    request,
+		cancel:     cancel,
+		createTime: s.now(),
+		now:        s.now,
+	}
+	s.jobs[job.id] = job
+	return job, nil
+}
+
+// Get Returns the job with the given id, and false if there is none or it expired
+func (s *Store) Get(id string) (*gameoflifepb.Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeExpired()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, false
+	}
+	return job.proto(false), true
+}
+
+// Cancel Cancels the job with the given id, which stops at the end of the current generation, and Returns
+// the job, and false if there is none or it expired. Cancelling a finished job has no effect.
+func (s *Store) Cancel(id string) (*gameoflifepb.Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeExpired()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, false
+	}
+	job.cancel()
+	return job.proto(false), true
+}
+
+// List Returns every job from the oldest to the newest, without their requests and results
+func (s *Store) List() []*gameoflifepb.Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeExpired()
+	jobs := make([]*Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job)
+	}
+	slices.SortFunc(jobs, func(a *Job, b *Job) int {
+		return cmp.Compare(a.seq, b.seq)
+	})
+	summaries := make([]*gameoflifepb.Job, len(jobs))
+	for i, job := range jobs {
+		summaries[i] = job.proto(true)
+	}
+	return summaries
+}
+
+// removeExpired Removes the jobs finished for longer than the TTL. s.mu must be held.
+func (s *Store) removeExpired() {
+	if s.ttl <= 0 {
+		return
+	}
+	now := s.now()
+	for id, job := range s.jobs {
+		job.mu.Lock()
+		expired := job.finished() && !now.Before(job.finishTime.Add(s.ttl))
+		job.mu.Unlock()
+		if expired {
+			delete(s.jobs, id)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestJobFinish(t *testing.T) {
+	var tests = []struct {
+		err        error
+		result     *gameoflifepb.GameResponse
+		state      gameoflifepb.JobState
+		generation int32
+	}{
+		{nil, &gameoflifepb.GameResponse{FinalGeneration: 3}, gameoflifepb.JobState_JOB_SUCCEEDED, 10},
+		{status.Error(codes.Canceled, "context canceled"), &gameoflifepb.GameResponse{FinalGeneration: 3}, gameoflifepb.JobState_JOB_CANCELLED, 3},
+		{status.Error(codes.InvalidArgument, "board: invalid"), nil, gameoflifepb.JobState_JOB_FAILED, 0},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v", tt.state)
+		t.Run(testname, func(t *testing.T) {
+			s := NewStore(0, time.Minute)
+			job, err := s.Create(&gameoflifepb.GameRequest{NumGens: 10}, func() {})
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			job.Start()
+			if got, _ := s.Get(job.ID()); got.State != gameoflifepb.JobState_JOB_RUNNING || got.FinishTime != nil {
+				t.Errorf("Got %v, expected a running job", got)
+			}
+			job.Finish(tt.result, tt.err)
+			got, _ := s.Get(job.ID())
+			if got.State != tt.state || got.Generation != tt.generation || got.StatusCode != int32(status.Code(tt.err)) || got.FinishTime == nil {
+				t.Errorf("Got %v, expected %v at generation %v", got, tt.state, tt.generation)
+			}
+		})
+	}
+}
+
+func TestStore(t *testing.T) {
+	s := NewStore(2, time.Minute)
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	cancelled := false
+	first, err := s.Create(&gameoflifepb.GameRequest{NumGens: 1}, func() { cancelled = true })
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	second, err := s.Create(&gameoflifepb.GameRequest{NumGens: 2}, func() {})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if _, err := s.Create(&gameoflifepb.GameRequest{NumGens: 3}, func() {}); err != ErrFull {
+		t.Errorf("Got %v, expected %v", err, ErrFull)
+	}
+
+	// Jobs are listed in order of creation, without their requests
+	list := s.List()
+	if len(list) != 2 || list[0].Id != first.ID() || list[1].Id != second.ID() || list[0].Request != nil {
+		t.Errorf("Got %v, expected the summaries of %v and %v", list, first.ID(), second.ID())
+	}
+	if got, ok := s.Get(first.ID()); !ok || got.Request.GetNumGens() != 1 || got.State != gameoflifepb.JobState_JOB_PENDING {
+		t.Errorf("Got %v, expected the pending job %v", got, first.ID())
+	}
+	if _, ok := s.Get("unknown"); ok {
+		t.Errorf("Got a job for an unknown id")
+	}
+
+	if _, ok := s.Cancel(first.ID()); !ok || !cancelled {
+		t.Errorf("Got cancelled %v, expected the job to be cancelled", cancelled)
+	}
+	first.Finish(nil, status.Error(codes.Canceled, "context canceled"))
+
+	// Finished jobs expire after the TTL, making room for new jobs, while running jobs are kept
+	now = now.Add(time.Minute)
+	if _, ok := s.Get(first.ID()); ok {
+		t.Errorf("Got job %v, expected it to be expired", first.ID())
+	}
+	if _, ok := s.Get(second.ID()); !ok {
+		t.Errorf("Got no job %v, expected it to be kept until finished", second.ID())
+	}
+	if _, err := s.Create(&gameoflifepb.GameRequest{NumGens: 3}, func() {}); err != nil {
+		t.Errorf("Got %v, expected a new job", err)
+	}
+}
+
+func TestStoreCancelContext(t *testing.T) {
+	s := NewStore(0, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	job, err := s.Create(&gameoflifepb.GameRequest{}, cancel)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	s.Cancel(job.ID())
+	if ctx.Err() != context.Canceled {
+		t.Errorf("Got %v, expected %v", ctx.Err(), context.Canceled)
+	}
+}
@@ -0,0 +1,157 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseColor(t *testing.T) {
+	var tests = []struct {
+		s     string
+		color color.RGBA
+		ok    bool
+	}{
+		{"ff8800", color.RGBA{R: 0xff, G: 0x88, A: 0xff}, true},
+		{"#00Ff10", color.RGBA{G: 0xff, B: 0x10, A: 0xff}, true},
+		{"fff", color.RGBA{}, false},
+		{"ff88001", color.RGBA{}, false},
+		{"gg0000", color.RGBA{}, false},
+		{"", color.RGBA{}, false},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%q", tt.s)
+		t.Run(testname, func(t *testing.T) {
+			got, err := ParseColor(tt.s)
+			if (err == nil) != tt.ok || got != tt.color {
+				t.Errorf("Got %v, %v, expected %v", got, err, tt.color)
+			}
+		})
+	}
+}
+
+func TestPNG(t *testing.T) {
+	alive := color.RGBA{R: 0xff, A: 0xff}
+	dead := color.RGBA{B: 0xff, A: 0xff}
+	var buf bytes.Buffer
+	// A Generations board with a live cell and a dying cell, and a short row
+	cells := Board{{0, 1, 0}, {2}}
+	if err := PNG(&buf, cells, WithCellSize(4), WithAliveColor(alive), WithDeadColor(dead)); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if got := img.Bounds().Size(); got.X != 12 || got.Y != 8 {
+		t.Errorf("Got size %v, expected 12x8", got)
+	}
+
+	var tests = []struct {
+		x, y  int
+		color color.RGBA
+	}{
+		{0, 0, dead},
+		{4, 0, alive},
+		{7, 3, alive},
+		{8, 0, dead},
+		{0, 4, color.RGBA{R: 0x7f, B: 0x7f, A: 0xff}},
+		{4, 4, dead},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%d,%d", tt.x, tt.y)
+		t.Run(testname, func(t *testing.T) {
+			if got := color.RGBAModel.Convert(img.At(tt.x, tt.y)); got != tt.color {
+				t.Errorf("Got %v, expected %v", got, tt.color)
+			}
+		})
+	}
+}
+
+func TestSVG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := SVG(&buf, Board{{1, 0}, {0, 1}}, WithCellSize(10)); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	svg := buf.String()
+	for _, want := range []string{
+		`width="20" height="20"`,
+		`<rect width="20" height="20" fill="#ffffff"/>`,
+		`<rect x="0" y="0" width="10" height="10" fill="#000000"/>`,
+		`<rect x="10" y="10" width="10" height="10" fill="#000000"/>`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("Got %v, expected it to contain %v", svg, want)
+		}
+	}
+	if got := strings.Count(svg, "<rect"); got != 3 {
+		t.Errorf("Got %v rectangles, expected 3", got)
+	}
+}
+
+func TestGIF(t *testing.T) {
+	var buf bytes.Buffer
+	generations := []Board{
+		{{0, 1, 0}, {0, 1, 0}, {0, 1, 0}},
+		{{0, 0, 0}, {1, 1, 1}, {0, 0, 0}},
+	}
+	if err := GIF(&buf, generations, WithCellSize(2), WithFrameDelay(250*time.Millisecond)); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	animation, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if len(animation.Image) != 2 {
+		t.Fatalf("Got %v frames, expected 2", len(animation.Image))
+	}
+	for i, delay := range animation.Delay {
+		if delay != 25 {
+			t.Errorf("Got delay %v of frame %v, expected 25", delay, i)
+		}
+	}
+	if got := animation.Image[1].ColorIndexAt(0, 2); got != 1 {
+		t.Errorf("Got %v, expected the live cell of the second generation", got)
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	huge := make(Board, 2048)
+	for i := range huge {
+		huge[i] = make([]uint8, 2048)
+	}
+	var tests = []struct {
+		name string
+		err  error
+	}{
+		{"empty", PNG(&bytes.Buffer{}, nil)},
+		{"empty row", SVG(&bytes.Buffer{}, Board{{}})},
+		{"no generations", GIF(&bytes.Buffer{}, nil)},
+		{"too large", PNG(&bytes.Buffer{}, huge, WithCellSize(MaxCellSize))},
+		{"too many frames", GIF(&bytes.Buffer{}, []Board{huge, huge, huge, huge, huge})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				t.Errorf("Got no error, expected an error")
+			}
+		})
+	}
+
+	// The size of an animation is checked before its generations are computed
+	if err := CheckSize(2048, 2048, 5); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Got %v, expected %v", err, ErrTooLarge)
+	}
+	if err := CheckSize(2048, 2048, 1, WithCellSize(1)); err != nil {
+		t.Errorf("Error: %v", err)
+	}
+	if err := tests[3].err; !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Got %v, expected %v", err, ErrTooLarge)
+	}
+}
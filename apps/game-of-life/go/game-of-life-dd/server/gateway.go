@@ -0,0 +1,271 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// gatewayCall Calls an RPC of s with its request read by decode, which fills a message from the body of the
+// HTTP request r, and from the variables of the path of r
+type gatewayCall func(ctx context.Context, s gameoflifepb.GameOfLifeServer, decode func(proto.Message) error, r *http.Request) (proto.Message, error)
+
+// gatewayRoute is a REST endpoint of the gateway, mapped onto a unary RPC of the GameOfLife service
+type gatewayRoute struct {
+	method  string
+	pattern string
+	rpc     string
+	call    gatewayCall
+}
+
+// gatewayRoutes are the REST endpoints of the unary RPCs, mapped onto the proto like google.api.http rules:
+// the body of a POST is the request message, or its game for CreateSession, and the variables of the path
+// set the fields of the same name
+var gatewayRoutes = []gatewayRoute{
+	{http.MethodPost, "/v1/games:run", "RunGame", func(ctx context.Context, s gameoflifepb.GameOfLifeServer, decode func(proto.Message) error, _ *http.Request) (proto.Message, error) {
+		req := &gameoflifepb.GameRequest{}
+		if err := decode(req); err != nil {
+			return nil, err
+		}
+		return s.RunGame(ctx, req)
+	}},
+	{http.MethodPost, "/v1/games:batchRun", "RunGames", func(ctx context.Context, s gameoflifepb.GameOfLifeServer, decode func(proto.Message) error, _ *http.Request) (proto.Message, error) {
+		req := &gameoflifepb.BatchGameRequest{}
+		if err := decode(req); err != nil {
+			return nil, err
+		}
+		return s.RunGames(ctx, req)
+	}},
+	{http.MethodGet, "/v1/patterns", "ListPatterns", func(ctx context.Context, s gameoflifepb.GameOfLifeServer, _ func(proto.Message) error, _ *http.Request) (proto.Message, error) {
+		return s.ListPatterns(ctx, &gameoflifepb.ListPatternsRequest{})
+	}},
+	{http.MethodGet, "/v1/patterns/{name}", "GetPattern", func(ctx context.Context, s gameoflifepb.GameOfLifeServer, _ func(proto.Message) error, r *http.Request) (proto.Message, error) {
+		return s.GetPattern(ctx, &gameoflifepb.GetPatternRequest{Name: r.PathValue("name")})
+	}},
+	{http.MethodPost, "/v1/jobs", "SubmitGame", func(ctx context.Context, s gameoflifepb.GameOfLifeServer, decode func(proto.Message) error, _ *http.Request) (proto.Message, error) {
+		req := &gameoflifepb.GameRequest{}
+		if err := decode(req); err != nil {
+			return nil, err
+		}
+		return s.SubmitGame(ctx, req)
+	}},
+	{http.MethodGet, "/v1/jobs", "ListJobs", func(ctx context.Context, s gameoflifepb.GameOfLifeServer, _ func(proto.Message) error, _ *http.Request) (proto.Message, error) {
+		return s.ListJobs(ctx, &gameoflifepb.ListJobsRequest{})
+	}},
+	{http.MethodGet, "/v1/jobs/{id}", "GetJob", func(ctx context.Context, s gameoflifepb.GameOfLifeServer, _ func(proto.Message) error, r *http.Request) (proto.Message, error) {
+		return s.GetJob(ctx, &gameoflifepb.GetJobRequest{Id: r.PathValue("id")})
+	}},
+	{http.MethodPost, "/v1/jobs/{id}:cancel", "CancelJob", func(ctx context.Context, s gameoflifepb.GameOfLifeServer, _ func(proto.Message) error, r *http.Request) (proto.Message, error) {
+		return s.CancelJob(ctx, &gameoflifepb.CancelJobRequest{Id: r.PathValue("id")})
+	}},
+	{http.MethodPost, "/v1/sessions", "CreateSession", func(ctx context.Context, s gameoflifepb.GameOfLifeServer, decode func(proto.Message) error, _ *http.Request) (proto.Message, error) {
+		req := &gameoflifepb.CreateSessionRequest{Game: &gameoflifepb.GameRequest{}}
+		if err := decode(req.Game); err != nil {
+			return nil, err
+		}
+		return s.CreateSession(ctx, req)
+	}},
+	{http.MethodPost, "/v1/sessions/{session_id}:step", "Step", func(ctx context.Context, s gameoflifepb.GameOfLifeServer, decode func(proto.Message) error, r *http.Request) (proto.Message, error) {
+		req := &gameoflifepb.StepRequest{}
+		if err := decode(req); err != nil {
+			return nil, err
+		}
+		req.SessionId = r.PathValue("session_id")
+		return s.Step(ctx, req)
+	}},
+	{http.MethodGet, "/v1/sessions/{session_id}", "GetSession", func(ctx context.Context, s gameoflifepb.GameOfLifeServer, _ func(proto.Message) error, r *http.Request) (proto.Message, error) {
+		return s.GetSession(ctx, &gameoflifepb.GetSessionRequest{SessionId: r.PathValue("session_id")})
+	}},
+	{http.MethodDelete, "/v1/sessions/{session_id}", "DeleteSession", func(ctx context.Context, s gameoflifepb.GameOfLifeServer, _ func(proto.Message) error, r *http.Request) (proto.Message, error) {
+		return s.DeleteSession(ctx, &gameoflifepb.DeleteSessionRequest{SessionId: r.PathValue("session_id")})
+	}},
+}
+
+// streamRoute is the REST endpoint of RunGameStream, whose frames are written as newline-delimited JSON
+var streamRoute = gatewayRoute{method: http.MethodPost, pattern: "/v1/games:stream", rpc: "RunGameStream"}
+
+// newGateway Returns the REST/JSON gateway of the GameOfLife service, which calls the RPCs of s in-process so that
+// clients without gRPC support can use the server. The requests and responses are the messages of the RPCs in
+// the JSON mapping of protobuf, and errors are the JSON of their gRPC status with the matching HTTP status code.
+// Every request is traced by a span continuing the trace of its headers, which is the parent of the span of the RPC.
+func newGateway(s gameoflifepb.GameOfLifeServer) *runtime.ServeMux {
+	mux := runtime.NewServeMux()
+	for _, route := range gatewayRoutes {
+		if err := mux.HandlePath(route.method, route.pattern, traced(route, unaryHandler(mux, s, route))); err != nil {
+			logger.Fatal("Registering gateway route", zap.String("pattern", route.pattern), zap.Error(err))
+		}
+	}
+	if err := mux.HandlePath(streamRoute.method, streamRoute.pattern, traced(streamRoute, streamHandler(mux, s))); err != nil {
+		logger.Fatal("Registering gateway route", zap.String("pattern", streamRoute.pattern), zap.Error(err))
+	}
+	return mux
+}
+
+// traced Returns the handler of the route for the gateway mux, traced by a span named after the route that
+// continues the trace of the headers of the request. The variables of the path are set as the path values of the request.
+func traced(route gatewayRoute, handler http.Handler) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		for name, value := range pathParams {
+			r.SetPathValue(name, value)
+		}
+		spanContext, _ := tracer.Extract(tracer.HTTPHeadersCarrier(r.Header))
+		span := tracer.StartSpan(route.method+" "+route.pattern, tracer.ChildOf(spanContext))
+		defer span.Finish()
+		handler.ServeHTTP(w, r.WithContext(tracer.ContextWithSpan(r.Context(), span)))
+	}
+}
+
+// gatewayContext Returns the context the RPC of the route is called with for r, holding the headers of r as
+// incoming gRPC metadata
+func gatewayContext(mux *runtime.ServeMux, r *http.Request, route gatewayRoute) (context.Context, error) {
+	rpc := fmt.Sprintf("/%s/%s", gameoflifepb.GameOfLife_ServiceDesc.ServiceName, route.rpc)
+	if span, ok := tracer.SpanFromContext(r.Context()); ok {
+		span.SetTag("http.route", route.pattern)
+		span.SetTag("gateway.rpc", rpc)
+	}
+	ctx, err := runtime.AnnotateIncomingContext(r.Context(), mux, r, rpc, runtime.WithHTTPPathPattern(route.pattern))
+	if err != nil {
+		return nil, err
+	}
+	return runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{}), nil
+}
+
+// gatewayDecoder Returns the function decoding the body of r into a message with the inbound marshaler. An empty
+// body leaves the message unset, a body over maxRequestBytes, which is not read past the limit, is a ResourceExhausted
+// error, and a body that is not the message is an InvalidArgument error.
+func gatewayDecoder(inbound runtime.Marshaler, w http.ResponseWriter, r *http.Request) func(proto.Message) error {
+	body := r.Body
+	if *maxRequestBytes > 0 {
+		body = http.MaxBytesReader(w, r.Body, int64(*maxRequestBytes))
+	}
+	return func(msg proto.Message) error {
+		err := inbound.NewDecoder(body).Decode(msg)
+		if err == nil || errors.Is(err, io.EOF) {
+			return nil
+		}
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			violation := &limitViolation{"request_bytes", fmt.Sprintf("request body has more than the limit of %d bytes", maxBytesErr.Limit)}
+			span, _ := tracer.SpanFromContext(r.Context())
+			return rejectRequest(span, "gateway", violation)
+		}
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+}
+
+// unaryHandler Returns the handler of the route, calling its RPC on s in a span named after the RPC and writing
+// the response
+func unaryHandler(mux *runtime.ServeMux, s gameoflifepb.GameOfLifeServer, route gatewayRoute) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		inbound, outbound := runtime.MarshalerForRequest(mux, r)
+		ctx, err := gatewayContext(mux, r, route)
+		if err != nil {
+			runtime.HTTPError(r.Context(), mux, outbound, w, r, err)
+			return
+		}
+		span, ctx := tracer.StartSpanFromContext(ctx, route.rpc)
+		resp, err := route.call(ctx, s, gatewayDecoder(inbound, w, r), r)
+		span.Finish(tracer.WithError(err))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		runtime.ForwardResponseMessage(ctx, mux, outbound, w, r, resp, mux.GetForwardResponseOptions()...)
+	}
+}
+
+// streamHandler Returns the handler of streamRoute, calling RunGameStream on s and writing every frame as
+// a {"result": frame} line, then an {"error": status} line if the game fails partway
+func streamHandler(mux *runtime.ServeMux, s gameoflifepb.GameOfLifeServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		inbound, outbound := runtime.MarshalerForRequest(mux, r)
+		ctx, err := gatewayContext(mux, r, streamRoute)
+		if err != nil {
+			runtime.HTTPError(r.Context(), mux, outbound, w, r, err)
+			return
+		}
+		req := &gameoflifepb.GameRequest{}
+		if err := gatewayDecoder(inbound, w, r)(req); err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+
+		// Cancelling the context stops the game, once the client went away or the handler returns
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream := &gatewayStream{
+			ctx:    ctx,
+			frames: make(chan *gameoflifepb.GenerationFrame),
+			done:   make(chan error, 1),
+		}
+		go func() {
+			stream.done <- s.RunGameStream(req, stream)
+			close(stream.frames)
+		}()
+		runtime.ForwardResponseStream(ctx, mux, outbound, w, r, stream.recv, mux.GetForwardResponseOptions()...)
+	}
+}
+
+// gatewayStream is the server stream of a RunGameStream called by the gateway, handing every frame sent by the
+// server over to the handler writing the response
+type gatewayStream struct {
+	ctx    context.Context
+	frames chan *gameoflifepb.GenerationFrame
+	// done holds the error returned by RunGameStream, before frames is closed
+	done chan error
+}
+
+// recv Returns the next frame sent by the server, or io.EOF once RunGameStream returned without error
+func (s *gatewayStream) recv() (proto.Message, error) {
+	frame, ok := <-s.frames
+	if !ok {
+		if err := <-s.done; err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	return frame, nil
+}
+
+func (s *gatewayStream) Send(frame *gameoflifepb.GenerationFrame) error {
+	select {
+	case s.frames <- frame:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func (s *gatewayStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *gatewayStream) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *gatewayStream) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *gatewayStream) SetTrailer(metadata.MD) {}
+
+func (s *gatewayStream) SendMsg(m any) error {
+	return s.Send(m.(*gameoflifepb.GenerationFrame))
+}
+
+func (s *gatewayStream) RecvMsg(any) error {
+	return io.EOF
+}
@@ -2,43 +2,716 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"encoding/binary"
+	"errors"
 	"flag"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"sync"
+	"time"
 
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-dd/cache"
 	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-dd/gameoflife"
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-dd/health"
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-dd/jobs"
 	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-dd/logging"
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-dd/sessions"
 	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
 
+	"github.com/DataDog/datadog-go/v5/statsd"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	grpctrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/google.golang.org/grpc"
+	redistrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/redis/go-redis.v9"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 )
 
 var (
-	grpcPort = flag.Int("grpcPort", 8081, "Port to be used by the gRPC server")
-	httpPort = flag.Int("httpPort", 8082, "Port to be used by the http server")
-	logger   *zap.Logger
+	grpcPort    = flag.Int("grpcPort", 8081, "Port to be used by the gRPC server")
+	httpPort    = flag.Int("httpPort", 8082, "Port to be used by the http server")
+	workers     = flag.Int("workers", 1, "Number of workers stepping each generation in parallel, each on a stripe of rows")
+	stripeSpans = flag.Bool("stripeSpans", false, "Create a child span for every stripe of every generation stepped by a worker")
+	statsdAddr  = flag.String("statsdAddr", "localhost:8125", "Address of the DogStatsD server receiving the game metrics")
+
+	maxRows            = flag.Int("maxRows", 1024, "Maximum number of rows of a board, 0 for no limit")
+	maxCols            = flag.Int("maxCols", 1024, "Maximum number of columns of a board, 0 for no limit")
+	maxCellGenerations = flag.Int64("maxCellGenerations", 1_000_000_000, "Maximum number of cells times generations of a game run with the STANDARD engine, 0 for no limit")
+	maxHashLifeNodes   = flag.Int("maxHashLifeNodes", 4_000_000, "Maximum number of quadtree nodes of a game run with the HASHLIFE engine, 0 for no limit")
+	maxStreamGens      = flag.Int("maxStreamGens", 100_000, "Maximum number of generations of a game streamed with RunGameStream, 0 for no limit")
+	maxRequestBytes    = flag.Int("maxRequestBytes", 1<<20, "Maximum size of a game request in bytes, 0 for no limit")
+	maxBatchSize       = flag.Int("maxBatchSize", 1000, "Maximum number of games of a RunGames batch, 0 for no limit")
+	batchConcurrency   = flag.Int("batchConcurrency", 8, "Number of games of a RunGames batch run concurrently")
+
+	cacheSize = flag.Int("cacheSize", 1024, "Number of game responses kept by the in-memory cache, 0 to disable the cache")
+	cacheTTL  = flag.Duration("cacheTTL", 10*time.Minute, "Time the game responses are cached for")
+	redisAddr = flag.String("redisAddr", "", "Address of a Redis server caching the game responses instead of the in-memory cache")
+
+	jobWorkers = flag.Int("jobWorkers", 2, "Number of jobs submitted with SubmitGame run concurrently")
+	maxJobs    = flag.Int("maxJobs", 1000, "Maximum number of jobs kept by the server, 0 for no limit")
+	jobTTL     = flag.Duration("jobTTL", time.Hour, "Time the finished jobs are kept for, 0 to keep them until the server stops")
+
+	sessionDir  = flag.String("sessionDir", "", "Directory storing the game sessions in files, which are kept in memory if unset")
+	maxSessions = flag.Int("maxSessions", 1000, "Maximum number of sessions kept by the server, 0 for no limit")
+	sessionTTL  = flag.Duration("sessionTTL", time.Hour, "Time a session is kept for after it was created or last stepped, 0 to keep it until it is deleted")
+
+	healthCacheTTL = flag.Duration("healthCacheTTL", 5*time.Second, "Time the result of the health checks is cached for, and the interval the gRPC health status is updated at")
+
+	logger *zap.Logger
+
+	statsdClient statsd.ClientInterface = &statsd.NoOpClient{}
+	// resultCache caches the responses of RunGame, and is nil if caching is disabled
+	resultCache cache.Cache
+	jobStore    *jobs.Store
+	// jobSlots holds a token for every running job, so that at most jobWorkers jobs run at a time
+	jobSlots     chan struct{}
+	sessionStore sessions.SessionStore
+	// healthChecks holds the checks of the readiness and liveness of the server, which also drive its gRPC health status
+	healthChecks = health.NewRegistry()
 )
 
+// recordHashLifeStats Sends the memoization statistics of a HashLife run to DogStatsD, and tags the span with them
+func recordHashLifeStats(span tracer.Span, gameConfiguration *gameoflifepb.GameRequest, stats *gameoflife.HashLifeStats) {
+	if gameConfiguration.Engine != gameoflifepb.Engine_HASHLIFE {
+		return
+	}
+	statsdClient.Count("gameoflife.hashlife.cache.hits", stats.CacheHits, nil, 1)
+	statsdClient.Count("gameoflife.hashlife.cache.misses", stats.CacheMisses, nil, 1)
+	span.SetTag("rungame_server.hashlife.cache_hits", stats.CacheHits)
+	span.SetTag("rungame_server.hashlife.cache_misses", stats.CacheMisses)
+}
+
+// tagRequest Tags the span with the rule, topology, engine and pattern of the game request, using the given tag prefix
+func tagRequest(span tracer.Span, prefix string, gameConfiguration *gameoflifepb.GameRequest) {
+	span.SetTag(prefix+".request.rule", gameConfiguration.Rule)
+	span.SetTag(prefix+".request.topology", gameConfiguration.Topology.String())
+	span.SetTag(prefix+".request.engine", gameConfiguration.Engine.String())
+	if gameConfiguration.PatternName != "" {
+		span.SetTag(prefix+".request.pattern_name", gameConfiguration.PatternName)
+	}
+	tagRandomBoard(span, prefix, gameConfiguration.RandomBoard)
+}
+
+// tagRandomBoard Tags the span with the seed and parameters of the random board of the request, if any,
+// so the board can be generated again from the trace
+func tagRandomBoard(span tracer.Span, prefix string, random *gameoflifepb.RandomBoard) {
+	if random == nil {
+		return
+	}
+	span.SetTag(prefix+".request.random_board.seed", random.Seed)
+	span.SetTag(prefix+".request.random_board.width", random.Width)
+	span.SetTag(prefix+".request.random_board.height", random.Height)
+	span.SetTag(prefix+".request.random_board.density", random.Density)
+}
+
+// tagResult Tags the span with how the run ended, using the given tag prefix
+func tagResult(span tracer.Span, prefix string, result *gameoflifepb.GameResponse) {
+	span.SetTag(prefix+".response.final_generation", result.FinalGeneration)
+	span.SetTag(prefix+".response.period", result.Period)
+	span.SetTag(prefix+".response.extinct", result.Extinct)
+}
+
+// tagCancellation Tags the span with the generation reached by a cancelled game
+func tagCancellation(span tracer.Span, prefix string, result *gameoflifepb.GameResponse) {
+	if result.GetCode() != gameoflifepb.ResponseCode_CANCELLED && result.GetCode() != gameoflifepb.ResponseCode_DEADLINE_EXCEEDED {
+		return
+	}
+	span.SetTag(prefix+".cancelled.generation", result.FinalGeneration)
+	span.SetTag(prefix+".cancelled.code", result.Code.String())
+}
+
+// statusError Returns the gRPC status error of a failed game: InvalidArgument with the field violation
+// of an invalid request, Canceled or DeadlineExceeded with the partial result as a detail for a cancelled game,
+// and err otherwise
+func statusError(err error, result *gameoflifepb.GameResponse) error {
+	var validationErr *gameoflife.ValidationError
+	if errors.As(err, &validationErr) {
+		st := status.New(codes.InvalidArgument, err.Error())
+		detailed, detailsErr := st.WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: validationErr.Field, Description: validationErr.Err.Error()},
+			},
+		})
+		if detailsErr != nil {
+			return st.Err()
+		}
+		return detailed.Err()
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		st := status.FromContextError(err)
+		if result == nil {
+			return st.Err()
+		}
+		detailed, detailsErr := st.WithDetails(result)
+		if detailsErr != nil {
+			return st.Err()
+		}
+		return detailed.Err()
+	}
+	return err
+}
+
+// limitViolation is a limit set by the command line flags that a game request is over
+type limitViolation struct {
+	// reason is the limit the request is over: request_bytes, rows, cols, cell_generations, stream_generations,
+	// hashlife_nodes, batch_size, jobs or sessions
+	reason      string
+	description string
+}
+
+// checkLimits Returns the first limit the game request is over, or nil if it is within the limits.
+// The cell generations budget only applies to the STANDARD engine, as HashLife does not step every cell of every generation.
+// HashLife games are instead limited by the nodes of their quadtree, which are only known while the game runs.
+func checkLimits(gameConfiguration *gameoflifepb.GameRequest) *limitViolation {
+	if size := proto.Size(gameConfiguration); *maxRequestBytes > 0 && size > *maxRequestBytes {
+		return &limitViolation{"request_bytes", fmt.Sprintf("request has %d bytes, the limit is %d", size, *maxRequestBytes)}
+	}
+	rows, cols, err := gameoflife.BoardSize(gameConfiguration)
+	if err != nil {
+		// Invalid boards are rejected by gameoflife.Run
+		return nil
+	}
+	if *maxRows > 0 && rows > *maxRows {
+		return &limitViolation{"rows", fmt.Sprintf("board has %d rows, the limit is %d", rows, *maxRows)}
+	}
+	if *maxCols > 0 && cols > *maxCols {
+		return &limitViolation{"cols", fmt.Sprintf("board has %d columns, the limit is %d", cols, *maxCols)}
+	}
+	cellGenerations := int64(rows) * int64(cols) * int64(gameConfiguration.NumGens)
+	if *maxCellGenerations > 0 && gameConfiguration.Engine == gameoflifepb.Engine_STANDARD && cellGenerations > *maxCellGenerations {
+		return &limitViolation{"cell_generations", fmt.Sprintf("game has %d cells times generations, the limit is %d", cellGenerations, *maxCellGenerations)}
+	}
+	return nil
+}
+
+// checkStreamLimits Returns the first limit the streamed game request is over like checkLimits, including the
+// generations of the stream, as a frame is sent for every generation whatever the engine
+func checkStreamLimits(gameConfiguration *gameoflifepb.GameRequest) *limitViolation {
+	if violation := checkLimits(gameConfiguration); violation != nil {
+		return violation
+	}
+	if *maxStreamGens > 0 && int(gameConfiguration.NumGens) > *maxStreamGens {
+		return &limitViolation{"stream_generations", fmt.Sprintf("streamed game has %d generations, the limit is %d", gameConfiguration.NumGens, *maxStreamGens)}
+	}
+	return nil
+}
+
+// runViolation Returns the limit a game went over while it ran, or nil if err is not over a limit
+func runViolation(err error) *limitViolation {
+	if errors.Is(err, gameoflife.ErrNodeLimit) {
+		return &limitViolation{"hashlife_nodes", fmt.Sprintf("game needs more than the limit of %d hashlife nodes", *maxHashLifeNodes)}
+	}
+	return nil
+}
+
+// rejectRequest Counts the rejection of a game request over a limit, and Returns its ResourceExhausted status error
+func rejectRequest(span tracer.Span, prefix string, violation *limitViolation) error {
+	statsdClient.Incr("gameoflife.requests.rejected", []string{"reason:" + violation.reason}, 1)
+	span.SetTag(prefix+".rejected.reason", violation.reason)
+	st := status.New(codes.ResourceExhausted, violation.description)
+	detailed, err := st.WithDetails(&errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{
+			{Subject: violation.reason, Description: violation.description},
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// newResultCache Returns the cache of game responses set by the command line flags, or nil if caching is disabled
+func newResultCache() cache.Cache {
+	if *redisAddr != "" {
+		return cache.NewRedis(redistrace.NewClient(&redis.Options{Addr: *redisAddr}), *cacheTTL)
+	}
+	if *cacheSize <= 0 {
+		return nil
+	}
+	return cache.NewLRU(*cacheSize, *cacheTTL, cache.WithEvictionHook(func(reason cache.EvictionReason) {
+		statsdClient.Incr("gameoflife.cache.evictions", []string{"reason:" + string(reason)}, 1)
+	}))
+}
+
+// newSessionStore Returns the store of game sessions set by the command line flags
+func newSessionStore() (sessions.SessionStore, error) {
+	if *sessionDir != "" {
+		return sessions.NewFileStore(*sessionDir, *maxSessions, *sessionTTL)
+	}
+	return sessions.NewMemoryStore(*maxSessions, *sessionTTL), nil
+}
+
+// getCachedResult Returns the cached response of the game with the given cache key, and false on a miss.
+// A failing cache is a miss, so that the game is run instead.
+func getCachedResult(ctx context.Context, key string) (*gameoflifepb.GameResponse, bool) {
+	span, ctx := tracer.StartSpanFromContext(ctx, "GetCachedResult")
+	result, hit, err := resultCache.Get(ctx, key)
+	if err != nil {
+		logger.Warn("Getting cached result", zap.Error(err))
+	}
+	span.SetTag("cache.hit", hit)
+	span.Finish(tracer.WithError(err))
+	if hit {
+		statsdClient.Incr("gameoflife.cache.hits", nil, 1)
+	} else {
+		statsdClient.Incr("gameoflife.cache.misses", nil, 1)
+	}
+	return result, hit
+}
+
+// setCachedResult Caches the response of the game with the given cache key
+func setCachedResult(ctx context.Context, key string, result *gameoflifepb.GameResponse) {
+	span, ctx := tracer.StartSpanFromContext(ctx, "SetCachedResult")
+	err := resultCache.Set(ctx, key, result)
+	if err != nil {
+		logger.Warn("Setting cached result", zap.Error(err))
+	}
+	span.Finish(tracer.WithError(err))
+}
+
+// recordStats Sends the statistics of the last generation of a game to DogStatsD, with the births and deaths
+// since generation 0
+func recordStats(stats *gameoflifepb.GenerationStats) {
+	if stats == nil {
+		return
+	}
+	statsdClient.Histogram("gameoflife.population", float64(stats.Population), nil, 1)
+	statsdClient.Histogram("gameoflife.births_since_start", float64(stats.Births), nil, 1)
+	statsdClient.Histogram("gameoflife.deaths_since_start", float64(stats.Deaths), nil, 1)
+	var width, height float64
+	if box := stats.BoundingBox; box != nil {
+		width, height = float64(box.MaxCol-box.MinCol+1), float64(box.MaxRow-box.MinRow+1)
+	}
+	statsdClient.Gauge("gameoflife.bounding_box.width", width, nil, 1)
+	statsdClient.Gauge("gameoflife.bounding_box.height", height, nil, 1)
+}
+
 type server struct {
 	gameoflifepb.UnimplementedGameOfLifeServer
 }
 
+// runOptions Returns the gameoflife options set by the command line flags,
+// collecting the HashLife statistics of the run into stats
+func runOptions(stats *gameoflife.HashLifeStats) []gameoflife.Option {
+	options := []gameoflife.Option{
+		gameoflife.WithWorkers(*workers),
+		gameoflife.WithHashLifeStats(stats),
+		gameoflife.WithMaxHashLifeNodes(*maxHashLifeNodes),
+	}
+	if *stripeSpans {
+		options = append(options, gameoflife.WithStripeHook(func(ctx context.Context, generation int, firstRow int, lastRow int) func() {
+			span, _ := tracer.StartSpanFromContext(ctx, "StepStripe")
+			span.SetTag("rungame_server.stripe.generation", generation)
+			span.SetTag("rungame_server.stripe.first_row", firstRow)
+			span.SetTag("rungame_server.stripe.last_row", lastRow)
+			return func() { span.Finish() }
+		}))
+	}
+	return options
+}
+
+// runGame Runs a game within the limits with the given extra options, or serves its response from the cache,
+// tagging span with the given tag prefix
+func runGame(ctx context.Context, span tracer.Span, prefix string, gameConfiguration *gameoflifepb.GameRequest, options ...gameoflife.Option) (*gameoflifepb.GameResponse, error) {
+	logger.Info("Received game configuration", zap.Any("gameConfiguration", gameConfiguration))
+	if violation := checkLimits(gameConfiguration); violation != nil {
+		logger.Warn("Rejected game configuration", zap.String("reason", violation.reason), zap.String("description", violation.description))
+		return nil, rejectRequest(span, prefix, violation)
+	}
+	tagRequest(span, prefix, gameConfiguration)
+
+	// The cache is looked up before running the game, which then fills the cache on a miss
+	key, keyErr := cache.Key(gameConfiguration)
+	cached := resultCache != nil && keyErr == nil
+	var result *gameoflifepb.GameResponse
+	hit := false
+	if cached {
+		result, hit = getCachedResult(ctx, key)
+		span.SetTag("cache.hit", hit)
+	}
+	if !hit {
+		var stats gameoflife.HashLifeStats
+		var summary *gameoflifepb.GenerationStats
+		options = append(options, gameoflife.WithSummaryHook(func(last *gameoflifepb.GenerationStats) { summary = last }))
+		var err error
+		result, err = gameoflife.Run(ctx, gameConfiguration, logger, append(runOptions(&stats), options...)...)
+		recordHashLifeStats(span, gameConfiguration, &stats)
+		if violation := runViolation(err); violation != nil {
+			logger.Warn("Rejected game configuration", zap.String("reason", violation.reason), zap.String("description", violation.description))
+			return nil, rejectRequest(span, prefix, violation)
+		}
+		if err != nil {
+			logger.Error("Calling gameoflife.Run", zap.Error(err))
+			tagCancellation(span, prefix, result)
+			return result, statusError(err, result)
+		}
+		recordStats(summary)
+		if cached {
+			setCachedResult(ctx, key, result)
+		}
+	}
+	tagResult(span, prefix, result)
+
+	return result, nil
+}
+
 func (s *server) RunGame(ctx context.Context, gameConfiguration *gameoflifepb.GameRequest) (*gameoflifepb.GameResponse, error) {
+	span, _ := tracer.SpanFromContext(ctx)
+	return runGame(ctx, span, "rungame_server", gameConfiguration)
+}
+
+// RunGames Runs the games of a batch, at most batchConcurrency at a time, each in its own child span.
+// A failed game only sets the status of its result, and the batch is only rejected if it is over maxBatchSize.
+func (s *server) RunGames(ctx context.Context, batch *gameoflifepb.BatchGameRequest) (*gameoflifepb.BatchGameResponse, error) {
+	span, _ := tracer.SpanFromContext(ctx)
+	concurrency := max(*batchConcurrency, 1)
+	span.SetTag("rungames_server.request.num_games", len(batch.Requests))
+	span.SetTag("rungames_server.concurrency", concurrency)
+	logger.Info("Received game batch", zap.Int("numGames", len(batch.Requests)))
+	if *maxBatchSize > 0 && len(batch.Requests) > *maxBatchSize {
+		violation := &limitViolation{"batch_size", fmt.Sprintf("batch has %d games, the limit is %d", len(batch.Requests), *maxBatchSize)}
+		logger.Warn("Rejected game batch", zap.String("reason", violation.reason), zap.String("description", violation.description))
+		return nil, rejectRequest(span, "rungames_server", violation)
+	}
+
+	results := make([]*gameoflifepb.BatchGameResult, len(batch.Requests))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, gameConfiguration := range batch.Requests {
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			results[i] = runBatchGame(ctx, i, gameConfiguration)
+		}()
+	}
+	wg.Wait()
+
+	numFailed := 0
+	for _, result := range results {
+		if result.StatusCode != int32(codes.OK) {
+			numFailed++
+		}
+	}
+	span.SetTag("rungames_server.response.num_failed", numFailed)
+	return &gameoflifepb.BatchGameResponse{Results: results}, nil
+}
+
+// runBatchGame Runs the game of the given index of a batch in its own span, and Returns its response or status
+func runBatchGame(ctx context.Context, index int, gameConfiguration *gameoflifepb.GameRequest) *gameoflifepb.BatchGameResult {
+	span, ctx := tracer.StartSpanFromContext(ctx, "RunBatchGame")
+	span.SetTag("rungames_server.game.index", index)
+	result, err := runGame(ctx, span, "rungames_server.game", gameConfiguration)
+	span.Finish(tracer.WithError(err))
+
+	st := status.Convert(err)
+	return &gameoflifepb.BatchGameResult{
+		Response:     result,
+		StatusCode:   int32(st.Code()),
+		ErrorMessage: st.Message(),
+	}
+}
+
+func (s *server) RunGameStream(gameConfiguration *gameoflifepb.GameRequest, stream gameoflifepb.GameOfLife_RunGameStreamServer) error {
+	span, ctx := tracer.StartSpanFromContext(stream.Context(), "RunGameStream")
 	logger.Info("Received game configuration", zap.Any("gameConfiguration", gameConfiguration))
+	if violation := checkStreamLimits(gameConfiguration); violation != nil {
+		logger.Warn("Rejected game configuration", zap.String("reason", violation.reason), zap.String("description", violation.description))
+		err := rejectRequest(span, "rungame_stream_server", violation)
+		span.Finish(tracer.WithError(err))
+		return err
+	}
+	tagRequest(span, "rungame_stream_server", gameConfiguration)
 
-	result, err := gameoflife.Run(ctx, gameConfiguration, logger)
+	numFrames := 0
+	var stats gameoflife.HashLifeStats
+	var summary *gameoflifepb.GenerationStats
+	result, err := gameoflife.RunStream(ctx, gameConfiguration, logger, func(frame *gameoflifepb.GenerationFrame) error {
+		numFrames++
+		return stream.Send(frame)
+	}, append(runOptions(&stats), gameoflife.WithSummaryHook(func(last *gameoflifepb.GenerationStats) { summary = last }))...)
+	span.SetTag("rungame_stream_server.response.num_frames", numFrames)
+	recordHashLifeStats(span, gameConfiguration, &stats)
+	if violation := runViolation(err); violation != nil {
+		logger.Warn("Rejected game configuration", zap.String("reason", violation.reason), zap.String("description", violation.description))
+		err = rejectRequest(span, "rungame_stream_server", violation)
+	} else if err == nil {
+		tagResult(span, "rungame_stream_server", result)
+		recordStats(summary)
+	} else {
+		tagCancellation(span, "rungame_stream_server", result)
+		err = statusError(err, result)
+	}
+	span.Finish(tracer.WithError(err))
 	if err != nil {
-		logger.Error("Calling gameoflife.Run", zap.Error(err))
-		return result, err
+		logger.Error("Calling gameoflife.RunStream", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *server) ListPatterns(ctx context.Context, _ *gameoflifepb.ListPatternsRequest) (*gameoflifepb.ListPatternsResponse, error) {
+	patterns := gameoflife.Patterns()
+	if span, ok := tracer.SpanFromContext(ctx); ok {
+		span.SetTag("listpatterns_server.response.num_patterns", len(patterns))
+	}
+	return &gameoflifepb.ListPatternsResponse{Patterns: patterns}, nil
+}
+
+func (s *server) GetPattern(ctx context.Context, req *gameoflifepb.GetPatternRequest) (*gameoflifepb.Pattern, error) {
+	if span, ok := tracer.SpanFromContext(ctx); ok {
+		span.SetTag("getpattern_server.request.name", req.Name)
+	}
+
+	pattern, ok := gameoflife.GetPattern(req.Name)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown pattern %q", req.Name)
+	}
+	return pattern, nil
+}
+
+// SubmitGame Starts running the game in the background, and Returns its pending job. The game is run
+// in its own trace, linked to the span of the request that submitted it.
+func (s *server) SubmitGame(ctx context.Context, gameConfiguration *gameoflifepb.GameRequest) (*gameoflifepb.Job, error) {
+	span, _ := tracer.SpanFromContext(ctx)
+	if violation := checkLimits(gameConfiguration); violation != nil {
+		logger.Warn("Rejected game configuration", zap.String("reason", violation.reason), zap.String("description", violation.description))
+		return nil, rejectRequest(span, "submitgame_server", violation)
+	}
+	// The job outlives the request, so it is only cancelled by CancelJob
+	jobCtx, cancel := context.WithCancel(context.Background())
+	job, err := jobStore.Create(gameConfiguration, cancel)
+	if err != nil {
+		cancel()
+		if errors.Is(err, jobs.ErrFull) {
+			violation := &limitViolation{"jobs", fmt.Sprintf("server already has the maximum of %d jobs", *maxJobs)}
+			logger.Warn("Rejected game configuration", zap.String("reason", violation.reason), zap.String("description", violation.description))
+			return nil, rejectRequest(span, "submitgame_server", violation)
+		}
+		logger.Error("Creating job", zap.Error(err))
+		return nil, err
 	}
+	span.SetTag("submitgame_server.job.id", job.ID())
+	logger.Info("Submitted job", zap.String("job_id", job.ID()))
 
-	return result, err
+	go runJob(jobCtx, spanLink(span), job, gameConfiguration)
+	return job.Proto(), nil
+}
+
+// spanLink Returns the link to span, with the high bits of its trace ID if it has a 128-bit trace ID
+func spanLink(span tracer.Span) ddtrace.SpanLink {
+	link := ddtrace.SpanLink{TraceID: span.Context().TraceID(), SpanID: span.Context().SpanID()}
+	if w3c, ok := span.Context().(ddtrace.SpanContextW3C); ok {
+		traceID := w3c.TraceID128Bytes()
+		link.TraceIDHigh = binary.BigEndian.Uint64(traceID[:8])
+	}
+	return link
+}
+
+// runJob Runs the game of a job once a job worker is free, in a new trace linked to the span that submitted it
+func runJob(ctx context.Context, link ddtrace.SpanLink, job *jobs.Job, gameConfiguration *gameoflifepb.GameRequest) {
+	span := tracer.StartSpan("RunJob", tracer.WithSpanLinks([]ddtrace.SpanLink{link}))
+	ctx = tracer.ContextWithSpan(ctx, span)
+	span.SetTag("runjob_server.job.id", job.ID())
+	jobLogger := logger.With(zap.String("job_id", job.ID()))
+
+	select {
+	case jobSlots <- struct{}{}:
+		defer func() { <-jobSlots }()
+	case <-ctx.Done():
+		jobLogger.Info("Job cancelled before it started")
+		err := status.FromContextError(ctx.Err()).Err()
+		job.Finish(nil, err)
+		span.Finish(tracer.WithError(err))
+		return
+	}
+	job.Start()
+	result, err := runGame(ctx, span, "runjob_server", gameConfiguration, gameoflife.WithProgressHook(job.SetGeneration))
+	job.Finish(result, err)
+	span.Finish(tracer.WithError(err))
+	jobLogger.Info("Finished job", zap.Stringer("code", status.Code(err)))
+}
+
+func (s *server) GetJob(ctx context.Context, req *gameoflifepb.GetJobRequest) (*gameoflifepb.Job, error) {
+	span, _ := tracer.SpanFromContext(ctx)
+	span.SetTag("getjob_server.request.id", req.Id)
+
+	job, ok := jobStore.Get(req.Id)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown job %q", req.Id)
+	}
+	span.SetTag("getjob_server.response.state", job.State.String())
+	span.SetTag("getjob_server.response.generation", job.Generation)
+	return job, nil
+}
+
+func (s *server) CancelJob(ctx context.Context, req *gameoflifepb.CancelJobRequest) (*gameoflifepb.Job, error) {
+	span, _ := tracer.SpanFromContext(ctx)
+	span.SetTag("canceljob_server.request.id", req.Id)
+
+	job, ok := jobStore.Cancel(req.Id)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown job %q", req.Id)
+	}
+	span.SetTag("canceljob_server.response.state", job.State.String())
+	return job, nil
+}
+
+func (s *server) ListJobs(ctx context.Context, _ *gameoflifepb.ListJobsRequest) (*gameoflifepb.ListJobsResponse, error) {
+	span, _ := tracer.SpanFromContext(ctx)
+	list := jobStore.List()
+	span.SetTag("listjobs_server.response.num_jobs", len(list))
+	return &gameoflifepb.ListJobsResponse{Jobs: list}, nil
+}
+
+// sessionError Returns the gRPC status error of a failed session store operation: NotFound for an unknown session,
+// and err otherwise
+func sessionError(err error, id string) error {
+	if errors.Is(err, sessions.ErrNotFound) {
+		return status.Errorf(codes.NotFound, "unknown session %q", id)
+	}
+	return err
+}
+
+// tagSession Tags the span with the state of a session, using the given tag prefix
+func tagSession(span tracer.Span, prefix string, session *gameoflifepb.Session) {
+	span.SetTag(prefix+".session.id", session.Id)
+	span.SetTag(prefix+".session.generation", session.Generation)
+	span.SetTag(prefix+".session.extinct", session.Extinct)
+}
+
+// CreateSession Stores the initial board of a game in a new session. The board is generated from the pattern
+// or random board of the game if it has one, so that every Step advances the same board.
+func (s *server) CreateSession(ctx context.Context, req *gameoflifepb.CreateSessionRequest) (*gameoflifepb.Session, error) {
+	span, _ := tracer.SpanFromContext(ctx)
+	game := req.GetGame()
+	if game == nil {
+		game = &gameoflifepb.GameRequest{}
+	}
+
+	// Running the game for 0 generations validates it and returns its initial board
+	initial := proto.Clone(game).(*gameoflifepb.GameRequest)
+	initial.NumGens = 0
+	result, err := runGame(ctx, span, "createsession_server", initial)
+	if err != nil {
+		return nil, err
+	}
+	now := timestamppb.Now()
+	session, err := sessionStore.Create(ctx, &gameoflifepb.Session{
+		Game: &gameoflifepb.GameRequest{
+			Board:           result.Board,
+			StructuredBoard: result.StructuredBoard,
+			Rule:            gameoflife.RequestRule(game),
+			Topology:        game.Topology,
+			Engine:          game.Engine,
+			Format:          game.Format,
+		},
+		Extinct:    result.Extinct,
+		CreateTime: now,
+		UpdateTime: now,
+	})
+	if errors.Is(err, sessions.ErrFull) {
+		violation := &limitViolation{"sessions", fmt.Sprintf("server already has the maximum of %d sessions", *maxSessions)}
+		logger.Warn("Rejected session", zap.String("reason", violation.reason), zap.String("description", violation.description))
+		return nil, rejectRequest(span, "createsession_server", violation)
+	}
+	if err != nil {
+		logger.Error("Creating session", zap.Error(err))
+		return nil, err
+	}
+	tagSession(span, "createsession_server", session)
+	logger.Info("Created session", zap.String("session_id", session.Id))
+	return session, nil
+}
+
+// Step Advances the board of a session by the given number of generations, within the limits of a game.
+// Concurrent steps of a session are applied one after the other.
+func (s *server) Step(ctx context.Context, req *gameoflifepb.StepRequest) (*gameoflifepb.Session, error) {
+	span, _ := tracer.SpanFromContext(ctx)
+	span.SetTag("step_server.request.session_id", req.SessionId)
+	span.SetTag("step_server.request.num_gens", req.NumGens)
+
+	numGens := req.NumGens
+	if numGens < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "num_gens: must not be negative, got %d", numGens)
+	}
+	if numGens == 0 {
+		numGens = 1
+	}
+	session, err := sessionStore.Update(ctx, req.SessionId, func(session *gameoflifepb.Session) error {
+		game := proto.Clone(session.Game).(*gameoflifepb.GameRequest)
+		game.NumGens = numGens
+		result, err := runGame(ctx, span, "step_server", game)
+		if err != nil {
+			return err
+		}
+		session.Game.Board = result.Board
+		session.Game.StructuredBoard = result.StructuredBoard
+		session.Generation += numGens
+		session.Extinct = result.Extinct
+		session.UpdateTime = timestamppb.Now()
+		return nil
+	})
+	if err != nil {
+		return nil, sessionError(err, req.SessionId)
+	}
+	tagSession(span, "step_server", session)
+	return session, nil
+}
+
+func (s *server) GetSession(ctx context.Context, req *gameoflifepb.GetSessionRequest) (*gameoflifepb.Session, error) {
+	span, _ := tracer.SpanFromContext(ctx)
+	span.SetTag("getsession_server.request.session_id", req.SessionId)
+
+	session, err := sessionStore.Get(ctx, req.SessionId)
+	if err != nil {
+		return nil, sessionError(err, req.SessionId)
+	}
+	tagSession(span, "getsession_server", session)
+	return session, nil
+}
+
+func (s *server) DeleteSession(ctx context.Context, req *gameoflifepb.DeleteSessionRequest) (*emptypb.Empty, error) {
+	span, _ := tracer.SpanFromContext(ctx)
+	span.SetTag("deletesession_server.request.session_id", req.SessionId)
+
+	if err := sessionStore.Delete(ctx, req.SessionId); err != nil {
+		return nil, sessionError(err, req.SessionId)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+const (
+	// selfTestBoard is a vertical blinker, whose next generation is selfTestResult
+	selfTestBoard  = "[[0,0,0,0,0],[0,0,1,0,0],[0,0,1,0,0],[0,0,1,0,0],[0,0,0,0,0]]"
+	selfTestResult = "[[0,0,0,0,0],[0,0,0,0,0],[0,1,1,1,0],[0,0,0,0,0],[0,0,0,0,0]]"
+)
+
+// selfTest Runs a generation of a blinker with the workers of the server, and Returns an error unless the blinker
+// turned horizontal
+func selfTest(ctx context.Context) error {
+	result, err := gameoflife.Run(ctx, &gameoflifepb.GameRequest{Board: selfTestBoard, NumGens: 1}, zap.NewNop(), gameoflife.WithWorkers(*workers))
+	if err != nil {
+		return fmt.Errorf("running a blinker: %w", err)
+	}
+	if result.Board != selfTestResult {
+		return fmt.Errorf("blinker stepped to %s, expected %s", result.Board, selfTestResult)
+	}
+	return nil
 }
 
 func main() {
@@ -51,11 +724,33 @@ func main() {
 	}
 	logger = logger.With(zap.String("service", "game-of-life-server"))
 
-	logger.Info("Arguments", zap.Int("grpcPort", *grpcPort), zap.Int("httpPort", *httpPort))
+	logger.Info("Arguments",
+		zap.Int("grpcPort", *grpcPort),
+		zap.Int("httpPort", *httpPort),
+		zap.Int("workers", *workers),
+		zap.Bool("stripeSpans", *stripeSpans),
+		zap.String("statsdAddr", *statsdAddr),
+	)
 
 	tracer.Start(tracer.WithRuntimeMetrics())
 	defer tracer.Stop()
 
+	statsdClient, err = statsd.New(*statsdAddr)
+	if err != nil {
+		logger.Fatal("failed to create DogStatsD client", zap.Error(err))
+	}
+	defer statsdClient.Close()
+	resultCache = newResultCache()
+	jobStore = jobs.NewStore(*maxJobs, *jobTTL)
+	jobSlots = make(chan struct{}, max(*jobWorkers, 1))
+	sessionStore, err = newSessionStore()
+	if err != nil {
+		logger.Fatal("failed to create session store", zap.Error(err))
+	}
+	// The server is alive while its engine runs games
+	healthChecks = health.NewRegistry(health.WithCacheTTL(*healthCacheTTL))
+	healthChecks.RegisterLiveness("self_test", selfTest)
+
 	// Start HTTP server
 	mux := SetupHandlers()
 
@@ -72,8 +767,21 @@ func main() {
 		logger.Fatal("failed to listen", zap.Error(err))
 	}
 
-	s := grpc.NewServer()
+	// Every RPC is traced by a span that the RPCs tag, and that is the parent of the spans of the game
+	options := []grpc.ServerOption{
+		grpc.UnaryInterceptor(grpctrace.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(grpctrace.StreamServerInterceptor()),
+	}
+	// Messages over the request limit are rejected before they are decoded. checkLimits still checks the size
+	// of the requests, to reject those of the gateway with a request_bytes violation.
+	if *maxRequestBytes > 0 {
+		options = append(options, grpc.MaxRecvMsgSize(*maxRequestBytes))
+	}
+	s := grpc.NewServer(options...)
 	gameoflifepb.RegisterGameOfLifeServer(s, &server{})
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	go healthChecks.UpdateServingStatus(context.Background(), healthServer, max(*healthCacheTTL, time.Second), gameoflifepb.GameOfLife_ServiceDesc.ServiceName)
 	reflection.Register(s)
 	if err := s.Serve(lis); err != nil {
 		logger.Fatal("failed to serve", zap.Error(err))
@@ -85,30 +793,17 @@ func SetupHandlers() *http.ServeMux {
 
 	mux.HandleFunc("/readiness", ReadinessHandler)
 	mux.HandleFunc("/liveness", LivenessHandler)
+	mux.Handle("/v1/", newGateway(&server{}))
 
 	return mux
 }
 
+// ReadinessHandler Returns the report of the health checks, with a 503 if any of them fails
 func ReadinessHandler(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusOK)
-
-	resp := struct {
-		Readiness bool `json:"readiness"`
-	}{
-		Readiness: true,
-	}
-
-	json.NewEncoder(w).Encode(resp)
+	health.WriteReport(w, "readiness", healthChecks.Readiness(r.Context()))
 }
 
+// LivenessHandler Returns the report of the liveness checks, with a 503 if any of them fails
 func LivenessHandler(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusOK)
-
-	resp := struct {
-		Liveness bool `json:"liveness"`
-	}{
-		Liveness: true,
-	}
-
-	json.NewEncoder(w).Encode(resp)
+	health.WriteReport(w, "liveness", healthChecks.Liveness(r.Context()))
 }
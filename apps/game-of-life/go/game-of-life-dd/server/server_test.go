@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"log"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-dd/jobs"
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-dd/sessions"
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"github.com/DataDog/datadog-go/v5/statsd"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	grpctrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/google.golang.org/grpc"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/mocktracer"
+)
+
+// startGRPCServer Starts the gRPC server with the trace interceptors of main on an in-memory listener
+func startGRPCServer(t *testing.T) *bufconn.Listener {
+	listener := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(grpctrace.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(grpctrace.StreamServerInterceptor()),
+	)
+	gameoflifepb.RegisterGameOfLifeServer(srv, &server{})
+	go func() {
+		if err := srv.Serve(listener); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	t.Cleanup(srv.Stop)
+	return listener
+}
+
+func setupServer(t *testing.T) (mocktracer.Tracer, gameoflifepb.GameOfLifeClient) {
+	mt := mocktracer.Start()
+	t.Cleanup(mt.Stop)
+	logger = zaptest.NewLogger(t)
+	jobStore = jobs.NewStore(*maxJobs, *jobTTL)
+	jobSlots = make(chan struct{}, 1)
+	sessionStore = sessions.NewMemoryStore(0, 0)
+
+	listener := startGRPCServer(t)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return mt, gameoflifepb.NewGameOfLifeClient(conn)
+}
+
+// finishedSpans Returns the finished spans of the given operation, and for grpc.server spans of the given RPC,
+// waiting for up to a second for at least n of them
+func finishedSpans(t *testing.T, mt mocktracer.Tracer, operation string, rpc string, n int) []mocktracer.Span {
+	deadline := time.Now().Add(time.Second)
+	for {
+		var spans []mocktracer.Span
+		for _, span := range mt.FinishedSpans() {
+			if span.OperationName() == operation && (rpc == "" || span.Tag(ext.ResourceName) == "/gameoflifepb.GameOfLife/"+rpc) {
+				spans = append(spans, span)
+			}
+		}
+		if len(spans) >= n || time.Now().After(deadline) {
+			if len(spans) < n {
+				t.Fatalf("Got %v %v spans, expected %v", len(spans), operation, n)
+			}
+			return spans
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRunGamesSpans(t *testing.T) {
+	mt, client := setupServer(t)
+
+	_, err := client.RunGames(context.Background(), &gameoflifepb.BatchGameRequest{
+		Requests: []*gameoflifepb.GameRequest{
+			{Board: "[[0,1,0],[0,1,0],[0,1,0]]", NumGens: 1},
+			{Board: "[[1,1],[1,1]]", NumGens: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	// Every game of the batch is a child span of the span of the RPC
+	rpcSpan := finishedSpans(t, mt, "grpc.server", "RunGames", 1)[0]
+	for _, span := range finishedSpans(t, mt, "RunBatchGame", "", 2) {
+		if span.ParentID() != rpcSpan.SpanID() || span.TraceID() != rpcSpan.TraceID() {
+			t.Errorf("Got parent %v, expected the RunGames span %v", span.ParentID(), rpcSpan.SpanID())
+		}
+		if span.Tag("rungames_server.game.request.topology") != "BOUNDED" {
+			t.Errorf("Got topology %v, expected BOUNDED", span.Tag("rungames_server.game.request.topology"))
+		}
+	}
+}
+
+func TestSubmitGameLink(t *testing.T) {
+	mt, client := setupServer(t)
+
+	job, err := client.SubmitGame(context.Background(), &gameoflifepb.GameRequest{Board: "[[0,1,0],[0,1,0],[0,1,0]]", NumGens: 1})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	// The job runs in its own trace, linked to the span of the SubmitGame request
+	rpcSpan := finishedSpans(t, mt, "grpc.server", "SubmitGame", 1)[0]
+	if rpcSpan.Tag("submitgame_server.job.id") != job.Id {
+		t.Errorf("Got job id %v, expected %v", rpcSpan.Tag("submitgame_server.job.id"), job.Id)
+	}
+	jobSpan := finishedSpans(t, mt, "RunJob", "", 1)[0]
+	if jobSpan.TraceID() == rpcSpan.TraceID() {
+		t.Errorf("Got the trace of the SubmitGame request, expected a new trace")
+	}
+	links := jobSpan.Links()
+	if len(links) != 1 {
+		t.Fatalf("Got %v links, expected 1", len(links))
+	}
+	if links[0].TraceID == 0 || links[0].SpanID == 0 {
+		t.Errorf("Got link %+v, expected non-zero trace and span ids", links[0])
+	}
+	if links[0].TraceID != rpcSpan.TraceID() || links[0].SpanID != rpcSpan.SpanID() {
+		t.Errorf("Got link %+v, expected trace %v and span %v", links[0], rpcSpan.TraceID(), rpcSpan.SpanID())
+	}
+	if w3c, ok := rpcSpan.Context().(ddtrace.SpanContextW3C); ok {
+		traceID := w3c.TraceID128Bytes()
+		if high := binary.BigEndian.Uint64(traceID[:8]); links[0].TraceIDHigh != high {
+			t.Errorf("Got trace id high bits %x, expected %x", links[0].TraceIDHigh, high)
+		}
+	}
+}
+
+func TestJobAndSessionSpans(t *testing.T) {
+	mt, client := setupServer(t)
+	ctx := context.Background()
+
+	job, err := client.SubmitGame(ctx, &gameoflifepb.GameRequest{Board: "[[0,1,0],[0,1,0],[0,1,0]]", NumGens: 1})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	_, err = client.GetJob(ctx, &gameoflifepb.GetJobRequest{Id: job.Id})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	_, err = client.CancelJob(ctx, &gameoflifepb.CancelJobRequest{Id: job.Id})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	_, err = client.ListJobs(ctx, &gameoflifepb.ListJobsRequest{})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	session, err := client.CreateSession(ctx, &gameoflifepb.CreateSessionRequest{Game: &gameoflifepb.GameRequest{Board: "[[0,1,0],[0,1,0],[0,1,0]]"}})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	_, err = client.Step(ctx, &gameoflifepb.StepRequest{SessionId: session.Id})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	_, err = client.GetSession(ctx, &gameoflifepb.GetSessionRequest{SessionId: session.Id})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	_, err = client.DeleteSession(ctx, &gameoflifepb.DeleteSessionRequest{SessionId: session.Id})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	// Every RPC tags the span of the interceptor
+	var tests = []struct {
+		rpc      string
+		tag      string
+		expected any
+	}{
+		{"GetJob", "getjob_server.request.id", job.Id},
+		{"CancelJob", "canceljob_server.request.id", job.Id},
+		{"ListJobs", "listjobs_server.response.num_jobs", nil},
+		{"CreateSession", "createsession_server.session.id", session.Id},
+		{"Step", "step_server.session.id", session.Id},
+		{"GetSession", "getsession_server.session.id", session.Id},
+		{"DeleteSession", "deletesession_server.request.session_id", session.Id},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rpc, func(t *testing.T) {
+			span := finishedSpans(t, mt, "grpc.server", tt.rpc, 1)[0]
+			value := span.Tag(tt.tag)
+			if value == nil || (tt.expected != nil && value != tt.expected) {
+				t.Errorf("Got %v = %v, expected %v", tt.tag, value, tt.expected)
+			}
+		})
+	}
+}
+
+// countingClient is a DogStatsD client summing the counts sent to it by name
+type countingClient struct {
+	statsd.NoOpClient
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func (c *countingClient) Count(name string, value int64, tags []string, rate float64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[name] += value
+	return nil
+}
+
+func TestHashLifeMetrics(t *testing.T) {
+	_, client := setupServer(t)
+	counts := &countingClient{counts: map[string]int64{}}
+	defer func(client statsd.ClientInterface) { statsdClient = client }(statsdClient)
+	statsdClient = counts
+
+	_, err := client.RunGame(context.Background(), &gameoflifepb.GameRequest{
+		Board:   "[[0,1,0],[0,0,1],[1,1,1]]",
+		NumGens: 1000,
+		Engine:  gameoflifepb.Engine_HASHLIFE,
+	})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	// The glider repeats itself, so the memoization has hits as well as misses
+	for _, name := range []string{"gameoflife.hashlife.cache.hits", "gameoflife.hashlife.cache.misses"} {
+		if counts.counts[name] == 0 {
+			t.Errorf("Got no %v, expected some", name)
+		}
+	}
+}
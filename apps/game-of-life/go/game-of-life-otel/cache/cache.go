@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Cache stores the responses of games by the key of their request. Cached responses must not be modified.
+type Cache interface {
+	// Get Returns the response cached under key, and false if there is none
+	Get(ctx context.Context, key string) (*gameoflifepb.GameResponse, bool, error)
+	// Set Caches the response under key
+	Set(ctx context.Context, key string, response *gameoflifepb.GameResponse) error
+}
+
+// Key Returns the cache key of the game request, a hash of all the fields of the request as they all change its response
+func Key(gameRequest *gameoflifepb.GameRequest) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(gameRequest)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+)
+
+// redisKeyPrefix is the prefix of the Redis keys of the cached responses
+const redisKeyPrefix = "gameoflife:response:"
+
+// Redis is a Cache storing the responses in Redis, which shares them between servers
+// and expires them with the TTL of the keys
+type Redis struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+}
+
+// NewRedis Returns a Redis cache keeping every response for ttl, or until evicted by Redis if ttl is 0.
+// The client can be instrumented by the caller to trace the Redis commands.
+func NewRedis(client redis.UniversalClient, ttl time.Duration) *Redis {
+	return &Redis{client: client, ttl: ttl}
+}
+
+func (c *Redis) Get(ctx context.Context, key string) (*gameoflifepb.GameResponse, bool, error) {
+	data, err := c.client.Get(ctx, redisKeyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	response := &gameoflifepb.GameResponse{}
+	if err := proto.Unmarshal(data, response); err != nil {
+		return nil, false, err
+	}
+	return response, true, nil
+}
+
+func (c *Redis) Set(ctx context.Context, key string, response *gameoflifepb.GameResponse) error {
+	data, err := proto.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, redisKeyPrefix+key, data, c.ttl).Err()
+}
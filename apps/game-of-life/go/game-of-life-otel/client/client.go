@@ -15,8 +15,11 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 var logger *zap.Logger
@@ -25,6 +28,19 @@ var logger *zap.Logger
 
 type Client interface {
 	RunGame(ctx context.Context, in *gameoflifepb.GameRequest, opts ...grpc.CallOption) (*gameoflifepb.GameResponse, error)
+	RunGameStream(ctx context.Context, in *gameoflifepb.GameRequest, opts ...grpc.CallOption) (gameoflifepb.GameOfLife_RunGameStreamClient, error)
+	ListPatterns(ctx context.Context, in *gameoflifepb.ListPatternsRequest, opts ...grpc.CallOption) (*gameoflifepb.ListPatternsResponse, error)
+	GetPattern(ctx context.Context, in *gameoflifepb.GetPatternRequest, opts ...grpc.CallOption) (*gameoflifepb.Pattern, error)
+	RunGames(ctx context.Context, in *gameoflifepb.BatchGameRequest, opts ...grpc.CallOption) (*gameoflifepb.BatchGameResponse, error)
+	SubmitGame(ctx context.Context, in *gameoflifepb.GameRequest, opts ...grpc.CallOption) (*gameoflifepb.Job, error)
+	GetJob(ctx context.Context, in *gameoflifepb.GetJobRequest, opts ...grpc.CallOption) (*gameoflifepb.Job, error)
+	CancelJob(ctx context.Context, in *gameoflifepb.CancelJobRequest, opts ...grpc.CallOption) (*gameoflifepb.Job, error)
+	ListJobs(ctx context.Context, in *gameoflifepb.ListJobsRequest, opts ...grpc.CallOption) (*gameoflifepb.ListJobsResponse, error)
+	CreateSession(ctx context.Context, in *gameoflifepb.CreateSessionRequest, opts ...grpc.CallOption) (*gameoflifepb.Session, error)
+	Step(ctx context.Context, in *gameoflifepb.StepRequest, opts ...grpc.CallOption) (*gameoflifepb.Session, error)
+	GetSession(ctx context.Context, in *gameoflifepb.GetSessionRequest, opts ...grpc.CallOption) (*gameoflifepb.Session, error)
+	DeleteSession(ctx context.Context, in *gameoflifepb.DeleteSessionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CheckConnection(ctx context.Context) error
 	Close() error
 }
 
@@ -92,32 +108,324 @@ func (c *gameOfLifeClient) RunGame(ctx context.Context, gameRequest *gameoflifep
 	span.SetAttributes(
 		attribute.String("rungame_client.request.board", gameRequest.Board),
 		attribute.Int("rungame_client.request.num_gens", int(gameRequest.NumGens)),
+		attribute.String("rungame_client.request.rule", gameRequest.Rule),
+		attribute.String("rungame_client.request.topology", gameRequest.Topology.String()),
+		attribute.String("rungame_client.request.engine", gameRequest.Engine.String()),
+		attribute.String("rungame_client.request.format", gameRequest.Format.String()),
 	)
 	gopts := c.cfg.options()
 	r, err := c.grpcClient.RunGame(ctx, gameRequest, gopts...)
 	if err != nil {
 		runGameLogger.Error("Calling grpcClient.RunGame",
 			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
 		)
 		span.RecordError(err)
 		return r, err
 	}
 	span.SetAttributes(
 		attribute.String("rungame_client.response.board", r.Board),
+		attribute.Int("rungame_client.response.final_generation", int(r.FinalGeneration)),
+		attribute.Int("rungame_client.response.period", int(r.Period)),
+		attribute.Bool("rungame_client.response.extinct", r.Extinct),
 	)
 	return r, err
 }
 
+// RunGameStream opens a stream of every generation of the game. The stream is bound to ctx
+// instead of the query timeout, so callers must cancel ctx once they are done receiving.
+func (c *gameOfLifeClient) RunGameStream(ctx context.Context, gameRequest *gameoflifepb.GameRequest, opts ...grpc.CallOption) (gameoflifepb.GameOfLife_RunGameStreamClient, error) {
+	ctx = withSourceMetadata(ctx, c.source)
+	span := trace.SpanFromContext(ctx)
+	runGameStreamLogger := logger.With(
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+	)
+
+	span.SetAttributes(
+		attribute.String("rungame_stream_client.request.board", gameRequest.Board),
+		attribute.Int("rungame_stream_client.request.num_gens", int(gameRequest.NumGens)),
+		attribute.String("rungame_stream_client.request.rule", gameRequest.Rule),
+		attribute.String("rungame_stream_client.request.topology", gameRequest.Topology.String()),
+		attribute.String("rungame_stream_client.request.engine", gameRequest.Engine.String()),
+		attribute.String("rungame_stream_client.request.format", gameRequest.Format.String()),
+	)
+	stream, err := c.grpcClient.RunGameStream(ctx, gameRequest, opts...)
+	if err != nil {
+		runGameStreamLogger.Error("Calling grpcClient.RunGameStream",
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+	return stream, nil
+}
+
+// ListPatterns lists the built-in patterns of the server
+func (c *gameOfLifeClient) ListPatterns(ctx context.Context, in *gameoflifepb.ListPatternsRequest, opts ...grpc.CallOption) (*gameoflifepb.ListPatternsResponse, error) {
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+	span := trace.SpanFromContext(ctx)
+
+	r, err := c.grpcClient.ListPatterns(ctx, in, append(c.cfg.options(), opts...)...)
+	if err != nil {
+		logger.Error("Calling grpcClient.ListPatterns",
+			zap.Error(err),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("listpatterns_client.response.num_patterns", len(r.Patterns)))
+	return r, nil
+}
+
+// GetPattern gets the built-in pattern of the server with the given name
+func (c *gameOfLifeClient) GetPattern(ctx context.Context, in *gameoflifepb.GetPatternRequest, opts ...grpc.CallOption) (*gameoflifepb.Pattern, error) {
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("getpattern_client.request.name", in.Name))
+
+	r, err := c.grpcClient.GetPattern(ctx, in, append(c.cfg.options(), opts...)...)
+	if err != nil {
+		logger.Error("Calling grpcClient.GetPattern",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+	return r, nil
+}
+
+// RunGames runs a batch of games, returning the result of every game
+func (c *gameOfLifeClient) RunGames(ctx context.Context, in *gameoflifepb.BatchGameRequest, opts ...grpc.CallOption) (*gameoflifepb.BatchGameResponse, error) {
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int("rungames_client.request.num_games", len(in.Requests)))
+
+	r, err := c.grpcClient.RunGames(ctx, in, append(c.cfg.options(), opts...)...)
+	if err != nil {
+		logger.Error("Calling grpcClient.RunGames",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+	return r, nil
+}
+
+// SubmitGame submits a game run in the background, returning its job
+func (c *gameOfLifeClient) SubmitGame(ctx context.Context, in *gameoflifepb.GameRequest, opts ...grpc.CallOption) (*gameoflifepb.Job, error) {
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int("submitgame_client.request.num_gens", int(in.NumGens)))
+
+	r, err := c.grpcClient.SubmitGame(ctx, in, append(c.cfg.options(), opts...)...)
+	if err != nil {
+		logger.Error("Calling grpcClient.SubmitGame",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("submitgame_client.response.job_id", r.Id))
+	return r, nil
+}
+
+// GetJob gets the job of the server with the given id
+func (c *gameOfLifeClient) GetJob(ctx context.Context, in *gameoflifepb.GetJobRequest, opts ...grpc.CallOption) (*gameoflifepb.Job, error) {
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("getjob_client.request.id", in.Id))
+
+	r, err := c.grpcClient.GetJob(ctx, in, append(c.cfg.options(), opts...)...)
+	if err != nil {
+		logger.Error("Calling grpcClient.GetJob",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+	return r, nil
+}
+
+// CancelJob cancels the job of the server with the given id
+func (c *gameOfLifeClient) CancelJob(ctx context.Context, in *gameoflifepb.CancelJobRequest, opts ...grpc.CallOption) (*gameoflifepb.Job, error) {
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("canceljob_client.request.id", in.Id))
+
+	r, err := c.grpcClient.CancelJob(ctx, in, append(c.cfg.options(), opts...)...)
+	if err != nil {
+		logger.Error("Calling grpcClient.CancelJob",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+	return r, nil
+}
+
+// ListJobs lists the jobs of the server
+func (c *gameOfLifeClient) ListJobs(ctx context.Context, in *gameoflifepb.ListJobsRequest, opts ...grpc.CallOption) (*gameoflifepb.ListJobsResponse, error) {
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+	span := trace.SpanFromContext(ctx)
+
+	r, err := c.grpcClient.ListJobs(ctx, in, append(c.cfg.options(), opts...)...)
+	if err != nil {
+		logger.Error("Calling grpcClient.ListJobs",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("listjobs_client.response.num_jobs", len(r.Jobs)))
+	return r, nil
+}
+
+// CreateSession creates a session holding the board of a game, returning it with its id
+func (c *gameOfLifeClient) CreateSession(ctx context.Context, in *gameoflifepb.CreateSessionRequest, opts ...grpc.CallOption) (*gameoflifepb.Session, error) {
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+	span := trace.SpanFromContext(ctx)
+
+	r, err := c.grpcClient.CreateSession(ctx, in, append(c.cfg.options(), opts...)...)
+	if err != nil {
+		logger.Error("Calling grpcClient.CreateSession",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("createsession_client.response.session_id", r.Id))
+	return r, nil
+}
+
+// Step advances the board of the session with the given id
+func (c *gameOfLifeClient) Step(ctx context.Context, in *gameoflifepb.StepRequest, opts ...grpc.CallOption) (*gameoflifepb.Session, error) {
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("step_client.request.session_id", in.SessionId),
+		attribute.Int("step_client.request.num_gens", int(in.NumGens)),
+	)
+
+	r, err := c.grpcClient.Step(ctx, in, append(c.cfg.options(), opts...)...)
+	if err != nil {
+		logger.Error("Calling grpcClient.Step",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetSession gets the session of the server with the given id
+func (c *gameOfLifeClient) GetSession(ctx context.Context, in *gameoflifepb.GetSessionRequest, opts ...grpc.CallOption) (*gameoflifepb.Session, error) {
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("getsession_client.request.session_id", in.SessionId))
+
+	r, err := c.grpcClient.GetSession(ctx, in, append(c.cfg.options(), opts...)...)
+	if err != nil {
+		logger.Error("Calling grpcClient.GetSession",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+	return r, nil
+}
+
+// DeleteSession deletes the session of the server with the given id
+func (c *gameOfLifeClient) DeleteSession(ctx context.Context, in *gameoflifepb.DeleteSessionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	ctx, cancel := prepareContext(ctx, c.source, c.cfg.gRPCQueryTimeout)
+	defer cancel()
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("deletesession_client.request.session_id", in.SessionId))
+
+	r, err := c.grpcClient.DeleteSession(ctx, in, append(c.cfg.options(), opts...)...)
+	if err != nil {
+		logger.Error("Calling grpcClient.DeleteSession",
+			zap.Error(err),
+			zap.Stringer("code", status.Code(err)),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+	return r, nil
+}
+
+// CheckConnection Returns nil once the connection to the server is ready, connecting it if it is idle, or an
+// error with the state of the connection if it isn't ready before ctx is done
+func (c *gameOfLifeClient) CheckConnection(ctx context.Context) error {
+	state := c.conn.GetState()
+	if state == connectivity.Idle {
+		c.conn.Connect()
+	}
+	for state != connectivity.Ready {
+		if !c.conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("connection to %s is %s", c.conn.Target(), state)
+		}
+		state = c.conn.GetState()
+	}
+	return nil
+}
+
 func (c *gameOfLifeClient) Close() error {
 	return c.conn.Close()
 }
 
 // prepareContext adds timeouts and source metadata to the context
 func prepareContext(ctx context.Context, source string, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(withSourceMetadata(ctx, source), timeout)
+}
+
+// withSourceMetadata adds source metadata to the outgoing context
+func withSourceMetadata(ctx context.Context, source string) context.Context {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		md = metadata.New(nil)
 	}
 	md.Append("source", source)
-	return context.WithTimeout(metadata.NewOutgoingContext(ctx, md), timeout)
+	return metadata.NewOutgoingContext(ctx, md)
 }
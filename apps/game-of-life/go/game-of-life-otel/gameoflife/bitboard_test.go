@@ -0,0 +1,103 @@
+package gameoflife
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+)
+
+func randomCells(rows int, cols int, r *rand.Rand) [][]int {
+	board := make([][]int, rows)
+	for i := range board {
+		board[i] = make([]int, cols)
+		for j := range board[i] {
+			board[i][j] = r.Intn(2)
+		}
+	}
+	return board
+}
+
+func formatCells(board [][]int) string {
+	data, _ := json.Marshal(board)
+	return string(data)
+}
+
+func TestBitBoard(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, size := range [][2]int{{1, 1}, {3, 5}, {2, 64}, {4, 65}, {3, 130}} {
+		cells := randomCells(size[0], size[1], r)
+		b := bitBoardFromCells(cells)
+		if !reflect.DeepEqual(cells, b.cells()) {
+			t.Errorf("Got %v, expected %v", b.cells(), cells)
+		}
+		if expected := formatCells(cells); b.String() != expected {
+			t.Errorf("Got %v, expected %v", b.String(), expected)
+		}
+		population := 0
+		for _, row := range cells {
+			for _, cell := range row {
+				population += cell
+			}
+		}
+		if b.population() != population {
+			t.Errorf("Got population %v, expected %v", b.population(), population)
+		}
+	}
+}
+
+func TestBitStepper(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	rules := []string{"B3/S23", "B36/S23", "B2/S", "B3678/S34678", "B0/S8", "B1357/S02468"}
+	topologies := []gameoflifepb.Topology{
+		gameoflifepb.Topology_BOUNDED,
+		gameoflifepb.Topology_TORUS,
+		gameoflifepb.Topology_KLEIN_BOTTLE,
+		gameoflifepb.Topology_CYLINDER,
+	}
+	for _, size := range [][2]int{{1, 1}, {1, 3}, {3, 1}, {5, 7}, {4, 64}, {6, 65}, {5, 130}} {
+		for _, rulestring := range rules {
+			for _, topology := range topologies {
+				testname := fmt.Sprintf("%vx%v,%v,%v", size[0], size[1], rulestring, topology)
+				t.Run(testname, func(t *testing.T) {
+					rule, err := parseRule(rulestring)
+					if err != nil {
+						t.Fatalf("Error: %v", err)
+					}
+					expected := randomCells(size[0], size[1], r)
+					stepper := newBitStepper(bitBoardFromCells(expected), rule, topology)
+					for gen := 1; gen <= 8; gen++ {
+						expected = executeRules(expected, rule, topology)
+						stepper.step()
+						if ans := stepper.cur.cells(); !reflect.DeepEqual(expected, ans) {
+							t.Fatalf("Generation %v: got %v, expected %v", gen, ans, expected)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestBitStepperAllocs(t *testing.T) {
+	cells := randomCells(100, 100, rand.New(rand.NewSource(1)))
+	for _, topology := range []gameoflifepb.Topology{gameoflifepb.Topology_BOUNDED, gameoflifepb.Topology_KLEIN_BOTTLE} {
+		stepper := newBitStepper(bitBoardFromCells(cells), ConwayRule, topology)
+		if allocs := testing.AllocsPerRun(10, stepper.step); allocs != 0 {
+			t.Errorf("Got %v allocations per generation, expected 0", allocs)
+		}
+	}
+}
+
+func BenchmarkBitStepper(b *testing.B) {
+	cells := randomCells(1000, 1000, rand.New(rand.NewSource(1)))
+	stepper := newBitStepper(bitBoardFromCells(cells), ConwayRule, gameoflifepb.Topology_TORUS)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		stepper.step()
+	}
+}
@@ -0,0 +1,83 @@
+package gameoflife
+
+import (
+	"errors"
+	"fmt"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+)
+
+// bitBoardFromProto Returns a packed copy of the given structured board
+func bitBoardFromProto(board *gameoflifepb.Board) (*bitBoard, error) {
+	rows, cols := int(board.GetHeight()), int(board.GetWidth())
+	if rows < 1 || cols < 1 {
+		return nil, errors.New("board size must be at least 1x1")
+	}
+	if len(board.GetRows()) > 0 && len(board.GetLiveCells()) > 0 {
+		return nil, errors.New("board can't have both rows and live cells")
+	}
+	if len(board.GetStates()) > 0 {
+		return nil, errors.New("board states are only supported by rules with more than 2 states")
+	}
+	b := newBitBoard(rows, cols)
+	if len(board.GetRows()) > 0 {
+		if len(board.GetRows()) != rows {
+			return nil, fmt.Errorf("board has %d rows, expected %d", len(board.GetRows()), rows)
+		}
+		rowBytes := (cols + 7) / 8
+		for i, packed := range board.GetRows() {
+			if len(packed) != rowBytes {
+				return nil, fmt.Errorf("row %d has %d bytes, expected %d", i, len(packed), rowBytes)
+			}
+			words := b.row(i)
+			for j, c := range packed {
+				words[j/8] |= uint64(c) << (8 * (j % 8))
+			}
+			if rem := cols % wordSize; rem != 0 && words[len(words)-1]>>rem != 0 {
+				return nil, fmt.Errorf("row %d has cells past column %d", i, cols-1)
+			}
+		}
+	}
+	for _, cell := range board.GetLiveCells() {
+		row, col := int(cell.GetRow()), int(cell.GetCol())
+		if row < 0 || row >= rows || col < 0 || col >= cols {
+			return nil, fmt.Errorf("live cell (%d, %d) is outside of the board", row, col)
+		}
+		if cell.GetState() > 1 {
+			return nil, fmt.Errorf("cells can only be 0's or 1's, cell (%d, %d) is %d", row, col, cell.GetState())
+		}
+		b.set(row, col, true)
+	}
+	return b, nil
+}
+
+// proto Returns the board as a structured board, with the live cells listed if sparse is true
+// and packed rows otherwise
+func (b *bitBoard) proto(sparse bool) *gameoflifepb.Board {
+	board := &gameoflifepb.Board{
+		Width:  int32(b.cols),
+		Height: int32(b.rows),
+	}
+	if sparse {
+		board.LiveCells = make([]*gameoflifepb.Cell, 0, b.population())
+		for i := 0; i < b.rows; i++ {
+			for j := 0; j < b.cols; j++ {
+				if b.get(i, j) {
+					board.LiveCells = append(board.LiveCells, &gameoflifepb.Cell{Row: int32(i), Col: int32(j)})
+				}
+			}
+		}
+		return board
+	}
+	rowBytes := (b.cols + 7) / 8
+	board.Rows = make([][]byte, b.rows)
+	for i := range board.Rows {
+		words := b.row(i)
+		packed := make([]byte, rowBytes)
+		for j := range packed {
+			packed[j] = byte(words[j/8] >> (8 * (j % 8)))
+		}
+		board.Rows[i] = packed
+	}
+	return board
+}
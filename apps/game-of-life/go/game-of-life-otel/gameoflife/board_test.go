@@ -0,0 +1,213 @@
+package gameoflife
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestBitBoardProto(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, size := range [][2]int{{1, 1}, {3, 5}, {2, 8}, {2, 64}, {4, 65}, {3, 130}} {
+		for _, sparse := range []bool{false, true} {
+			testname := fmt.Sprintf("%v,%v", size, sparse)
+			t.Run(testname, func(t *testing.T) {
+				cells := randomCells(size[0], size[1], r)
+				board := bitBoardFromCells(cells).proto(sparse)
+				if board.GetHeight() != int32(size[0]) || board.GetWidth() != int32(size[1]) {
+					t.Errorf("Got %vx%v, expected %vx%v", board.GetWidth(), board.GetHeight(), size[1], size[0])
+				}
+				b, err := bitBoardFromProto(board)
+				if err != nil {
+					t.Fatalf("Error: %v", err)
+				}
+				if !reflect.DeepEqual(cells, b.cells()) {
+					t.Errorf("Got %v, expected %v", b.cells(), cells)
+				}
+			})
+		}
+	}
+
+	packed := &gameoflifepb.Board{Width: 10, Height: 2, Rows: [][]byte{{0b00000101, 0b10}, {0, 0b01}}}
+	expected := [][]int{{1, 0, 1, 0, 0, 0, 0, 0, 0, 1}, {0, 0, 0, 0, 0, 0, 0, 0, 1, 0}}
+	if b, err := bitBoardFromProto(packed); err != nil {
+		t.Errorf("Error: %v", err)
+	} else if !reflect.DeepEqual(expected, b.cells()) {
+		t.Errorf("Got %v, expected %v", b.cells(), expected)
+	}
+}
+
+func TestBitBoardFromProtoErrors(t *testing.T) {
+	var tests = []*gameoflifepb.Board{
+		{Width: 0, Height: 1},
+		{Width: 1, Height: -1},
+		{Width: 2, Height: 1, Rows: [][]byte{{1}}, LiveCells: []*gameoflifepb.Cell{{Row: 0, Col: 0}}},
+		{Width: 2, Height: 2, Rows: [][]byte{{1}}},
+		{Width: 9, Height: 1, Rows: [][]byte{{1}}},
+		// Column 2 is past the last column
+		{Width: 2, Height: 1, Rows: [][]byte{{0b100}}},
+		{Width: 2, Height: 2, LiveCells: []*gameoflifepb.Cell{{Row: 2, Col: 0}}},
+		{Width: 2, Height: 2, LiveCells: []*gameoflifepb.Cell{{Row: 0, Col: -1}}},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v", tt)
+		t.Run(testname, func(t *testing.T) {
+			if _, err := bitBoardFromProto(tt); err == nil {
+				t.Errorf("Error not found: %v", err)
+			}
+		})
+	}
+}
+
+func TestRunStructuredBoard(t *testing.T) {
+	blinker := []*gameoflifepb.Cell{{Row: 0, Col: 1}, {Row: 1, Col: 1}, {Row: 2, Col: 1}}
+	var tests = []struct {
+		board         *gameoflifepb.Board
+		numGens       int32
+		responseBoard *gameoflifepb.Board
+	}{
+		{&gameoflifepb.Board{Width: 3, Height: 3, LiveCells: blinker}, 1,
+			&gameoflifepb.Board{Width: 3, Height: 3, LiveCells: []*gameoflifepb.Cell{{Row: 1, Col: 0}, {Row: 1, Col: 1}, {Row: 1, Col: 2}}}},
+		{&gameoflifepb.Board{Width: 3, Height: 3, Rows: [][]byte{{0b010}, {0b010}, {0b010}}}, 1,
+			&gameoflifepb.Board{Width: 3, Height: 3, Rows: [][]byte{{0}, {0b111}, {0}}}},
+		// An empty structured board has neither rows nor live cells, and is returned as rows
+		{&gameoflifepb.Board{Width: 2, Height: 1}, 1,
+			&gameoflifepb.Board{Width: 2, Height: 1, Rows: [][]byte{{0}}}},
+		{&gameoflifepb.Board{Width: 1, Height: 1, LiveCells: []*gameoflifepb.Cell{{Row: 0, Col: 0}}}, 1,
+			&gameoflifepb.Board{Width: 1, Height: 1, LiveCells: []*gameoflifepb.Cell{}}},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v,%v", tt.board, tt.numGens)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
+				// The structured board takes precedence over the JSON board
+				Board:           "[[1]]",
+				StructuredBoard: tt.board,
+				NumGens:         tt.numGens,
+			}, zaptest.NewLogger(t))
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			if ans.GetBoard() != "" {
+				t.Errorf("Got board %v, expected none", ans.GetBoard())
+			}
+			if !proto.Equal(ans.GetStructuredBoard(), tt.responseBoard) {
+				t.Errorf("Got %v, expected %v", ans.GetStructuredBoard(), tt.responseBoard)
+			}
+		})
+	}
+
+	var frames []*gameoflifepb.GenerationFrame
+	_, err := RunStream(context.Background(), &gameoflifepb.GameRequest{
+		StructuredBoard: &gameoflifepb.Board{Width: 3, Height: 3, LiveCells: blinker},
+		NumGens:         1,
+	}, zaptest.NewLogger(t), func(frame *gameoflifepb.GenerationFrame) error {
+		frames = append(frames, frame)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if len(frames) != 2 {
+		t.Fatalf("Got %v frames, expected 2", len(frames))
+	}
+	for _, frame := range frames {
+		if frame.GetBoard() != "" || len(frame.GetStructuredBoard().GetLiveCells()) != 3 {
+			t.Errorf("Got %v, expected a structured board with 3 live cells", frame)
+		}
+	}
+
+	ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
+		StructuredBoard: &gameoflifepb.Board{Width: 2, Height: 2, Rows: [][]byte{{1}}},
+		NumGens:         1,
+	}, zaptest.NewLogger(t))
+	if err == nil {
+		t.Errorf("Error not found: %v", err)
+	} else if ans.Code != gameoflifepb.ResponseCode_BAD_REQUEST {
+		t.Errorf("Got %v, expected %v", ans.Code, gameoflifepb.ResponseCode_BAD_REQUEST)
+	}
+}
+
+func TestBoardSize(t *testing.T) {
+	var tests = []struct {
+		gameRequest *gameoflifepb.GameRequest
+		rows        int
+		cols        int
+		errors      bool
+	}{
+		{&gameoflifepb.GameRequest{Board: "[[0,1,0],[0,1,0]]"}, 2, 3, false},
+		{&gameoflifepb.GameRequest{Board: "[[0],[0,1,0]]"}, 2, 3, false},
+		{&gameoflifepb.GameRequest{Board: "[[0,1"}, 0, 0, true},
+		// Only the header of RLE patterns is read
+		{&gameoflifepb.GameRequest{Board: "#N Huge\nx = 100000, y = 20000\nnot parsed", Format: gameoflifepb.BoardFormat_RLE}, 20000, 100000, false},
+		{&gameoflifepb.GameRequest{Board: "bo$2bo!", Format: gameoflifepb.BoardFormat_RLE}, 0, 0, true},
+		{&gameoflifepb.GameRequest{Board: "!Comment\n.O\n..O\n", Format: gameoflifepb.BoardFormat_PLAINTEXT}, 2, 3, false},
+		{&gameoflifepb.GameRequest{Board: "[[1]]", StructuredBoard: &gameoflifepb.Board{Width: 7, Height: 5}}, 5, 7, false},
+		{&gameoflifepb.GameRequest{Board: "[[1]]", RandomBoard: &gameoflifepb.RandomBoard{Width: 300, Height: 200, Density: 0.5}}, 200, 300, false},
+		{&gameoflifepb.GameRequest{Board: "[[1]]", Format: gameoflifepb.BoardFormat(42)}, 0, 0, true},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v", tt.gameRequest)
+		t.Run(testname, func(t *testing.T) {
+			rows, cols, err := BoardSize(tt.gameRequest)
+			if tt.errors {
+				if err == nil {
+					t.Errorf("Error not found: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			if rows != tt.rows || cols != tt.cols {
+				t.Errorf("Got %vx%v, expected %vx%v", rows, cols, tt.rows, tt.cols)
+			}
+		})
+	}
+}
+
+func TestBoardCells(t *testing.T) {
+	var tests = []struct {
+		board      string
+		structured *gameoflifepb.Board
+		format     gameoflifepb.BoardFormat
+		cells      [][]int
+		errors     bool
+	}{
+		{"[[0,1,0],[1]]", nil, gameoflifepb.BoardFormat_JSON, [][]int{{0, 1, 0}, {1, 0, 0}}, false},
+		{"[[0,1", nil, gameoflifepb.BoardFormat_JSON, nil, true},
+		{"x = 3, y = 2, rule = B3/S23\nbo$3o!", nil, gameoflifepb.BoardFormat_RLE, [][]int{{0, 1, 0}, {1, 1, 1}}, false},
+		// Dying states of rules with more than 2 states
+		{"x = 3, y = 1, rule = /2/3\n.AB!", nil, gameoflifepb.BoardFormat_RLE, [][]int{{0, 1, 2}}, false},
+		{"!Comment\n.O\nO.O\n", nil, gameoflifepb.BoardFormat_PLAINTEXT, [][]int{{0, 1, 0}, {1, 0, 1}}, false},
+		{"", &gameoflifepb.Board{Width: 2, Height: 2, LiveCells: []*gameoflifepb.Cell{{Row: 1, Col: 0}}}, gameoflifepb.BoardFormat_JSON, [][]int{{0, 0}, {1, 0}}, false},
+		{"", &gameoflifepb.Board{Width: 2, Height: 1, NumStates: 3, States: []byte{2, 1}}, gameoflifepb.BoardFormat_JSON, [][]int{{2, 1}}, false},
+		{"", &gameoflifepb.Board{Width: 0, Height: 1}, gameoflifepb.BoardFormat_JSON, nil, true},
+		{"[[1]]", nil, gameoflifepb.BoardFormat(42), nil, true},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%q/%v/%v", tt.board, tt.structured, tt.format)
+		t.Run(testname, func(t *testing.T) {
+			cells, err := BoardCells(tt.board, tt.structured, tt.format)
+			if tt.errors {
+				if err == nil {
+					t.Errorf("Error not found: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			if !reflect.DeepEqual(cells, tt.cells) {
+				t.Errorf("Got %v, expected %v", cells, tt.cells)
+			}
+		})
+	}
+}
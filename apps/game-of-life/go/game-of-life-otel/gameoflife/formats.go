@@ -0,0 +1,349 @@
+package gameoflife
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+)
+
+// rleLineLength is the maximum length of the lines of an RLE pattern written by appendRLE
+const rleLineLength = 70
+
+func validateFormat(format gameoflifepb.BoardFormat) error {
+	if _, ok := gameoflifepb.BoardFormat_name[int32(format)]; !ok {
+		return fmt.Errorf("unknown board format %d", format)
+	}
+	return nil
+}
+
+// appendBoard Appends the board to buf in the given format. The rule is written in the header of RLE patterns.
+func appendBoard(buf []byte, b *bitBoard, format gameoflifepb.BoardFormat, rule Rule) []byte {
+	switch format {
+	case gameoflifepb.BoardFormat_RLE:
+		return appendRLE(buf, b, rule)
+	case gameoflifepb.BoardFormat_PLAINTEXT:
+		return appendPlaintext(buf, b)
+	}
+	return b.appendJSON(buf)
+}
+
+// parseRLE Parses a pattern in run length encoded format, e.g.
+//
+//	#N Glider
+//	x = 3, y = 3, rule = B3/S23
+//	bob$2bo$3o!
+//
+// It Returns the board and the rule of the header, which is empty if the header has no rule.
+func parseRLE(data string) (*bitBoard, string, error) {
+	var b *bitBoard
+	rule, err := scanRLE(data, 2,
+		func(rows int, cols int) { b = newBitBoard(rows, cols) },
+		func(row int, col int, _ uint8) { b.set(row, col, true) },
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	return b, rule, nil
+}
+
+// parseStateRLE Parses a pattern in run length encoded format like parseRLE, with cells of the given number of states
+func parseStateRLE(data string, states int) (*stateBoard, string, error) {
+	var b *stateBoard
+	rule, err := scanRLE(data, states,
+		func(rows int, cols int) { b = newStateBoard(rows, cols) },
+		func(row int, col int, state uint8) { b.set(row, col, state) },
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	return b, rule, nil
+}
+
+// rleState Returns the state of a cell written with the given tag and prefix, the number of p to y letters before it,
+// or -1 if the tag is not a cell. Two-state patterns use b for dead and o for live cells, and patterns with more states
+// also use . for dead cells and A to X for states 1 to 24, prefixed with p to y for the higher states, as in Golly.
+func rleState(tag rune, prefix int, states int) int {
+	switch {
+	case prefix > 0 && (tag < 'A' || tag > 'X'):
+		return -1
+	case tag == 'b' || (states > 2 && tag == '.'):
+		return 0
+	case tag == 'o':
+		return 1
+	case states > 2 && tag >= 'A' && tag <= 'X':
+		return 24*prefix + int(tag-'A') + 1
+	}
+	return -1
+}
+
+// scanRLE Parses a pattern in run length encoded format with cells of the given number of states. newBoard is called
+// with the size of the header, and set with every cell that is not dead. It Returns the rule of the header.
+func scanRLE(data string, states int, newBoard func(rows int, cols int), set func(row int, col int, state uint8)) (string, error) {
+	lines := strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+	i, err := rleHeaderLine(lines)
+	if err != nil {
+		return "", err
+	}
+	cols, rows, rule, err := parseRLEHeader(lines[i])
+	if err != nil {
+		return "", err
+	}
+
+	newBoard(rows, cols)
+	row, col, count, prefix := 0, 0, 0, 0
+	ended := false
+	for _, line := range lines[i+1:] {
+		if ended {
+			break
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, c := range line {
+			switch {
+			case c >= '0' && c <= '9' && prefix == 0:
+				count = count*10 + int(c-'0')
+				if count > rows*cols {
+					return "", fmt.Errorf("run of %d cells is larger than the board", count)
+				}
+				continue
+			case c == ' ' || c == '\t':
+				continue
+			case states > 2 && c >= 'p' && c <= 'y' && prefix == 0:
+				prefix = int(c-'p') + 1
+				continue
+			}
+			n := max(count, 1)
+			count = 0
+			if state := rleState(c, prefix, states); state >= 0 {
+				prefix = 0
+				if state >= states {
+					return "", fmt.Errorf("cells can only be states 0 to %d, cell (%d, %d) is %d", states-1, row, col, state)
+				}
+				if col+n > cols {
+					return "", fmt.Errorf("row %d is longer than the width %d", row, cols)
+				}
+				if row >= rows {
+					return "", fmt.Errorf("pattern is taller than the height %d", rows)
+				}
+				for ; n > 0; n-- {
+					if state != 0 {
+						set(row, col, uint8(state))
+					}
+					col++
+				}
+				continue
+			}
+			switch {
+			case c == '$' && prefix == 0:
+				row, col = row+n, 0
+			case c == '!' && prefix == 0:
+				ended = true
+			default:
+				return "", fmt.Errorf("unknown RLE tag %q", c)
+			}
+			if ended {
+				break
+			}
+		}
+	}
+	if !ended {
+		return "", errors.New("missing ! at the end of the RLE pattern")
+	}
+	return rule, nil
+}
+
+// rleHeaderLine Returns the index of the header line of an RLE pattern, after the comments and empty lines
+func rleHeaderLine(lines []string) (int, error) {
+	i := 0
+	for i < len(lines) && (strings.HasPrefix(lines[i], "#") || strings.TrimSpace(lines[i]) == "") {
+		i++
+	}
+	if i == len(lines) {
+		return 0, errors.New("missing RLE header")
+	}
+	return i, nil
+}
+
+// parseRLEHeader Parses the header line of an RLE pattern, e.g. x = 3, y = 3, rule = B3/S23
+func parseRLEHeader(line string) (cols int, rows int, rule string, err error) {
+	fields := strings.Split(line, ",")
+fields:
+	for k, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return 0, 0, "", fmt.Errorf("invalid RLE header %q", line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "x", "y":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return 0, 0, "", fmt.Errorf("invalid RLE size %s = %q", key, value)
+			}
+			if key == "x" {
+				cols = n
+			} else {
+				rows = n
+			}
+		case "rule":
+			// The rule is the last field, as Larger than Life rules have commas
+			rule = strings.TrimSpace(strings.Join(append([]string{value}, fields[k+1:]...), ","))
+			break fields
+		default:
+			return 0, 0, "", fmt.Errorf("unknown RLE header field %q", key)
+		}
+	}
+	if cols == 0 || rows == 0 {
+		return 0, 0, "", fmt.Errorf("RLE header %q must set x and y", line)
+	}
+	return cols, rows, rule, nil
+}
+
+// appendRLE Appends the board to buf in run length encoded format, with lines of at most rleLineLength characters
+func appendRLE(buf []byte, b *bitBoard, rule Rule) []byte {
+	return appendRLECells(buf, b.rows, b.cols, rule.String(), 2, func(row int, col int) uint8 {
+		if b.get(row, col) {
+			return 1
+		}
+		return 0
+	})
+}
+
+// CellsRLE Returns the board of the given cells, as returned by BoardCells, in run length encoded format with the rule
+// in its header, so that clients can convert a board of any format to RLE. The empty rule is Conway's Life.
+func CellsRLE(cells [][]int, rulestring string) (string, error) {
+	rule, err := parseStateRule(rulestring)
+	if err != nil {
+		return "", err
+	}
+	cols := 0
+	for _, row := range cells {
+		cols = max(cols, len(row))
+	}
+	return string(appendRLECells(nil, len(cells), cols, rule.String(), rule.states, func(row int, col int) uint8 {
+		if col >= len(cells[row]) {
+			return 0
+		}
+		return uint8(min(max(cells[row][col], 0), rule.states-1))
+	})), nil
+}
+
+// rleTag Appends the tag of a cell of the given state to buf, as read by rleState
+func rleTag(buf []byte, state uint8, states int) []byte {
+	switch {
+	case states == 2 && state == 0:
+		return append(buf, 'b')
+	case states == 2:
+		return append(buf, 'o')
+	case state == 0:
+		return append(buf, '.')
+	case state > 24:
+		buf = append(buf, 'p'+byte((state-25)/24))
+	}
+	return append(buf, 'A'+byte((state-1)%24))
+}
+
+// appendRLECells Appends a rows x cols board of cells of the given number of states to buf in run length encoded format,
+// with lines of at most rleLineLength characters. state Returns the state of every cell.
+func appendRLECells(buf []byte, rows int, cols int, rule string, states int, state func(row int, col int) uint8) []byte {
+	buf = fmt.Appendf(buf, "x = %d, y = %d, rule = %s\n", cols, rows, rule)
+	lineStart := len(buf)
+	appendRun := func(n int, tag []byte) {
+		var token [24]byte
+		t := token[:0]
+		if n > 1 {
+			t = strconv.AppendInt(t, int64(n), 10)
+		}
+		t = append(t, tag...)
+		if len(buf)-lineStart+len(t) > rleLineLength {
+			buf = append(buf, '\n')
+			lineStart = len(buf)
+		}
+		buf = append(buf, t...)
+	}
+
+	// Empty rows are only written once the next row with live cells is found, so trailing empty rows are dropped
+	var tag [2]byte
+	pendingRows := 0
+	for i := 0; i < rows; i++ {
+		if i > 0 {
+			pendingRows++
+		}
+		col := 0
+		for col < cols {
+			s := state(i, col)
+			n := 1
+			for col+n < cols && state(i, col+n) == s {
+				n++
+			}
+			if s == 0 && col+n == cols {
+				// Trailing dead cells of a row are dropped
+				break
+			}
+			if pendingRows > 0 {
+				appendRun(pendingRows, []byte{'$'})
+				pendingRows = 0
+			}
+			appendRun(n, rleTag(tag[:0], s, states))
+			col += n
+		}
+	}
+	appendRun(1, []byte{'!'})
+	return append(buf, '\n')
+}
+
+// parsePlaintext Parses a pattern in plaintext (.cells) format, with a line of '.' for dead and 'O' for live cells per row.
+// Lines starting with '!' are comments, and rows shorter than the longest row are padded with dead cells.
+func parsePlaintext(data string) (*bitBoard, error) {
+	rows, cols := plaintextRows(data)
+	if len(rows) < 1 || cols < 1 {
+		return nil, errors.New("board size must be at least 1x1")
+	}
+	b := newBitBoard(len(rows), cols)
+	for i, line := range rows {
+		for j, c := range []byte(line) {
+			switch c {
+			case 'O', '*':
+				b.set(i, j, true)
+			case '.':
+			default:
+				return nil, fmt.Errorf("unknown plaintext cell %q in row %d", c, i)
+			}
+		}
+	}
+	return b, nil
+}
+
+// plaintextRows Returns the rows of a plaintext pattern without its comments, and the length of the longest row
+func plaintextRows(data string) ([]string, int) {
+	data = strings.TrimSuffix(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+	var rows []string
+	cols := 0
+	for _, line := range strings.Split(data, "\n") {
+		if strings.HasPrefix(line, "!") {
+			continue
+		}
+		rows = append(rows, line)
+		cols = max(cols, len(line))
+	}
+	return rows, cols
+}
+
+// appendPlaintext Appends the board to buf in plaintext (.cells) format
+func appendPlaintext(buf []byte, b *bitBoard) []byte {
+	for i := 0; i < b.rows; i++ {
+		for j := 0; j < b.cols; j++ {
+			if b.get(i, j) {
+				buf = append(buf, 'O')
+			} else {
+				buf = append(buf, '.')
+			}
+		}
+		buf = append(buf, '\n')
+	}
+	return buf
+}
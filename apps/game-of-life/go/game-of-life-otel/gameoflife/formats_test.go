@@ -0,0 +1,222 @@
+package gameoflife
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func TestParseRLE(t *testing.T) {
+	var tests = []struct {
+		data   string
+		cells  [][]int
+		rule   string
+		errors bool
+	}{
+		{"#N Glider\n#C A comment\nx = 3, y = 3, rule = B3/S23\nbob$2bo$3o!\n", [][]int{{0, 1, 0}, {0, 0, 1}, {1, 1, 1}}, "B3/S23", false},
+		{"x=3,y=2\n\nob\n$o2b!", [][]int{{1, 0, 0}, {1, 0, 0}}, "", false},
+		{"x = 2, y = 4, rule = 23/36\r\n2o2$2o!", [][]int{{1, 1}, {0, 0}, {1, 1}, {0, 0}}, "23/36", false},
+		{"x = 12, y = 1\n1\n2o!", [][]int{{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}}, "", false},
+		{"x = 2, y = 2\n!", [][]int{{0, 0}, {0, 0}}, "", false},
+		// Everything after the ! is ignored
+		{"x = 1, y = 1\no! trailing text", [][]int{{1}}, "", false},
+		{"bob$2bo$3o!", nil, "", true},
+		{"", nil, "", true},
+		{"x = 0, y = 1\n!", nil, "", true},
+		{"x = 3\nooo!", nil, "", true},
+		{"x = 3, y = 1, z = 2\nooo!", nil, "", true},
+		{"x = 2, y = 1\nooo!", nil, "", true},
+		{"x = 2, y = 1\no$o!", nil, "", true},
+		{"x = 2, y = 1\noA!", nil, "", true},
+		// Larger than Life rules have commas
+		{"x = 1, y = 1, rule = R2,C0,M1,S2..3,B3..3,NM\no!", [][]int{{1}}, "R2,C0,M1,S2..3,B3..3,NM", false},
+		{"x = 2, y = 1\noo", nil, "", true},
+		{"x = 2, y = 1\n99999999999o!", nil, "", true},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%q", tt.data)
+		t.Run(testname, func(t *testing.T) {
+			b, rule, err := parseRLE(tt.data)
+			if tt.errors {
+				if err == nil {
+					t.Errorf("Error not found: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.cells, b.cells()) || rule != tt.rule {
+				t.Errorf("Got %v %v, expected %v %v", b.cells(), rule, tt.cells, tt.rule)
+			}
+		})
+	}
+}
+
+func TestCellsRLE(t *testing.T) {
+	var tests = []struct {
+		cells [][]int
+		rule  string
+		data  string
+	}{
+		{[][]int{{0, 1, 0}, {0, 0, 1}, {1, 1, 1}}, "", "x = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n"},
+		{[][]int{{1, 1}, {0}, {0, 0}, {1, 0}}, "b36/s23", "x = 2, y = 4, rule = B36/S23\n2o3$o!\n"},
+		{[][]int{{0, 1, 2}, {2, 1, 0}}, "/2/3", "x = 3, y = 2, rule = B2/S/C3\n.AB$BA!\n"},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v,%v", tt.cells, tt.rule)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := CellsRLE(tt.cells, tt.rule)
+			if err != nil || ans != tt.data {
+				t.Errorf("Got %q, %v, expected %q", ans, err, tt.data)
+			}
+		})
+	}
+	if _, err := CellsRLE([][]int{{1}}, "B3/S23/X"); err == nil {
+		t.Errorf("Got no error for an invalid rule")
+	}
+}
+
+func TestAppendRLE(t *testing.T) {
+	var tests = []struct {
+		cells [][]int
+		rule  string
+		data  string
+	}{
+		{[][]int{{0, 1, 0}, {0, 0, 1}, {1, 1, 1}}, "B3/S23", "x = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n"},
+		{[][]int{{1, 1}, {0, 0}, {0, 0}, {1, 0}, {0, 0}}, "B36/S23", "x = 2, y = 5, rule = B36/S23\n2o3$o!\n"},
+		{[][]int{{0, 0}, {0, 0}}, "B3/S23", "x = 2, y = 2, rule = B3/S23\n!\n"},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v", tt.cells)
+		t.Run(testname, func(t *testing.T) {
+			rule, _ := parseRule(tt.rule)
+			if ans := string(appendRLE(nil, bitBoardFromCells(tt.cells), rule)); ans != tt.data {
+				t.Errorf("Got %q, expected %q", ans, tt.data)
+			}
+		})
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for _, size := range [][2]int{{1, 1}, {5, 3}, {10, 100}} {
+		cells := randomCells(size[0], size[1], r)
+		data := string(appendRLE(nil, bitBoardFromCells(cells), ConwayRule))
+		for _, line := range strings.Split(data, "\n") {
+			if len(line) > rleLineLength {
+				t.Errorf("Got line of %v characters, expected at most %v", len(line), rleLineLength)
+			}
+		}
+		b, rule, err := parseRLE(data)
+		if err != nil {
+			t.Errorf("Error: %v", err)
+		} else if !reflect.DeepEqual(cells, b.cells()) || rule != ConwayRule.String() {
+			t.Errorf("Got %v %v, expected %v %v", b.cells(), rule, cells, ConwayRule)
+		}
+	}
+}
+
+func TestPlaintext(t *testing.T) {
+	var tests = []struct {
+		data   string
+		cells  [][]int
+		errors bool
+	}{
+		{"!Name: Glider\n.O.\n..O\nOOO\n", [][]int{{0, 1, 0}, {0, 0, 1}, {1, 1, 1}}, false},
+		// Short rows are padded with dead cells, and empty lines are empty rows
+		{".O\n\n*", [][]int{{0, 1}, {0, 0}, {1, 0}}, false},
+		{"..\r\n.O\r\n", [][]int{{0, 0}, {0, 1}}, false},
+		{"", nil, true},
+		{"!Comment only\n", nil, true},
+		{".O.\n.X.", nil, true},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%q", tt.data)
+		t.Run(testname, func(t *testing.T) {
+			b, err := parsePlaintext(tt.data)
+			if tt.errors {
+				if err == nil {
+					t.Errorf("Error not found: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.cells, b.cells()) {
+				t.Errorf("Got %v, expected %v", b.cells(), tt.cells)
+			}
+		})
+	}
+
+	if ans, expected := string(appendPlaintext(nil, bitBoardFromCells([][]int{{0, 1, 0}, {0, 0, 1}, {1, 1, 1}}))), ".O.\n..O\nOOO\n"; ans != expected {
+		t.Errorf("Got %q, expected %q", ans, expected)
+	}
+}
+
+func TestRunFormats(t *testing.T) {
+	var tests = []struct {
+		board         string
+		format        gameoflifepb.BoardFormat
+		rule          string
+		numGens       int32
+		responseBoard string
+	}{
+		{"x = 3, y = 3\nbo$bo$bo!", gameoflifepb.BoardFormat_RLE, "", 1, "x = 3, y = 3, rule = B3/S23\n$3o!\n"},
+		// The rule of the RLE header is used unless the request has a rule
+		{"x = 3, y = 3, rule = B2/S\nbo$bo!", gameoflifepb.BoardFormat_RLE, "", 1, "x = 3, y = 3, rule = B2/S\nobo$obo!\n"},
+		{"x = 3, y = 3, rule = B2/S\nbo$bo!", gameoflifepb.BoardFormat_RLE, "B3/S23", 1, "x = 3, y = 3, rule = B3/S23\n!\n"},
+		{".O.\n.O.\n.O.\n", gameoflifepb.BoardFormat_PLAINTEXT, "", 1, "...\nOOO\n...\n"},
+		{"[[0,1,0],[0,1,0],[0,1,0]]", gameoflifepb.BoardFormat_JSON, "", 1, "[[0,0,0],[1,1,1],[0,0,0]]"},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%+v", &tt)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
+				Board:   tt.board,
+				Format:  tt.format,
+				Rule:    tt.rule,
+				NumGens: tt.numGens,
+			}, zaptest.NewLogger(t))
+			if err != nil {
+				t.Errorf("Error: %v", err)
+			} else if ans.GetBoard() != tt.responseBoard {
+				t.Errorf("Got %q, expected %q", ans.Board, tt.responseBoard)
+			}
+		})
+	}
+
+	var frames []string
+	_, err := RunStream(context.Background(), &gameoflifepb.GameRequest{
+		Board:   ".O.\n.O.\n.O.\n",
+		Format:  gameoflifepb.BoardFormat_PLAINTEXT,
+		NumGens: 1,
+	}, zaptest.NewLogger(t), func(frame *gameoflifepb.GenerationFrame) error {
+		frames = append(frames, frame.Board)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	} else if expected := []string{".O.\n.O.\n.O.\n", "...\nOOO\n...\n"}; !reflect.DeepEqual(expected, frames) {
+		t.Errorf("Got %q, expected %q", frames, expected)
+	}
+
+	for _, format := range []gameoflifepb.BoardFormat{gameoflifepb.BoardFormat_RLE, gameoflifepb.BoardFormat_PLAINTEXT, gameoflifepb.BoardFormat(42)} {
+		ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
+			Board:   "[[1]]",
+			Format:  format,
+			NumGens: 1,
+		}, zaptest.NewLogger(t))
+		if err == nil {
+			t.Errorf("Error not found: %v", err)
+		} else if ans.Code != gameoflifepb.ResponseCode_BAD_REQUEST {
+			t.Errorf("Got %v, expected %v", ans.Code, gameoflifepb.ResponseCode_BAD_REQUEST)
+		}
+	}
+}
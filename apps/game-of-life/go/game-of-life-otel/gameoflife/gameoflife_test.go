@@ -2,9 +2,12 @@ package gameoflife
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"hash/maphash"
 	"reflect"
 	"testing"
+	"time"
 
 	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
 
@@ -18,6 +21,7 @@ func TestRun(t *testing.T) {
 		responseCode  gameoflifepb.ResponseCode
 		responseBoard string
 	}{
+		{"[[1]]", 0, gameoflifepb.ResponseCode_OK, "[[1]]"},
 		{"[[1]]", 1, gameoflifepb.ResponseCode_OK, "[[0]]"},
 		{"[[1]]", 100, gameoflifepb.ResponseCode_OK, "[[0]]"},
 		{"[[1,1],[1,0]]", 1, gameoflifepb.ResponseCode_OK, "[[1,1],[1,1]]"},
@@ -48,14 +52,18 @@ func TestRun(t *testing.T) {
 	var errorTests = []struct {
 		board        string
 		numGens      int32
+		rule         string
 		responseCode gameoflifepb.ResponseCode
+		field        string
 	}{
-		{"invalid board", 1, gameoflifepb.ResponseCode_BAD_REQUEST},
-		{"[1,1]", 1, gameoflifepb.ResponseCode_BAD_REQUEST},
-		{"[[]]", 1, gameoflifepb.ResponseCode_BAD_REQUEST},
-		{"[]", 1, gameoflifepb.ResponseCode_BAD_REQUEST},
-		{"[1]", 1, gameoflifepb.ResponseCode_BAD_REQUEST},
-		{"[[1,0],[1,2]]", 1, gameoflifepb.ResponseCode_BAD_REQUEST},
+		{"invalid board", 1, "", gameoflifepb.ResponseCode_BAD_REQUEST, "board"},
+		{"[1,1]", 1, "", gameoflifepb.ResponseCode_BAD_REQUEST, "board"},
+		{"[[]]", 1, "", gameoflifepb.ResponseCode_BAD_REQUEST, "board"},
+		{"[]", 1, "", gameoflifepb.ResponseCode_BAD_REQUEST, "board"},
+		{"[1]", 1, "", gameoflifepb.ResponseCode_BAD_REQUEST, "board"},
+		{"[[1,0],[1,2]]", 1, "", gameoflifepb.ResponseCode_BAD_REQUEST, "board[1][1]"},
+		{"[[1,0],[1,0,0]]", 1, "", gameoflifepb.ResponseCode_BAD_REQUEST, "board[1]"},
+		{"[[1]]", 1, "B9/S23", gameoflifepb.ResponseCode_BAD_REQUEST, "rule"},
 	}
 	for _, tt := range errorTests {
 		testname := fmt.Sprintf("%+v", &tt)
@@ -63,11 +71,94 @@ func TestRun(t *testing.T) {
 			ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
 				Board:   tt.board,
 				NumGens: tt.numGens,
+				Rule:    tt.rule,
 			}, zaptest.NewLogger(t))
+			var validationErr *ValidationError
 			if err == nil {
 				t.Errorf("Error not found: %v", err)
 			} else if ans.Code != tt.responseCode {
 				t.Errorf("Got %v, expected %v", ans.Code, tt.responseCode)
+			} else if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Errorf("Got %v, expected a ValidationError of %v", err, tt.field)
+			}
+		})
+	}
+}
+
+func TestRunRules(t *testing.T) {
+	var tests = []struct {
+		board         string
+		rule          string
+		numGens       int32
+		responseBoard string
+	}{
+		{"[[0,0,0],[1,0,1],[0,0,0]]", "", 1, "[[0,0,0],[0,0,0],[0,0,0]]"},
+		{"[[0,0,0],[1,0,1],[0,0,0]]", "B3/S23", 1, "[[0,0,0],[0,0,0],[0,0,0]]"},
+		{"[[0,0,0],[1,0,1],[0,0,0]]", "B2/S", 1, "[[0,1,0],[0,1,0],[0,1,0]]"},
+		{"[[0,0,0],[1,0,1],[0,0,0]]", "/2", 1, "[[0,1,0],[0,1,0],[0,1,0]]"},
+		{"[[1,1],[1,1]]", "B36/S23", 5, "[[1,1],[1,1]]"},
+		{"[[1,1],[1,0]]", "B3678/S34678", 1, "[[0,0],[0,1]]"},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%+v", &tt)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
+				Board:   tt.board,
+				NumGens: tt.numGens,
+				Rule:    tt.rule,
+			}, zaptest.NewLogger(t))
+			if err != nil {
+				t.Errorf("Error: %v", err)
+			} else if ans.GetBoard() != tt.responseBoard {
+				t.Errorf("Got %v, expected %v", ans.Board, tt.responseBoard)
+			}
+		})
+	}
+
+	ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
+		Board:   "[[1]]",
+		NumGens: 1,
+		Rule:    "B9/S23",
+	}, zaptest.NewLogger(t))
+	if err == nil {
+		t.Errorf("Error not found: %v", err)
+	} else if ans.Code != gameoflifepb.ResponseCode_BAD_REQUEST {
+		t.Errorf("Got %v, expected %v", ans.Code, gameoflifepb.ResponseCode_BAD_REQUEST)
+	}
+}
+
+func TestParseRule(t *testing.T) {
+	var tests = []struct {
+		rulestring     string
+		expectedResult string
+	}{
+		{"", "B3/S23"},
+		{"B3/S23", "B3/S23"},
+		{"b36/s23", "B36/S23"},
+		{"S23/B3", "B3/S23"},
+		{"23/3", "B3/S23"},
+		{"B2/S", "B2/S"},
+		{"/2", "B2/S"},
+		{"B3678/S34678", "B3678/S34678"},
+		{" B1357/S1357 ", "B1357/S1357"},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%+v", &tt)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := parseRule(tt.rulestring)
+			if err != nil {
+				t.Errorf("Error: %v", err)
+			} else if ans.String() != tt.expectedResult {
+				t.Errorf("Got %v, expected %v", ans, tt.expectedResult)
+			}
+		})
+	}
+
+	var errorTests = []string{"B3", "B9/S23", "X3/S23", "B3/B3", "B3/S2/S3", "B3a/S23"}
+	for _, tt := range errorTests {
+		t.Run(tt, func(t *testing.T) {
+			if _, err := parseRule(tt); err == nil {
+				t.Errorf("Error not found for %v", tt)
 			}
 		})
 	}
@@ -91,7 +182,40 @@ func TestCalcLiveNeighbors(t *testing.T) {
 	for _, tt := range tests {
 		testname := fmt.Sprintf("%v,%v,%v,%v", &tt.row, &tt.col, &tt.board, &tt.expectedResult)
 		t.Run(testname, func(t *testing.T) {
-			ans := calcLiveNeighbors(tt.row, tt.col, tt.board)
+			ans := calcLiveNeighbors(tt.row, tt.col, tt.board, gameoflifepb.Topology_BOUNDED)
+			if ans != tt.expectedResult {
+				t.Errorf("Got %v, expected %v", ans, tt.expectedResult)
+			}
+		})
+	}
+}
+
+func TestCalcLiveNeighborsTopology(t *testing.T) {
+	board := [][]int{{0, 0, 0, 1}, {0, 0, 0, 0}, {1, 0, 0, 0}}
+	var tests = []struct {
+		row            int
+		col            int
+		topology       gameoflifepb.Topology
+		expectedResult int
+	}{
+		{1, 1, gameoflifepb.Topology_BOUNDED, 1},
+		{2, 1, gameoflifepb.Topology_BOUNDED, 1},
+		{2, 3, gameoflifepb.Topology_BOUNDED, 0},
+		{0, 0, gameoflifepb.Topology_BOUNDED, 0},
+		{2, 3, gameoflifepb.Topology_CYLINDER, 1},
+		{0, 0, gameoflifepb.Topology_CYLINDER, 1},
+		{2, 1, gameoflifepb.Topology_CYLINDER, 1},
+		{0, 0, gameoflifepb.Topology_TORUS, 2},
+		{2, 1, gameoflifepb.Topology_TORUS, 1},
+		{0, 2, gameoflifepb.Topology_TORUS, 1},
+		{0, 0, gameoflifepb.Topology_KLEIN_BOTTLE, 2},
+		{2, 1, gameoflifepb.Topology_KLEIN_BOTTLE, 2},
+		{0, 2, gameoflifepb.Topology_KLEIN_BOTTLE, 2},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v,%v,%v", tt.row, tt.col, tt.topology)
+		t.Run(testname, func(t *testing.T) {
+			ans := calcLiveNeighbors(tt.row, tt.col, board, tt.topology)
 			if ans != tt.expectedResult {
 				t.Errorf("Got %v, expected %v", ans, tt.expectedResult)
 			}
@@ -99,6 +223,153 @@ func TestCalcLiveNeighbors(t *testing.T) {
 	}
 }
 
+func TestRunCycles(t *testing.T) {
+	glider := "[[0,1,0,0,0,0],[0,0,1,0,0,0],[1,1,1,0,0,0],[0,0,0,0,0,0],[0,0,0,0,0,0],[0,0,0,0,0,0]]"
+	var tests = []struct {
+		board           string
+		numGens         int32
+		rule            string
+		topology        gameoflifepb.Topology
+		engine          gameoflifepb.Engine
+		finalGeneration int32
+		period          int32
+		extinct         bool
+	}{
+		// A block is a still life
+		{"[[1,1,0],[1,1,0],[0,0,0]]", 100, "", gameoflifepb.Topology_BOUNDED, gameoflifepb.Engine_STANDARD, 1, 1, false},
+		{"[[1,1,0],[1,0,0],[0,0,0]]", 100, "", gameoflifepb.Topology_BOUNDED, gameoflifepb.Engine_STANDARD, 2, 1, false},
+		{"[[1,0],[0,0]]", 100, "", gameoflifepb.Topology_BOUNDED, gameoflifepb.Engine_STANDARD, 2, 1, true},
+		{"[[0,0],[0,0]]", 100, "", gameoflifepb.Topology_BOUNDED, gameoflifepb.Engine_STANDARD, 1, 1, true},
+		{"[[0,1,0],[0,1,0],[0,1,0]]", 1001, "", gameoflifepb.Topology_BOUNDED, gameoflifepb.Engine_STANDARD, 2, 2, false},
+		// An empty board and a full board alternate with B0 rules
+		{"[[0,0],[0,0]]", 101, "B0/S", gameoflifepb.Topology_BOUNDED, gameoflifepb.Engine_STANDARD, 2, 2, false},
+		{glider, 100, "", gameoflifepb.Topology_TORUS, gameoflifepb.Engine_STANDARD, 24, 24, false},
+		{glider, 11, "", gameoflifepb.Topology_TORUS, gameoflifepb.Engine_STANDARD, 11, 0, false},
+		{glider, 0, "", gameoflifepb.Topology_TORUS, gameoflifepb.Engine_STANDARD, 0, 0, false},
+		// HashLife doesn't look for repeated generations, and the glider is still alive outside of the board
+		{glider, 100, "", gameoflifepb.Topology_BOUNDED, gameoflifepb.Engine_HASHLIFE, 100, 0, false},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%+v", &tt)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
+				Board:    tt.board,
+				NumGens:  tt.numGens,
+				Rule:     tt.rule,
+				Topology: tt.topology,
+				Engine:   tt.engine,
+			}, zaptest.NewLogger(t))
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			if ans.GetFinalGeneration() != tt.finalGeneration || ans.GetPeriod() != tt.period || ans.GetExtinct() != tt.extinct {
+				t.Errorf("Got final generation %v, period %v, extinct %v, expected %v, %v, %v",
+					ans.GetFinalGeneration(), ans.GetPeriod(), ans.GetExtinct(), tt.finalGeneration, tt.period, tt.extinct)
+			}
+			if tt.engine != gameoflifepb.Engine_STANDARD {
+				return
+			}
+
+			// The board must be the one of the last generation, even if the run stopped early
+			rule, _ := parseRule(tt.rule)
+			board, _ := parseBoard(tt.board, zaptest.NewLogger(t))
+			for i := int32(0); i < tt.numGens; i++ {
+				board = executeRules(board, rule, tt.topology)
+			}
+			if expected := formatCells(board); ans.GetBoard() != expected {
+				t.Errorf("Got %v, expected %v", ans.GetBoard(), expected)
+			}
+		})
+	}
+}
+
+// fixedStateEngine is an engine whose state is set by the test
+type fixedStateEngine struct {
+	engine
+	state []byte
+}
+
+func (e *fixedStateEngine) appendState(buf []byte) ([]byte, bool) {
+	return append(buf, e.state...), true
+}
+
+func TestCycleFinder(t *testing.T) {
+	f := newCycleFinder()
+	eng := &fixedStateEngine{state: []byte{1, 2}}
+	if _, found := f.find(eng, 0); found {
+		t.Fatalf("Got a cycle at the first generation")
+	}
+
+	// A different state with the hash of a recorded state isn't a repeated generation
+	eng.state = []byte{3, 4}
+	h := maphash.Bytes(f.seed, eng.state)
+	f.seen[h] = append(f.seen[h], recordedState{generation: 1, state: []byte{5, 6}})
+	if first, found := f.find(eng, 2); found {
+		t.Errorf("Got a cycle from generation %v, expected a hash collision", first)
+	}
+	eng.state = []byte{1, 2}
+	if first, found := f.find(eng, 3); !found || first != 0 {
+		t.Errorf("Got %v, %v, expected the cycle from generation 0", first, found)
+	}
+
+	// The states past maxCycleBytes aren't recorded
+	f.size = maxCycleBytes - 1
+	eng.state = []byte{7, 8}
+	f.find(eng, 4)
+	if _, found := f.find(eng, 5); found {
+		t.Errorf("Got a cycle from a state over maxCycleBytes")
+	}
+}
+
+func TestRunTopology(t *testing.T) {
+	glider := "[[0,1,0,0,0,0],[0,0,1,0,0,0],[1,1,1,0,0,0],[0,0,0,0,0,0],[0,0,0,0,0,0],[0,0,0,0,0,0]]"
+	var tests = []struct {
+		board         string
+		topology      gameoflifepb.Topology
+		numGens       int32
+		responseBoard string
+	}{
+		// A glider travels one cell diagonally every 4 generations, so it returns to its start position on a 6x6 torus after 24
+		{glider, gameoflifepb.Topology_TORUS, 24, glider},
+		{glider, gameoflifepb.Topology_TORUS, 12, "[[0,0,0,0,0,0],[0,0,0,0,0,0],[0,0,0,0,0,0],[0,0,0,0,1,0],[0,0,0,0,0,1],[0,0,0,1,1,1]]"},
+		// Without wrapping the glider turns into a block in the corner
+		{glider, gameoflifepb.Topology_BOUNDED, 24, "[[0,0,0,0,0,0],[0,0,0,0,0,0],[0,0,0,0,0,0],[0,0,0,0,0,0],[0,0,0,0,1,1],[0,0,0,0,1,1]]"},
+		// A blinker split across the left and right edges keeps oscillating when the columns wrap
+		{"[[0,0,0,0],[1,1,0,1],[0,0,0,0]]", gameoflifepb.Topology_BOUNDED, 1, "[[0,0,0,0],[0,0,0,0],[0,0,0,0]]"},
+		{"[[0,0,0,0],[1,1,0,1],[0,0,0,0]]", gameoflifepb.Topology_CYLINDER, 1, "[[1,0,0,0],[1,0,0,0],[1,0,0,0]]"},
+		{"[[0,0,0,0],[1,1,0,1],[0,0,0,0]]", gameoflifepb.Topology_CYLINDER, 2, "[[0,0,0,0],[1,1,0,1],[0,0,0,0]]"},
+		// A blinker split across the top and bottom edges is mirrored on a Klein bottle
+		{"[[0,1,0,0],[0,0,0,0],[0,1,0,0],[0,1,0,0]]", gameoflifepb.Topology_TORUS, 1, "[[0,0,0,0],[0,0,0,0],[0,0,0,0],[1,1,1,0]]"},
+		{"[[0,1,0,0],[0,0,0,0],[0,1,0,0],[0,1,0,0]]", gameoflifepb.Topology_KLEIN_BOTTLE, 1, "[[0,0,0,0],[0,0,0,0],[0,0,0,0],[0,1,1,0]]"},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v,%v", tt.topology, tt.numGens)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
+				Board:    tt.board,
+				NumGens:  tt.numGens,
+				Topology: tt.topology,
+			}, zaptest.NewLogger(t))
+			if err != nil {
+				t.Errorf("Error: %v", err)
+			} else if ans.GetBoard() != tt.responseBoard {
+				t.Errorf("Got %v, expected %v", ans.Board, tt.responseBoard)
+			}
+		})
+	}
+
+	ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
+		Board:    "[[1]]",
+		NumGens:  1,
+		Topology: gameoflifepb.Topology(42),
+	}, zaptest.NewLogger(t))
+	if err == nil {
+		t.Errorf("Error not found: %v", err)
+	} else if ans.Code != gameoflifepb.ResponseCode_BAD_REQUEST {
+		t.Errorf("Got %v, expected %v", ans.Code, gameoflifepb.ResponseCode_BAD_REQUEST)
+	}
+}
+
 func TestExecuteRules(t *testing.T) {
 	var tests = []struct {
 		fromBoard      [][]int
@@ -116,10 +387,169 @@ func TestExecuteRules(t *testing.T) {
 	for _, tt := range tests {
 		testname := fmt.Sprintf("%v,%v", &tt.fromBoard, &tt.expectedResult)
 		t.Run(testname, func(t *testing.T) {
-			ans := executeRules(tt.fromBoard)
+			ans := executeRules(tt.fromBoard, ConwayRule, gameoflifepb.Topology_BOUNDED)
 			if !reflect.DeepEqual(tt.expectedResult, ans) {
 				t.Errorf("Got %v, expected %v", ans, tt.expectedResult)
 			}
 		})
 	}
 }
+
+func TestRunStream(t *testing.T) {
+	var frames []*gameoflifepb.GenerationFrame
+	ans, err := RunStream(context.Background(), &gameoflifepb.GameRequest{
+		Board:   "[[0,1,0],[0,1,0],[0,1,0]]",
+		NumGens: 3,
+	}, zaptest.NewLogger(t), func(frame *gameoflifepb.GenerationFrame) error {
+		frames = append(frames, frame)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	expectedBoards := []string{
+		"[[0,1,0],[0,1,0],[0,1,0]]",
+		"[[0,0,0],[1,1,1],[0,0,0]]",
+		"[[0,1,0],[0,1,0],[0,1,0]]",
+	}
+	// The stream stops once generation 2 repeats generation 0
+	if len(frames) != len(expectedBoards) {
+		t.Fatalf("Got %v frames, expected %v", len(frames), len(expectedBoards))
+	}
+	for i, frame := range frames {
+		if frame.GetGeneration() != int32(i) || frame.GetBoard() != expectedBoards[i] {
+			t.Errorf("Got generation %v %v, expected generation %v %v", frame.GetGeneration(), frame.GetBoard(), i, expectedBoards[i])
+		}
+	}
+	if ans.GetBoard() != expectedBoards[1] {
+		t.Errorf("Got %v, expected %v", ans.GetBoard(), expectedBoards[1])
+	}
+	if ans.GetFinalGeneration() != 2 || ans.GetPeriod() != 2 {
+		t.Errorf("Got final generation %v and period %v, expected 2 and 2", ans.GetFinalGeneration(), ans.GetPeriod())
+	}
+
+	sendErr := errors.New("stream closed")
+	_, err = RunStream(context.Background(), &gameoflifepb.GameRequest{
+		Board:   "[[1,1],[1,1]]",
+		NumGens: 3,
+	}, zaptest.NewLogger(t), func(frame *gameoflifepb.GenerationFrame) error {
+		return sendErr
+	})
+	if err != sendErr {
+		t.Errorf("Got %v, expected %v", err, sendErr)
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	glider := "[[0,1,0,0,0,0],[0,0,1,0,0,0],[1,1,1,0,0,0],[0,0,0,0,0,0],[0,0,0,0,0,0],[0,0,0,0,0,0]]"
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	expiredCtx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	var tests = []struct {
+		ctx    context.Context
+		engine gameoflifepb.Engine
+		code   gameoflifepb.ResponseCode
+		err    error
+	}{
+		{cancelledCtx, gameoflifepb.Engine_STANDARD, gameoflifepb.ResponseCode_CANCELLED, context.Canceled},
+		{expiredCtx, gameoflifepb.Engine_STANDARD, gameoflifepb.ResponseCode_DEADLINE_EXCEEDED, context.DeadlineExceeded},
+		{cancelledCtx, gameoflifepb.Engine_HASHLIFE, gameoflifepb.ResponseCode_CANCELLED, context.Canceled},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v,%v", tt.engine, tt.code)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := Run(tt.ctx, &gameoflifepb.GameRequest{
+				Board:   glider,
+				NumGens: 100,
+				Engine:  tt.engine,
+			}, zaptest.NewLogger(t))
+			if err != tt.err {
+				t.Errorf("Got %v, expected %v", err, tt.err)
+			}
+			if ans.GetCode() != tt.code || ans.GetFinalGeneration() != 0 || ans.GetBoard() != glider {
+				t.Errorf("Got %v, expected %v at generation 0", ans, tt.code)
+			}
+		})
+	}
+
+	// Cancelling while streaming stops the game after the generation being sent
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var frames []*gameoflifepb.GenerationFrame
+	ans, err := RunStream(ctx, &gameoflifepb.GameRequest{
+		Board:   glider,
+		NumGens: 100,
+	}, zaptest.NewLogger(t), func(frame *gameoflifepb.GenerationFrame) error {
+		frames = append(frames, frame)
+		if frame.Generation == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if err != context.Canceled {
+		t.Errorf("Got %v, expected %v", err, context.Canceled)
+	}
+	if len(frames) != 4 {
+		t.Errorf("Got %v frames, expected 4", len(frames))
+	}
+	if ans.GetCode() != gameoflifepb.ResponseCode_CANCELLED || ans.GetFinalGeneration() != 3 || ans.GetBoard() != frames[3].Board || len(ans.GetStats()) != 2 {
+		t.Errorf("Got %v, expected the board of generation 3", ans)
+	}
+}
+
+func TestRunProgress(t *testing.T) {
+	// The glider doesn't repeat in 6 generations, so the standard engine reaches every generation,
+	// while HashLife jumps by powers of two
+	glider := "[[0,1,0,0,0,0],[0,0,1,0,0,0],[1,1,1,0,0,0],[0,0,0,0,0,0],[0,0,0,0,0,0],[0,0,0,0,0,0]]"
+	var tests = []struct {
+		engine      gameoflifepb.Engine
+		generations []int
+	}{
+		{gameoflifepb.Engine_STANDARD, []int{1, 2, 3, 4, 5, 6}},
+		{gameoflifepb.Engine_HASHLIFE, []int{2, 6}},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%+v", &tt)
+		t.Run(testname, func(t *testing.T) {
+			var generations []int
+			_, err := Run(context.Background(), &gameoflifepb.GameRequest{
+				Board:   glider,
+				NumGens: 6,
+				Engine:  tt.engine,
+			}, zaptest.NewLogger(t), WithProgressHook(func(generation int) {
+				generations = append(generations, generation)
+			}))
+			if err != nil {
+				t.Errorf("Got %v, expected no error", err)
+			}
+			if !reflect.DeepEqual(generations, tt.generations) {
+				t.Errorf("Got %v, expected %v", generations, tt.generations)
+			}
+		})
+	}
+}
+
+func TestRequestRule(t *testing.T) {
+	var tests = []struct {
+		gameRequest *gameoflifepb.GameRequest
+		rule        string
+	}{
+		{&gameoflifepb.GameRequest{Board: "[[1]]"}, ""},
+		{&gameoflifepb.GameRequest{Board: "[[1]]", Rule: "B36/S23"}, "B36/S23"},
+		{&gameoflifepb.GameRequest{Board: "x = 1, y = 1, rule = B2/S\no!", Format: gameoflifepb.BoardFormat_RLE}, "B2/S"},
+		{&gameoflifepb.GameRequest{Board: "x = 1, y = 1, rule = B2/S\no!", Format: gameoflifepb.BoardFormat_RLE, Rule: "B3/S23"}, "B3/S23"},
+		{&gameoflifepb.GameRequest{PatternName: "glider"}, "B3/S23"},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%+v", &tt)
+		t.Run(testname, func(t *testing.T) {
+			ans := RequestRule(tt.gameRequest)
+			if ans != tt.rule {
+				t.Errorf("Got %v, expected %v", ans, tt.rule)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package gameoflife
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+)
+
+// node is a square of 2^level x 2^level cells on an unbounded plane, stored as a quadtree.
+// Nodes are interned by their children, so equal squares share the same node.
+type node struct {
+	level      int
+	nw, ne     *node
+	sw, se     *node
+	population int
+}
+
+// quadrants identifies an interned node by its children
+type quadrants struct {
+	nw, ne, sw, se *node
+}
+
+// successorKey identifies the memoized result of advancing a node by 2^step generations
+type successorKey struct {
+	node *node
+	step int
+}
+
+// HashLifeStats are the memoization statistics of a HashLife run
+type HashLifeStats struct {
+	CacheHits   int64
+	CacheMisses int64
+	// Nodes is the number of distinct nodes created by the run
+	Nodes int64
+}
+
+// ErrNodeLimit is returned by a game run with the HASHLIFE engine that needs more nodes than its limit,
+// set by WithMaxHashLifeNodes
+var ErrNodeLimit = errors.New("the game is over the limit of hashlife nodes")
+
+// errNodeLimit is the panic of join past the limit of nodes, recovered by advance
+var errNodeLimit = errors.New("node limit reached")
+
+// hashLife is an unbounded plane advanced with the HashLife algorithm.
+// The root node covers the plane from (originRow, originCol) and all cells outside of it are dead.
+type hashLife struct {
+	rule       Rule
+	dead, live *node
+	nodes      map[quadrants]*node
+	successors map[successorKey]*node
+	empty      []*node
+	root       *node
+	originRow  int64
+	originCol  int64
+	stats      HashLifeStats
+	// maxNodes is the number of interned nodes past which the plane can't be advanced, 0 for no limit
+	maxNodes int
+}
+
+// validateHashLife Returns an error if the rule and topology can't be run on an unbounded plane
+func validateHashLife(rule Rule, topology gameoflifepb.Topology) error {
+	if rule.Birth[0] {
+		return errors.New("the hashlife engine does not support rules with B0")
+	}
+	if topology != gameoflifepb.Topology_BOUNDED {
+		return errors.New("the hashlife engine only supports an unbounded plane, with the BOUNDED topology")
+	}
+	return nil
+}
+
+// newHashLife Returns a plane holding the given board with its top left cell at (0, 0)
+func newHashLife(board *bitBoard, rule Rule) *hashLife {
+	h := &hashLife{
+		rule:       rule,
+		dead:       &node{},
+		live:       &node{population: 1},
+		nodes:      map[quadrants]*node{},
+		successors: map[successorKey]*node{},
+	}
+	level := 2
+	for 1<<level < max(board.rows, board.cols) {
+		level++
+	}
+	h.root = h.build(board, level, 0, 0)
+	return h
+}
+
+// build Returns the node of the given level with its top left cell at (row, col) of the board
+func (h *hashLife) build(board *bitBoard, level int, row int, col int) *node {
+	if row >= board.rows || col >= board.cols {
+		return h.emptyNode(level)
+	}
+	if level == 0 {
+		if board.get(row, col) {
+			return h.live
+		}
+		return h.dead
+	}
+	half := 1 << (level - 1)
+	return h.join(
+		h.build(board, level-1, row, col),
+		h.build(board, level-1, row, col+half),
+		h.build(board, level-1, row+half, col),
+		h.build(board, level-1, row+half, col+half),
+	)
+}
+
+// join Returns the interned node with the given children
+func (h *hashLife) join(nw *node, ne *node, sw *node, se *node) *node {
+	key := quadrants{nw, ne, sw, se}
+	if n, ok := h.nodes[key]; ok {
+		return n
+	}
+	n := &node{
+		level:      nw.level + 1,
+		nw:         nw,
+		ne:         ne,
+		sw:         sw,
+		se:         se,
+		population: nw.population + ne.population + sw.population + se.population,
+	}
+	if h.maxNodes > 0 && len(h.nodes) >= h.maxNodes {
+		panic(errNodeLimit)
+	}
+	h.nodes[key] = n
+	h.stats.Nodes++
+	return n
+}
+
+// emptyNode Returns the node of the given level with no live cells
+func (h *hashLife) emptyNode(level int) *node {
+	for len(h.empty) <= level {
+		if len(h.empty) == 0 {
+			h.empty = append(h.empty, h.dead)
+			continue
+		}
+		e := h.empty[len(h.empty)-1]
+		h.empty = append(h.empty, h.join(e, e, e, e))
+	}
+	return h.empty[level]
+}
+
+// centre Returns the node one level up with n in its center
+func (h *hashLife) centre(n *node) *node {
+	e := h.emptyNode(n.level - 1)
+	return h.join(
+		h.join(e, e, e, n.nw),
+		h.join(e, e, n.ne, e),
+		h.join(e, n.sw, e, e),
+		h.join(n.se, e, e, e),
+	)
+}
+
+// confined Returns true if all live cells of n are in its central square of half the size of its quadrants
+func confined(n *node) bool {
+	return n.population == n.nw.se.se.population+n.ne.sw.sw.population+n.sw.ne.ne.population+n.se.nw.nw.population
+}
+
+// life4x4 Returns the central 2x2 cells of a level 2 node after one generation
+func (h *hashLife) life4x4(n *node) *node {
+	var cells [4][4]int
+	for i, q := range [4]*node{n.nw, n.ne, n.sw, n.se} {
+		row, col := i/2*2, i%2*2
+		cells[row][col] = q.nw.population
+		cells[row][col+1] = q.ne.population
+		cells[row+1][col] = q.sw.population
+		cells[row+1][col+1] = q.se.population
+	}
+	var next [4]*node
+	for i := range next {
+		row, col := 1+i/2, 1+i%2
+		liveNeighbors := 0
+		for r := row - 1; r <= row+1; r++ {
+			for c := col - 1; c <= col+1; c++ {
+				if r != row || c != col {
+					liveNeighbors += cells[r][c]
+				}
+			}
+		}
+		next[i] = h.dead
+		if (cells[row][col] == 1 && h.rule.Survive[liveNeighbors]) || (cells[row][col] == 0 && h.rule.Birth[liveNeighbors]) {
+			next[i] = h.live
+		}
+	}
+	return h.join(next[0], next[1], next[2], next[3])
+}
+
+// successor Returns the central node one level down from n, advanced by 2^step generations.
+// The step can be at most n.level-2.
+func (h *hashLife) successor(n *node, step int) *node {
+	step = min(step, n.level-2)
+	if n.population == 0 {
+		return h.emptyNode(n.level - 1)
+	}
+	key := successorKey{n, step}
+	if result, ok := h.successors[key]; ok {
+		h.stats.CacheHits++
+		return result
+	}
+	h.stats.CacheMisses++
+
+	var result *node
+	if n.level == 2 {
+		result = h.life4x4(n)
+	} else {
+		// The nine overlapping sub-squares of n, one level down, advanced by 2^step generations
+		// (or 2^(level-3) if step is level-2)
+		c00 := h.successor(n.nw, step)
+		c01 := h.successor(h.join(n.nw.ne, n.ne.nw, n.nw.se, n.ne.sw), step)
+		c02 := h.successor(n.ne, step)
+		c10 := h.successor(h.join(n.nw.sw, n.nw.se, n.sw.nw, n.sw.ne), step)
+		c11 := h.successor(h.join(n.nw.se, n.ne.sw, n.sw.ne, n.se.nw), step)
+		c12 := h.successor(h.join(n.ne.sw, n.ne.se, n.se.nw, n.se.ne), step)
+		c20 := h.successor(n.sw, step)
+		c21 := h.successor(h.join(n.sw.ne, n.se.nw, n.sw.se, n.se.sw), step)
+		c22 := h.successor(n.se, step)
+		if step < n.level-2 {
+			result = h.join(
+				h.join(c00.se, c01.sw, c10.ne, c11.nw),
+				h.join(c01.se, c02.sw, c11.ne, c12.nw),
+				h.join(c10.se, c11.sw, c20.ne, c21.nw),
+				h.join(c11.se, c12.sw, c21.ne, c22.nw),
+			)
+		} else {
+			result = h.join(
+				h.successor(h.join(c00, c01, c10, c11), step),
+				h.successor(h.join(c01, c02, c11, c12), step),
+				h.successor(h.join(c10, c11, c20, c21), step),
+				h.successor(h.join(c11, c12, c21, c22), step),
+			)
+		}
+	}
+	h.successors[key] = result
+	return result
+}
+
+// advance Advances the plane by the given number of generations. It Returns ErrNodeLimit if the plane needs more
+// than maxNodes nodes, and the plane is then left at one of the generations it went through.
+func (h *hashLife) advance(generations uint64) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if r != errNodeLimit {
+				panic(r)
+			}
+			err = fmt.Errorf("%w: the game needs more than %d nodes", ErrNodeLimit, h.maxNodes)
+		}
+	}()
+	for generations > 0 {
+		step := bits.TrailingZeros64(generations)
+		generations &^= 1 << step
+		// Grow the root until the pattern can't escape the central node returned by successor
+		for h.root.level < step+3 || !confined(h.root) {
+			// The origin only moves once the new root is built, as centre panics past the limit of nodes
+			originRow, originCol := h.originRow-1<<(h.root.level-1), h.originCol-1<<(h.root.level-1)
+			h.root = h.centre(h.root)
+			h.originRow, h.originCol = originRow, originCol
+		}
+		h.root = h.successor(h.root, step)
+		h.originRow += 1 << (h.root.level - 1)
+		h.originCol += 1 << (h.root.level - 1)
+	}
+	return nil
+}
+
+// population Returns the number of live cells on the plane
+func (h *hashLife) population() int {
+	return h.root.population
+}
+
+// cells Returns the rows x cols window of the plane with its top left cell at (row, col)
+func (h *hashLife) cells(row int64, col int64, rows int, cols int) [][]int {
+	board := make([][]int, rows)
+	for i := range board {
+		board[i] = make([]int, cols)
+	}
+	h.fill(board, h.root, h.originRow-row, h.originCol-col)
+	return board
+}
+
+// fill Copies the live cells of n, with its top left cell at (row, col), into the board
+func (h *hashLife) fill(board [][]int, n *node, row int64, col int64) {
+	size := int64(1) << n.level
+	if n.population == 0 || row >= int64(len(board)) || col >= int64(len(board[0])) || row+size <= 0 || col+size <= 0 {
+		return
+	}
+	if n.level == 0 {
+		board[row][col] = 1
+		return
+	}
+	half := size / 2
+	h.fill(board, n.nw, row, col)
+	h.fill(board, n.ne, row, col+half)
+	h.fill(board, n.sw, row+half, col)
+	h.fill(board, n.se, row+half, col+half)
+}
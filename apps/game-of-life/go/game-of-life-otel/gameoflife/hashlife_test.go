@@ -0,0 +1,203 @@
+package gameoflife
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"go.uber.org/zap/zaptest"
+)
+
+// touchesEdge Returns true if any cell on the edge of the board is alive
+func touchesEdge(board [][]int) bool {
+	rows, cols := len(board), len(board[0])
+	for i := 0; i < rows; i++ {
+		if board[i][0] == 1 || board[i][cols-1] == 1 {
+			return true
+		}
+	}
+	for j := 0; j < cols; j++ {
+		if board[0][j] == 1 || board[rows-1][j] == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func TestHashLifeMatchesExecuteRules(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, rulestring := range []string{"B3/S23", "B36/S23", "B2/S", "B3678/S34678", "B35678/S5678"} {
+		for soup := 0; soup < 4; soup++ {
+			testname := fmt.Sprintf("%v,%v", rulestring, soup)
+			t.Run(testname, func(t *testing.T) {
+				rule, err := parseRule(rulestring)
+				if err != nil {
+					t.Fatalf("Error: %v", err)
+				}
+				board := make([][]int, 48)
+				for i := range board {
+					board[i] = make([]int, 40)
+				}
+				for i := 20; i < 28; i++ {
+					for j := 16; j < 24; j++ {
+						board[i][j] = r.Intn(2)
+					}
+				}
+
+				// Run the reference implementation until the pattern reaches the edge of the board
+				expected := [][][]int{board}
+				for len(expected) < 200 {
+					next := executeRules(expected[len(expected)-1], rule, gameoflifepb.Topology_BOUNDED)
+					if touchesEdge(next) {
+						break
+					}
+					expected = append(expected, next)
+				}
+
+				stepped := newHashLife(bitBoardFromCells(board), rule)
+				for gen := 1; gen < len(expected); gen++ {
+					stepped.advance(1)
+					if ans := stepped.cells(0, 0, len(board), len(board[0])); !reflect.DeepEqual(expected[gen], ans) {
+						t.Fatalf("Generation %v: got %v, expected %v", gen, ans, expected[gen])
+					}
+				}
+				for _, gens := range []int{1, 2, 3, 7, 8, 13, len(expected) - 1} {
+					if gens >= len(expected) {
+						continue
+					}
+					jumped := newHashLife(bitBoardFromCells(board), rule)
+					jumped.advance(uint64(gens))
+					if ans := jumped.cells(0, 0, len(board), len(board[0])); !reflect.DeepEqual(expected[gens], ans) {
+						t.Errorf("Advancing %v generations: got %v, expected %v", gens, ans, expected[gens])
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestHashLifeGlider(t *testing.T) {
+	glider := [][]int{{0, 1, 0}, {0, 0, 1}, {1, 1, 1}}
+	h := newHashLife(bitBoardFromCells(glider), ConwayRule)
+	// A glider travels one cell diagonally every 4 generations
+	h.advance(1_000_000)
+	if h.population() != 5 {
+		t.Errorf("Got population %v, expected 5", h.population())
+	}
+	if ans := h.cells(250_000, 250_000, 3, 3); !reflect.DeepEqual(glider, ans) {
+		t.Errorf("Got %v, expected %v", ans, glider)
+	}
+	if h.stats.CacheHits == 0 || h.stats.CacheMisses == 0 {
+		t.Errorf("Got %+v, expected cache hits and misses", h.stats)
+	}
+}
+
+func TestHashLifeNodeLimit(t *testing.T) {
+	blinker := [][]int{{0, 1, 0}, {0, 1, 0}, {0, 1, 0}}
+	h := newHashLife(bitBoardFromCells(blinker), ConwayRule)
+	// The limit is reached while growing the root, which leaves the plane at generation 0
+	h.maxNodes = len(h.nodes)
+	if err := h.advance(1); !errors.Is(err, ErrNodeLimit) {
+		t.Errorf("Got %v, expected %v", err, ErrNodeLimit)
+	}
+	if ans := h.cells(0, 0, 3, 3); !reflect.DeepEqual(blinker, ans) {
+		t.Errorf("Got %v, expected %v", ans, blinker)
+	}
+}
+
+func TestRunHashLife(t *testing.T) {
+	var tests = []struct {
+		board         string
+		numGens       int32
+		responseBoard string
+	}{
+		{"[[0,1,0],[0,1,0],[0,1,0]]", 1_000_001, "[[0,0,0],[1,1,1],[0,0,0]]"},
+		{"[[1,1],[1,0]]", 10, "[[1,1],[1,1]]"},
+		// The glider leaves the board, which is only a window onto the unbounded plane
+		{"[[0,1,0],[0,0,1],[1,1,1]]", 100, "[[0,0,0],[0,0,0],[0,0,0]]"},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%+v", &tt)
+		t.Run(testname, func(t *testing.T) {
+			var stats HashLifeStats
+			ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
+				Board:   tt.board,
+				NumGens: tt.numGens,
+				Engine:  gameoflifepb.Engine_HASHLIFE,
+			}, zaptest.NewLogger(t), WithHashLifeStats(&stats))
+			if err != nil {
+				t.Errorf("Error: %v", err)
+			} else if ans.GetBoard() != tt.responseBoard {
+				t.Errorf("Got %v, expected %v", ans.Board, tt.responseBoard)
+			}
+			if stats.CacheMisses == 0 {
+				t.Errorf("Got %+v, expected cache misses", stats)
+			}
+		})
+	}
+
+	var frames []string
+	_, err := RunStream(context.Background(), &gameoflifepb.GameRequest{
+		Board:   "[[0,1,0],[0,1,0],[0,1,0]]",
+		NumGens: 2,
+		Engine:  gameoflifepb.Engine_HASHLIFE,
+	}, zaptest.NewLogger(t), func(frame *gameoflifepb.GenerationFrame) error {
+		frames = append(frames, frame.Board)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	} else if expected := []string{"[[0,1,0],[0,1,0],[0,1,0]]", "[[0,0,0],[1,1,1],[0,0,0]]", "[[0,1,0],[0,1,0],[0,1,0]]"}; !reflect.DeepEqual(expected, frames) {
+		t.Errorf("Got %v, expected %v", frames, expected)
+	}
+
+	// The R-pentomino grows for a thousand generations, past a small limit of nodes
+	ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
+		Board:   "[[0,1,1],[1,1,0],[0,1,0]]",
+		NumGens: 1000,
+		Engine:  gameoflifepb.Engine_HASHLIFE,
+	}, zaptest.NewLogger(t), WithMaxHashLifeNodes(1000))
+	if !errors.Is(err, ErrNodeLimit) || ans != nil {
+		t.Errorf("Got %v, %v, expected %v", ans, err, ErrNodeLimit)
+	}
+	_, err = Run(context.Background(), &gameoflifepb.GameRequest{
+		Board:   "[[0,1,1],[1,1,0],[0,1,0]]",
+		NumGens: 1000,
+		Engine:  gameoflifepb.Engine_HASHLIFE,
+	}, zaptest.NewLogger(t), WithMaxHashLifeNodes(1_000_000))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	var errorTests = []struct {
+		rule     string
+		topology gameoflifepb.Topology
+		engine   gameoflifepb.Engine
+	}{
+		{"B0/S8", gameoflifepb.Topology_BOUNDED, gameoflifepb.Engine_HASHLIFE},
+		{"B3/S23", gameoflifepb.Topology_TORUS, gameoflifepb.Engine_HASHLIFE},
+		{"B3/S23", gameoflifepb.Topology_BOUNDED, gameoflifepb.Engine(42)},
+	}
+	for _, tt := range errorTests {
+		testname := fmt.Sprintf("%+v", &tt)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
+				Board:    "[[1]]",
+				NumGens:  1,
+				Rule:     tt.rule,
+				Topology: tt.topology,
+				Engine:   tt.engine,
+			}, zaptest.NewLogger(t))
+			if err == nil {
+				t.Errorf("Error not found: %v", err)
+			} else if ans.Code != gameoflifepb.ResponseCode_BAD_REQUEST {
+				t.Errorf("Got %v, expected %v", ans.Code, gameoflifepb.ResponseCode_BAD_REQUEST)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package gameoflife
+
+import (
+	"context"
+	"sync"
+)
+
+// StripeHook is called by a worker before it steps the rows [firstRow, lastRow) of a generation.
+// The returned function is called once the stripe is done.
+type StripeHook func(ctx context.Context, generation int, firstRow int, lastRow int) func()
+
+// stripe is a range of rows [first, last) stepped by a single worker
+type stripe struct {
+	first int
+	last  int
+}
+
+// stripePool steps a bitStepper with a fixed number of workers, each stepping a stripe of rows
+type stripePool struct {
+	stepper *bitStepper
+	stripes []stripe
+	hook    StripeHook
+	jobs    chan int
+	wg      sync.WaitGroup
+
+	// Set by step before the stripes of a generation are dispatched
+	ctx        context.Context
+	generation int
+}
+
+// newStripePool Starts workers stepping the given stepper. The board is split into one stripe per worker.
+func newStripePool(stepper *bitStepper, workers int, hook StripeHook) *stripePool {
+	rows := stepper.cur.rows
+	if workers > rows {
+		workers = rows
+	}
+	p := &stripePool{
+		stepper: stepper,
+		hook:    hook,
+		jobs:    make(chan int, workers),
+	}
+	for w := 0; w < workers; w++ {
+		p.stripes = append(p.stripes, stripe{first: w * rows / workers, last: (w + 1) * rows / workers})
+	}
+	for w := 0; w < workers; w++ {
+		go p.work()
+	}
+	return p
+}
+
+// work Steps the stripes received from the jobs channel until the pool is closed
+func (p *stripePool) work() {
+	for i := range p.jobs {
+		st := p.stripes[i]
+		var done func()
+		if p.hook != nil {
+			done = p.hook(p.ctx, p.generation, st.first, st.last)
+		}
+		for row := st.first; row < st.last; row++ {
+			p.stepper.stepRow(row)
+		}
+		if done != nil {
+			done()
+		}
+		p.wg.Done()
+	}
+}
+
+// step Advances the board by one generation, waiting for every stripe to be stepped
+func (p *stripePool) step(ctx context.Context, generation int) {
+	p.ctx, p.generation = ctx, generation
+	p.stepper.prepare()
+	p.wg.Add(len(p.stripes))
+	for i := range p.stripes {
+		p.jobs <- i
+	}
+	p.wg.Wait()
+	p.stepper.swap()
+}
+
+// close Stops the workers
+func (p *stripePool) close() {
+	close(p.jobs)
+}
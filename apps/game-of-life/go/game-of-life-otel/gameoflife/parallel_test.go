@@ -0,0 +1,73 @@
+package gameoflife
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func TestRunWorkers(t *testing.T) {
+	board := formatCells(randomCells(37, 70, rand.New(rand.NewSource(1))))
+	for _, topology := range []gameoflifepb.Topology{gameoflifepb.Topology_BOUNDED, gameoflifepb.Topology_KLEIN_BOTTLE} {
+		gameRequest := &gameoflifepb.GameRequest{
+			Board:    board,
+			NumGens:  20,
+			Topology: topology,
+		}
+		expected, err := Run(context.Background(), gameRequest, zaptest.NewLogger(t))
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		for _, workers := range []int{2, 3, 8, 100} {
+			testname := fmt.Sprintf("%v,%v", topology, workers)
+			t.Run(testname, func(t *testing.T) {
+				ans, err := Run(context.Background(), gameRequest, zaptest.NewLogger(t), WithWorkers(workers))
+				if err != nil {
+					t.Errorf("Error: %v", err)
+				} else if ans.GetBoard() != expected.GetBoard() {
+					t.Errorf("Got %v, expected %v", ans.GetBoard(), expected.GetBoard())
+				}
+			})
+		}
+	}
+}
+
+func TestRunStripeHook(t *testing.T) {
+	var mu sync.Mutex
+	rowsStepped := map[int]int{}
+	started, done := 0, 0
+	hook := func(ctx context.Context, generation int, firstRow int, lastRow int) func() {
+		mu.Lock()
+		defer mu.Unlock()
+		started++
+		rowsStepped[generation] += lastRow - firstRow
+		return func() {
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+		}
+	}
+
+	_, err := Run(context.Background(), &gameoflifepb.GameRequest{
+		// A glider doesn't repeat an earlier generation, so every generation is stepped
+		Board:   "[[0,1,0,0,0],[0,0,1,0,0],[1,1,1,0,0],[0,0,0,0,0],[0,0,0,0,0]]",
+		NumGens: 4,
+	}, zaptest.NewLogger(t), WithWorkers(2), WithStripeHook(hook))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if started != 8 || done != 8 {
+		t.Errorf("Got %v started and %v done stripes, expected 8", started, done)
+	}
+	for generation := 1; generation <= 4; generation++ {
+		if rowsStepped[generation] != 5 {
+			t.Errorf("Generation %v: got %v rows stepped, expected 5", generation, rowsStepped[generation])
+		}
+	}
+}
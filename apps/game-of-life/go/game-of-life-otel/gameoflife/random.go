@@ -0,0 +1,38 @@
+package gameoflife
+
+import (
+	"errors"
+	"math/rand/v2"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+)
+
+// validateRandomBoard Checks the size and density of a random board
+func validateRandomBoard(random *gameoflifepb.RandomBoard) error {
+	if random.GetWidth() <= 0 || random.GetHeight() <= 0 {
+		return errors.New("width and height must be positive")
+	}
+	if random.GetDensity() < 0 || random.GetDensity() > 1 {
+		return errors.New("density must be between 0 and 1")
+	}
+	return nil
+}
+
+// randomBoard Generates a random board from its seed. PCG gives the same sequence for a
+// seed on every platform and Go version, so a board can be reproduced from the seed alone.
+func randomBoard(random *gameoflifepb.RandomBoard) (*bitBoard, error) {
+	if err := validateRandomBoard(random); err != nil {
+		return nil, err
+	}
+	seed := uint64(random.GetSeed())
+	r := rand.New(rand.NewPCG(seed, seed))
+	board := newBitBoard(int(random.GetHeight()), int(random.GetWidth()))
+	for i := 0; i < board.rows; i++ {
+		for j := 0; j < board.cols; j++ {
+			if r.Float64() < random.GetDensity() {
+				board.set(i, j, true)
+			}
+		}
+	}
+	return board, nil
+}
@@ -0,0 +1,61 @@
+package gameoflife
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func TestRandomBoard(t *testing.T) {
+	var tests = []struct {
+		random     *gameoflifepb.RandomBoard
+		population int
+		err        bool
+	}{
+		{&gameoflifepb.RandomBoard{Width: 20, Height: 10, Density: 0, Seed: 1}, 0, false},
+		{&gameoflifepb.RandomBoard{Width: 20, Height: 10, Density: 1, Seed: 1}, 200, false},
+		{&gameoflifepb.RandomBoard{Width: 0, Height: 10, Density: 0.5}, 0, true},
+		{&gameoflifepb.RandomBoard{Width: 10, Height: -1, Density: 0.5}, 0, true},
+		{&gameoflifepb.RandomBoard{Width: 10, Height: 10, Density: 1.5}, 0, true},
+		{&gameoflifepb.RandomBoard{Width: 10, Height: 10, Density: -0.1}, 0, true},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%+v", &tt)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := randomBoard(tt.random)
+			if (err != nil) != tt.err {
+				t.Fatalf("Got error %v, expected error %v", err, tt.err)
+			}
+			if err == nil && ans.population() != tt.population {
+				t.Errorf("Got %v, expected %v", ans.population(), tt.population)
+			}
+		})
+	}
+
+	// The same seed gives the same board, and another seed a different one
+	random := &gameoflifepb.RandomBoard{Width: 64, Height: 64, Density: 0.3, Seed: 42}
+	first, _ := randomBoard(random)
+	second, _ := randomBoard(random)
+	if first.String() != second.String() {
+		t.Errorf("Got %v, expected %v", second, first)
+	}
+	other, _ := randomBoard(&gameoflifepb.RandomBoard{Width: 64, Height: 64, Density: 0.3, Seed: 43})
+	if other.String() == first.String() {
+		t.Errorf("Got the same board for seeds 42 and 43")
+	}
+	if population := first.population(); population < 64*64/5 || population > 64*64*2/5 {
+		t.Errorf("Got %v live cells, expected about %v", population, 64*64*3/10)
+	}
+
+	// Invalid random boards are reported on the random_board field
+	_, err := Run(context.Background(), &gameoflifepb.GameRequest{RandomBoard: &gameoflifepb.RandomBoard{Density: 0.5}, NumGens: 1}, zaptest.NewLogger(t))
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "random_board" {
+		t.Errorf("Got %v, expected a ValidationError of random_board", err)
+	}
+}
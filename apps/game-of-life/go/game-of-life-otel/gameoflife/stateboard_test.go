@@ -0,0 +1,108 @@
+package gameoflife
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestStateRLE(t *testing.T) {
+	var tests = []struct {
+		cells  [][]int
+		states int
+		data   string
+	}{
+		{[][]int{{0, 1, 2}, {0, 0, 0}, {3, 3, 0}}, 4, "x = 3, y = 3, rule = B2/S/C4\n.AB2$2C!\n"},
+		{[][]int{{24, 25, 48}, {49, 255, 0}}, 256, "x = 3, y = 2, rule = B2/S/C256\nXpApX$qAyO!\n"},
+		{[][]int{{0, 1, 1}, {1, 0, 0}}, 2, "x = 3, y = 2, rule = R2,C0,M0,S1..2,B2..3,NM\nb2o$o!\n"},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%+v", &tt)
+		t.Run(testname, func(t *testing.T) {
+			rule, _ := parseStateRule(fmt.Sprintf("B2/S/C%d", tt.states))
+			if tt.states == 2 {
+				rule, _ = parseStateRule("R2,C0,M0,S1..2,B2..3")
+			}
+			b := stateBoardFromCells(tt.cells)
+			if ans := string(appendStateBoard(nil, b, gameoflifepb.BoardFormat_RLE, rule)); ans != tt.data {
+				t.Errorf("Got %q, expected %q", ans, tt.data)
+			}
+			parsed, rulestring, err := parseStateRLE(tt.data, tt.states)
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			if parsed.String() != b.String() || rulestring != rule.String() {
+				t.Errorf("Got %v %v, expected %v %v", parsed, rulestring, b, rule)
+			}
+		})
+	}
+
+	var errorTests = []string{"x = 2, y = 1\nAD!", "x = 2, y = 1\npp!", "x = 2, y = 1\np$!", "x = 2, y = 1\nzA!", "x = 2, y = 1\nA3A!"}
+	for _, tt := range errorTests {
+		t.Run(tt, func(t *testing.T) {
+			if _, _, err := parseStateRLE(tt, 3); err == nil {
+				t.Errorf("Error not found for %q", tt)
+			}
+		})
+	}
+}
+
+func TestStateBoardProto(t *testing.T) {
+	b := stateBoardFromCells([][]int{{0, 1, 2}, {3, 0, 1}})
+	for _, sparse := range []bool{false, true} {
+		board := b.proto(sparse, 4)
+		if board.GetNumStates() != 4 {
+			t.Errorf("Got %v states, expected 4", board.GetNumStates())
+		}
+		ans, err := stateBoardFromProto(board, 4)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		if ans.String() != b.String() {
+			t.Errorf("Got %v, expected %v", ans, b)
+		}
+	}
+	// Two-state boards keep their packed rows
+	if board := stateBoardFromCells([][]int{{0, 1, 1}}).proto(false, 2); len(board.GetRows()) != 1 || board.GetNumStates() != 0 {
+		t.Errorf("Got %v, expected packed rows", board)
+	}
+
+	var errorTests = []*gameoflifepb.Board{
+		{Width: 2, Height: 1, States: []byte{0, 1, 2}},
+		{Width: 2, Height: 1, States: []byte{0, 4}},
+		{Width: 2, Height: 1, States: []byte{0, 1}, LiveCells: []*gameoflifepb.Cell{{Row: 0, Col: 0}}},
+		{Width: 2, Height: 1, LiveCells: []*gameoflifepb.Cell{{Row: 0, Col: 0, State: 4}}},
+		{Width: 2, Height: 1, LiveCells: []*gameoflifepb.Cell{{Row: 1, Col: 0, State: 2}}},
+		{Width: 0, Height: 1},
+	}
+	for _, tt := range errorTests {
+		t.Run(fmt.Sprintf("%v", tt), func(t *testing.T) {
+			if _, err := stateBoardFromProto(tt, 4); err == nil {
+				t.Errorf("Error not found for %v", tt)
+			}
+		})
+	}
+
+	// Structured boards of multi-state rules are returned in the form of the request
+	for _, board := range []*gameoflifepb.Board{
+		{Width: 3, Height: 1, States: []byte{1, 0, 1}},
+		{Width: 3, Height: 1, LiveCells: []*gameoflifepb.Cell{{Row: 0, Col: 0}, {Row: 0, Col: 2}}},
+	} {
+		ans, err := Run(context.Background(), &gameoflifepb.GameRequest{StructuredBoard: board, NumGens: 1, Rule: "/2/3"}, zaptest.NewLogger(t))
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		expected := &gameoflifepb.Board{Width: 3, Height: 1, NumStates: 3, States: []byte{2, 1, 2}}
+		if len(board.GetLiveCells()) > 0 {
+			expected = &gameoflifepb.Board{Width: 3, Height: 1, NumStates: 3, LiveCells: []*gameoflifepb.Cell{{Row: 0, Col: 0, State: 2}, {Row: 0, Col: 1}, {Row: 0, Col: 2, State: 2}}}
+		}
+		if !proto.Equal(ans.GetStructuredBoard(), expected) {
+			t.Errorf("Got %v, expected %v", ans.GetStructuredBoard(), expected)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package gameoflife
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"go.uber.org/zap/zaptest"
+)
+
+// executeStateRule Returns the board resulting from executing the given rule on the given board, counting
+// the neighbors of every cell one by one. This is the reference implementation of the stateEngine.
+func executeStateRule(fromBoard [][]int, rule stateRule, topology gameoflifepb.Topology) [][]int {
+	r := rule.radius
+	toBoard := copyBoard(fromBoard)
+	for i := range fromBoard {
+		for j := range fromBoard[i] {
+			n := 0
+			for di := -r; di <= r; di++ {
+				for dj := -r; dj <= r; dj++ {
+					if rule.neighborhood == vonNeumannNeighborhood && max(di, -di)+max(dj, -dj) > r {
+						continue
+					}
+					if di == 0 && dj == 0 && !rule.middle {
+						continue
+					}
+					if row, col, ok := wrapCoordinates(i+di, j+dj, len(fromBoard), len(fromBoard[0]), topology); ok && fromBoard[row][col] == 1 {
+						n++
+					}
+				}
+			}
+			toBoard[i][j] = int(rule.next(uint8(fromBoard[i][j]), n))
+		}
+	}
+	return toBoard
+}
+
+func TestParseStateRule(t *testing.T) {
+	var tests = []struct {
+		rulestring string
+		expected   string
+		states     int
+		lifeLike   bool
+	}{
+		{"", "B3/S23", 2, true},
+		{"b36/s23", "B36/S23", 2, true},
+		{"/2/3", "B2/S/C3", 3, false},
+		{"345/2/4", "B2/S345/C4", 4, false},
+		{"B2/S/C3", "B2/S/C3", 3, false},
+		{"B2/S/G3", "B2/S/C3", 3, false},
+		{"B3/S23/C2", "B3/S23", 2, true},
+		{"B2/S013V", "B2/S013V", 2, false},
+		{"/2/3V", "B2/S/C3V", 3, false},
+		{"R5,C0,M1,S34..58,B34..45,NM", "R5,C0,M1,S34..58,B34..45,NM", 2, false},
+		{"r2,c3,m0,s3..5,b4..4", "R2,C3,M0,S3..5,B4..4,NM", 3, false},
+		{"R3,C0,M0,S2..6,B3..5,NN", "R3,C0,M0,S2..6,B3..5,NN", 2, false},
+		{"R2,C0,M0,S1..0,B1..3,NM", "R2,C0,M0,S1..0,B1..3,NM", 2, false},
+		// Range 1 rules are written in B/S notation
+		{"R1,C0,M0,S2..3,B3..3,NM", "B3/S23", 2, true},
+		{"R1,C5,M0,S2..3,B3..3,NN", "B3/S23/C5V", 5, false},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%+v", &tt)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := parseStateRule(tt.rulestring)
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			_, lifeLike := ans.lifeLike()
+			if ans.String() != tt.expected || ans.states != tt.states || lifeLike != tt.lifeLike {
+				t.Errorf("Got %v %v %v, expected %v %v %v", ans, ans.states, lifeLike, tt.expected, tt.states, tt.lifeLike)
+			}
+			// The notation of the rule parses back to the same rule
+			if again, err := parseStateRule(ans.String()); err != nil || !reflect.DeepEqual(again, ans) {
+				t.Errorf("Got %v %v, expected %v", again, err, ans)
+			}
+		})
+	}
+
+	var errorTests = []string{
+		"B3", "B5/S/C3V", "B2/S/C1", "B2/S/C257", "B2/S/CX", "B2/S2/S3", "B2/X/C3",
+		"R0,C0,M0,S1..2,B1..2", "R51,C0,M0,S1..2,B1..2", "R2,C1,M0,S1..2,B1..2", "R2,C0,M2,S1..2,B1..2",
+		"R2,C0,M0,S1..25,B1..2", "R2,C0,M0,S1..2", "R2,C0,M0,S1..2,B1..2,NX", "R2,C0,M0,S1-2,B1..2", "R2,C0,R3,S1..2,B1..2",
+	}
+	for _, tt := range errorTests {
+		t.Run(tt, func(t *testing.T) {
+			if _, err := parseStateRule(tt); err == nil {
+				t.Errorf("Error not found for %v", tt)
+			}
+		})
+	}
+}
+
+func TestStateEngine(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	// Conway's Game of Life is run like the other rules to compare the engine with executeRules
+	conway := newStateRule(2, 1, mooreNeighborhood, false)
+	conway.birth[3], conway.survive[2], conway.survive[3] = true, true, true
+	rules := []stateRule{conway}
+	for _, rulestring := range []string{"/2/3", "345/2/4", "B2/S013V", "B1/S1234/C5V", "R2,C0,M1,S3..8,B4..6,NM", "R3,C4,M0,S2..9,B3..5,NN", "R4,C0,M1,S10..20,B8..12"} {
+		rule, err := parseStateRule(rulestring)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		rules = append(rules, rule)
+	}
+	topologies := []gameoflifepb.Topology{
+		gameoflifepb.Topology_BOUNDED,
+		gameoflifepb.Topology_TORUS,
+		gameoflifepb.Topology_KLEIN_BOTTLE,
+		gameoflifepb.Topology_CYLINDER,
+	}
+	for _, size := range [][2]int{{1, 1}, {3, 1}, {5, 7}, {12, 9}} {
+		for _, rule := range rules {
+			for _, topology := range topologies {
+				testname := fmt.Sprintf("%vx%v,%v,%v", size[0], size[1], rule, topology)
+				t.Run(testname, func(t *testing.T) {
+					expected := randomCells(size[0], size[1], r)
+					e := newStateEngine(stateBoardFromCells(expected), rule, topology)
+					for gen := 1; gen <= 8; gen++ {
+						if rule.states == 2 && rule.radius == 1 && rule.neighborhood == mooreNeighborhood {
+							life, _ := parseRule(rule.String())
+							expected = executeRules(expected, life, topology)
+						} else {
+							expected = executeStateRule(expected, rule, topology)
+						}
+						e.advance(1)
+						if ans := e.String(); ans != formatCells(expected) {
+							t.Fatalf("Generation %v: got %v, expected %v", gen, ans, formatCells(expected))
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestRunStateRules(t *testing.T) {
+	var tests = []struct {
+		gameRequest *gameoflifepb.GameRequest
+		board       string
+		population  int32
+	}{
+		// In Brian's Brain, live cells always die, and go through a dying state before they are dead
+		{&gameoflifepb.GameRequest{Board: "[[1,0,1]]", NumGens: 1, Rule: "/2/3"}, "[[2,1,2]]", 1},
+		{&gameoflifepb.GameRequest{Board: "[[1,0,1]]", NumGens: 2, Rule: "/2/3"}, "[[0,2,0]]", 0},
+		{&gameoflifepb.GameRequest{Board: "[[1,0,2]]", NumGens: 1, Rule: "/2/3"}, "[[2,0,0]]", 0},
+		// The rule of the RLE header is used, and the cells of multi-state rules are written with letters
+		{&gameoflifepb.GameRequest{Board: "x = 3, y = 1, rule = B2/S/C3\nA.A!", NumGens: 1, Format: gameoflifepb.BoardFormat_RLE}, "x = 3, y = 1, rule = B2/S/C3\nBAB!\n", 1},
+		{&gameoflifepb.GameRequest{Board: "x = 3, y = 1\nobo!", NumGens: 1, Rule: "R1,C0,M1,S1..1,B2..2", Format: gameoflifepb.BoardFormat_RLE}, "x = 3, y = 1, rule = R1,C0,M1,S1..1,B2..2,NM\n3o!\n", 3},
+		// A von Neumann neighborhood only counts the orthogonal neighbors
+		{&gameoflifepb.GameRequest{Board: "[[0,1,0],[1,0,1],[0,1,0]]", NumGens: 1, Rule: "B4/SV"}, "[[0,0,0],[0,1,0],[0,0,0]]", 1},
+		{&gameoflifepb.GameRequest{Board: "[[1,0,1],[0,0,0],[1,0,1]]", NumGens: 1, Rule: "B4/SV"}, "[[0,0,0],[0,0,0],[0,0,0]]", 0},
+		// Patterns start with live cells only
+		{&gameoflifepb.GameRequest{PatternName: "blinker", Placement: &gameoflifepb.PatternPlacement{Row: 1}, NumGens: 1, Rule: "/2/3"}, "[[1,0,1],[2,2,2],[1,0,1]]", 4},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v", tt.gameRequest)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := Run(context.Background(), tt.gameRequest, zaptest.NewLogger(t))
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			if ans.GetBoard() != tt.board || ans.GetStats()[len(ans.GetStats())-1].GetPopulation() != tt.population {
+				t.Errorf("Got %v %v, expected %v %v", ans.GetBoard(), ans.GetStats(), tt.board, tt.population)
+			}
+		})
+	}
+
+	var errorTests = []struct {
+		gameRequest *gameoflifepb.GameRequest
+		field       string
+	}{
+		{&gameoflifepb.GameRequest{Board: "[[1,0,3]]", NumGens: 1, Rule: "/2/3"}, "board[0][2]"},
+		{&gameoflifepb.GameRequest{Board: "[[1,0,2]]", NumGens: 1, Rule: "B3/S23V"}, "board[0][2]"},
+		{&gameoflifepb.GameRequest{Board: "x = 3, y = 1\nA.C!", NumGens: 1, Rule: "/2/3", Format: gameoflifepb.BoardFormat_RLE}, "board"},
+		{&gameoflifepb.GameRequest{Board: "O.O", NumGens: 1, Rule: "/2/3", Format: gameoflifepb.BoardFormat_PLAINTEXT}, "format"},
+		{&gameoflifepb.GameRequest{Board: "[[1,0,1]]", NumGens: 1, Rule: "/2/3", Engine: gameoflifepb.Engine_HASHLIFE}, "engine"},
+		{&gameoflifepb.GameRequest{Board: "[[1,0,1]]", NumGens: 1, Rule: "R2,C0,M0,S1..2"}, "rule"},
+		{&gameoflifepb.GameRequest{StructuredBoard: &gameoflifepb.Board{Width: 2, Height: 1, States: []byte{1, 3}}, NumGens: 1, Rule: "/2/3"}, "structured_board"},
+		{&gameoflifepb.GameRequest{StructuredBoard: &gameoflifepb.Board{Width: 2, Height: 1, States: []byte{1, 1}}, NumGens: 1}, "structured_board"},
+	}
+	for _, tt := range errorTests {
+		testname := fmt.Sprintf("%v", tt.gameRequest)
+		t.Run(testname, func(t *testing.T) {
+			_, err := Run(context.Background(), tt.gameRequest, zaptest.NewLogger(t))
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Errorf("Got %v, expected a ValidationError of %v", err, tt.field)
+			}
+		})
+	}
+}
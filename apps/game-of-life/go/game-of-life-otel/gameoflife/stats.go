@@ -0,0 +1,35 @@
+package gameoflife
+
+import (
+	"math/bits"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+)
+
+// maxGenerationStats is the largest number of generations of a game whose response has the stats of every generation
+const maxGenerationStats = 10_000
+
+// statsRecorder computes the statistics of each generation, counting births and deaths
+// against the previously recorded board
+type statsRecorder struct {
+	prev *bitBoard
+}
+
+// record Returns the statistics of the board of the given generation, and keeps a copy of the board
+func (r *statsRecorder) record(generation int, b *bitBoard) *gameoflifepb.GenerationStats {
+	stats := &gameoflifepb.GenerationStats{
+		Generation:  int32(generation),
+		Population:  int32(b.population()),
+		BoundingBox: b.boundingBox(),
+	}
+	if r.prev == nil {
+		r.prev = newBitBoard(b.rows, b.cols)
+	} else {
+		for k, w := range b.words {
+			stats.Births += int32(bits.OnesCount64(w &^ r.prev.words[k]))
+			stats.Deaths += int32(bits.OnesCount64(r.prev.words[k] &^ w))
+		}
+	}
+	copy(r.prev.words, b.words)
+	return stats
+}
@@ -0,0 +1,157 @@
+package gameoflife
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/protobuf/proto"
+)
+
+// cellStats Returns the statistics of the board computed cell by cell
+func cellStats(generation int, prev [][]int, board [][]int) *gameoflifepb.GenerationStats {
+	stats := &gameoflifepb.GenerationStats{Generation: int32(generation)}
+	for i, row := range board {
+		for j, cell := range row {
+			if cell == 1 {
+				stats.Population++
+				if stats.BoundingBox == nil {
+					stats.BoundingBox = &gameoflifepb.BoundingBox{MinRow: int32(i), MinCol: int32(j), MaxRow: int32(i), MaxCol: int32(j)}
+				}
+				stats.BoundingBox.MinCol = min(stats.BoundingBox.MinCol, int32(j))
+				stats.BoundingBox.MaxRow = int32(i)
+				stats.BoundingBox.MaxCol = max(stats.BoundingBox.MaxCol, int32(j))
+			}
+			if prev != nil && cell == 1 && prev[i][j] == 0 {
+				stats.Births++
+			}
+			if prev != nil && cell == 0 && prev[i][j] == 1 {
+				stats.Deaths++
+			}
+		}
+	}
+	return stats
+}
+
+func TestStatsRecorder(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, size := range [][2]int{{1, 1}, {5, 7}, {3, 64}, {6, 130}} {
+		testname := fmt.Sprintf("%v", size)
+		t.Run(testname, func(t *testing.T) {
+			board := randomCells(size[0], size[1], r)
+			recorder := &statsRecorder{}
+			var prev [][]int
+			for generation := 0; generation < 5; generation++ {
+				ans := recorder.record(generation, bitBoardFromCells(board))
+				if expected := cellStats(generation, prev, board); !proto.Equal(expected, ans) {
+					t.Errorf("Got %v, expected %v", ans, expected)
+				}
+				prev, board = board, executeRules(board, ConwayRule, gameoflifepb.Topology_TORUS)
+			}
+		})
+	}
+}
+
+func TestRunStats(t *testing.T) {
+	var summary *gameoflifepb.GenerationStats
+	ans, err := Run(context.Background(), &gameoflifepb.GameRequest{
+		Board:           "[[0,1,0,0],[0,1,0,0],[0,1,0,0],[0,0,0,0]]",
+		NumGens:         5,
+		GenerationStats: true,
+	}, zaptest.NewLogger(t), WithSummaryHook(func(stats *gameoflifepb.GenerationStats) { summary = stats }))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	// The summary counts the births and deaths since generation 0 even with the stats of every generation
+	if summary.GetGeneration() != 2 || summary.GetBirths() != 0 || summary.GetDeaths() != 0 {
+		t.Errorf("Got summary %v, expected generation 2 with no births or deaths", summary)
+	}
+	// The blinker repeats generation 0 at generation 2
+	expected := []*gameoflifepb.GenerationStats{
+		{Generation: 0, Population: 3, BoundingBox: &gameoflifepb.BoundingBox{MinRow: 0, MinCol: 1, MaxRow: 2, MaxCol: 1}},
+		{Generation: 1, Population: 3, Births: 2, Deaths: 2, BoundingBox: &gameoflifepb.BoundingBox{MinRow: 1, MinCol: 0, MaxRow: 1, MaxCol: 2}},
+		{Generation: 2, Population: 3, Births: 2, Deaths: 2, BoundingBox: &gameoflifepb.BoundingBox{MinRow: 0, MinCol: 1, MaxRow: 2, MaxCol: 1}},
+	}
+	if len(ans.Stats) != len(expected) {
+		t.Fatalf("Got %v stats, expected %v", len(ans.Stats), len(expected))
+	}
+	for i := range expected {
+		if !proto.Equal(expected[i], ans.Stats[i]) {
+			t.Errorf("Got %v, expected %v", ans.Stats[i], expected[i])
+		}
+	}
+
+	// By default only the first and last generations are kept, with the births and deaths since generation 0
+	ans, err = Run(context.Background(), &gameoflifepb.GameRequest{
+		Board:   "[[0,1,0,0],[0,1,0,0],[0,1,0,0],[0,0,0,0]]",
+		NumGens: 5,
+	}, zaptest.NewLogger(t))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if len(ans.Stats) != 2 || ans.Stats[1].Generation != 2 || ans.Stats[1].Births != 0 || ans.Stats[1].Deaths != 0 {
+		t.Errorf("Got %v, expected the stats of generations 0 and 2", ans.Stats)
+	}
+
+	// The stats of every generation are only kept for games of up to maxGenerationStats generations
+	_, err = Run(context.Background(), &gameoflifepb.GameRequest{
+		Board:           "[[1,0],[0,0]]",
+		NumGens:         maxGenerationStats + 1,
+		GenerationStats: true,
+	}, zaptest.NewLogger(t))
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "generation_stats" {
+		t.Errorf("Got %v, expected a ValidationError of generation_stats", err)
+	}
+
+	ans, err = Run(context.Background(), &gameoflifepb.GameRequest{
+		Board:   "[[1,0],[0,0]]",
+		NumGens: 3,
+	}, zaptest.NewLogger(t))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	last := ans.Stats[len(ans.Stats)-1]
+	if last.Population != 0 || last.BoundingBox != nil {
+		t.Errorf("Got %v, expected an empty board", last)
+	}
+
+	// HashLife only has the statistics of the first and last generations
+	ans, err = Run(context.Background(), &gameoflifepb.GameRequest{
+		Board:   "[[0,1,0],[0,1,0],[0,1,0]]",
+		NumGens: 1001,
+		Engine:  gameoflifepb.Engine_HASHLIFE,
+	}, zaptest.NewLogger(t))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if len(ans.Stats) != 2 || ans.Stats[1].Generation != 1001 || ans.Stats[1].Births != 2 || ans.Stats[1].Deaths != 2 {
+		t.Errorf("Got %v, expected the stats of generations 0 and 1001", ans.Stats)
+	}
+
+	var frames []*gameoflifepb.GenerationFrame
+	ans, err = RunStream(context.Background(), &gameoflifepb.GameRequest{
+		Board:           "[[0,1,0],[0,1,0],[0,1,0]]",
+		NumGens:         3,
+		GenerationStats: true,
+	}, zaptest.NewLogger(t), func(frame *gameoflifepb.GenerationFrame) error {
+		frames = append(frames, frame)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if len(frames) != len(ans.Stats) {
+		t.Fatalf("Got %v stats, expected %v", len(ans.Stats), len(frames))
+	}
+	for i, frame := range frames {
+		if !proto.Equal(ans.Stats[i], frame.Stats) {
+			t.Errorf("Got %v, expected %v", frame.Stats, ans.Stats[i])
+		}
+	}
+}
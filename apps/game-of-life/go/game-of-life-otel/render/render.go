@@ -0,0 +1,254 @@
+package render
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxCellSize is the largest size of a cell in pixels
+	MaxCellSize = 64
+	// maxPixels is the largest number of pixels of an image, or of all the frames of an animation
+	maxPixels = 64 << 20
+)
+
+// ErrTooLarge is returned for boards whose image would be over maxPixels
+var ErrTooLarge = errors.New("image too large")
+
+// Board holds the state of every cell of a board, from 0 for dead, with one byte per cell
+type Board [][]uint8
+
+// NewBoard Returns the board of the given cells, such as those returned by gameoflife.BoardCells
+func NewBoard(cells [][]int) Board {
+	board := make(Board, len(cells))
+	for i, row := range cells {
+		board[i] = make([]uint8, len(row))
+		for j, cell := range row {
+			board[i][j] = uint8(cell)
+		}
+	}
+	return board
+}
+
+// config holds the configuration of the rendering of boards
+type config struct {
+	cellSize int
+	alive    color.RGBA
+	dead     color.RGBA
+	delay    time.Duration
+}
+
+// Option is a function that alters the rendering config
+type Option func(*config)
+
+// WithCellSize Sets the size of a cell in pixels, 8 by default, between 1 and MaxCellSize
+func WithCellSize(pixels int) Option {
+	return func(c *config) {
+		c.cellSize = min(max(pixels, 1), MaxCellSize)
+	}
+}
+
+// WithAliveColor Sets the color of the live cells, black by default. The dying states of rules with more
+// than 2 states fade from the live color to the dead color.
+func WithAliveColor(alive color.Color) Option {
+	return func(c *config) {
+		c.alive = color.RGBAModel.Convert(alive).(color.RGBA)
+	}
+}
+
+// WithDeadColor Sets the color of the dead cells, white by default
+func WithDeadColor(dead color.Color) Option {
+	return func(c *config) {
+		c.dead = color.RGBAModel.Convert(dead).(color.RGBA)
+	}
+}
+
+// WithFrameDelay Sets the time every generation of an animation is shown for, 100ms by default
+func WithFrameDelay(delay time.Duration) Option {
+	return func(c *config) {
+		c.delay = delay
+	}
+}
+
+// newConfig Returns the config with the given options applied over the defaults
+func newConfig(options []Option) *config {
+	c := &config{
+		cellSize: 8,
+		alive:    color.RGBA{A: 0xff},
+		dead:     color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		delay:    100 * time.Millisecond,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// ParseColor Parses a color written as 6 hexadecimal digits, e.g. ff8800 or #ff8800
+func ParseColor(s string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(s, "#")
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(hex) != 6 {
+		return color.RGBA{}, fmt.Errorf("color %q must be 6 hexadecimal digits", s)
+	}
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}, nil
+}
+
+// boardSize Returns the number of rows and columns of the board, the length of its longest row
+func boardSize(cells Board) (rows int, cols int) {
+	for _, row := range cells {
+		cols = max(cols, len(row))
+	}
+	return len(cells), cols
+}
+
+// maxState Returns the highest state of the cells of the generations, at least 1
+func maxState(generations ...Board) int {
+	highest := 1
+	for _, cells := range generations {
+		for _, row := range cells {
+			for _, cell := range row {
+				highest = max(highest, int(cell))
+			}
+		}
+	}
+	return highest
+}
+
+// palette Returns the colors of the cells of states 0 to highest: the dead color, the live color,
+// then the dying states fading to the dead color
+func (c *config) palette(highest int) color.Palette {
+	palette := color.Palette{c.dead, c.alive}
+	for state := 2; state <= highest; state++ {
+		t := float64(state-1) / float64(highest)
+		blend := func(from uint8, to uint8) uint8 {
+			return uint8(float64(from) + t*(float64(to)-float64(from)))
+		}
+		palette = append(palette, color.RGBA{
+			R: blend(c.alive.R, c.dead.R),
+			G: blend(c.alive.G, c.dead.G),
+			B: blend(c.alive.B, c.dead.B),
+			A: 0xff,
+		})
+	}
+	return palette
+}
+
+// CheckSize Returns ErrTooLarge if the given number of frames of a rows x cols board, drawn with the options,
+// are over the size of an image, so that a board can be rejected before its generations are computed
+func CheckSize(rows int, cols int, frames int, options ...Option) error {
+	return newConfig(options).checkSize(rows, cols, frames)
+}
+
+// checkSize Returns ErrTooLarge if the given number of frames of the board don't fit in maxPixels
+func (c *config) checkSize(rows int, cols int, frames int) error {
+	pixels := int64(rows*c.cellSize) * int64(cols*c.cellSize) * int64(frames)
+	if pixels > maxPixels {
+		return fmt.Errorf("%w: %d pixels, the limit is %d", ErrTooLarge, pixels, maxPixels)
+	}
+	return nil
+}
+
+// paletted Returns the board drawn with the palette, of states 0 to len(palette) - 1
+func (c *config) paletted(cells Board, rows int, cols int, palette color.Palette) *image.Paletted {
+	img := image.NewPaletted(image.Rect(0, 0, cols*c.cellSize, rows*c.cellSize), palette)
+	for i, row := range cells {
+		for j, cell := range row {
+			if cell == 0 {
+				continue
+			}
+			index := uint8(min(int(cell), len(palette)-1))
+			for y := i * c.cellSize; y < (i+1)*c.cellSize; y++ {
+				line := img.Pix[y*img.Stride+j*c.cellSize : y*img.Stride+(j+1)*c.cellSize]
+				for x := range line {
+					line[x] = index
+				}
+			}
+		}
+	}
+	return img
+}
+
+// PNG Writes the board, with the state of every cell, as a PNG image
+func PNG(w io.Writer, cells Board, options ...Option) error {
+	c := newConfig(options)
+	rows, cols := boardSize(cells)
+	if rows == 0 || cols == 0 {
+		return errors.New("board size must be at least 1x1")
+	}
+	if err := c.checkSize(rows, cols, 1); err != nil {
+		return err
+	}
+	return png.Encode(w, c.paletted(cells, rows, cols, c.palette(maxState(cells))))
+}
+
+// GIF Writes the generations of a game as an animated GIF, looping over the generations. Every generation is drawn
+// with the size of the largest generation, and the dying states have the same colors in every generation.
+func GIF(w io.Writer, generations []Board, options ...Option) error {
+	c := newConfig(options)
+	rows, cols := 0, 0
+	for _, cells := range generations {
+		r, k := boardSize(cells)
+		rows, cols = max(rows, r), max(cols, k)
+	}
+	if rows == 0 || cols == 0 {
+		return errors.New("board size must be at least 1x1")
+	}
+	if err := c.checkSize(rows, cols, len(generations)); err != nil {
+		return err
+	}
+	palette := c.palette(maxState(generations...))
+	// The delay of a GIF frame is in hundredths of a second
+	delay := int(c.delay / (10 * time.Millisecond))
+	animation := &gif.GIF{}
+	for _, cells := range generations {
+		animation.Image = append(animation.Image, c.paletted(cells, rows, cols, palette))
+		animation.Delay = append(animation.Delay, delay)
+	}
+	return gif.EncodeAll(w, animation)
+}
+
+// hexColor Returns the color in the #rrggbb notation of SVG
+func hexColor(c color.Color) string {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	return fmt.Sprintf("#%02x%02x%02x", rgba.R, rgba.G, rgba.B)
+}
+
+// SVG Writes the board, with the state of every cell, as an SVG image with a rectangle for every cell that is not dead
+func SVG(w io.Writer, cells Board, options ...Option) error {
+	c := newConfig(options)
+	rows, cols := boardSize(cells)
+	if rows == 0 || cols == 0 {
+		return errors.New("board size must be at least 1x1")
+	}
+	if err := c.checkSize(rows, cols, 1); err != nil {
+		return err
+	}
+	palette := c.palette(maxState(cells))
+	width, height := cols*c.cellSize, rows*c.cellSize
+
+	buf := bufio.NewWriter(w)
+	fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(buf, `<rect width="%d" height="%d" fill="%s"/>`+"\n", width, height, hexColor(c.dead))
+	for i, row := range cells {
+		for j, cell := range row {
+			if cell == 0 {
+				continue
+			}
+			fmt.Fprintf(buf, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+				j*c.cellSize, i*c.cellSize, c.cellSize, c.cellSize, hexColor(palette[min(int(cell), len(palette)-1)]))
+		}
+	}
+	buf.WriteString("</svg>\n")
+	return buf.Flush()
+}
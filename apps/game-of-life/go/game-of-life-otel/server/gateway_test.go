@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// sendGatewayRequest Sends a request to the gateway, and Returns the response
+func sendGatewayRequest(method string, target string, body string) *httptest.ResponseRecorder {
+	wr := httptest.NewRecorder()
+	SetupHandlers().ServeHTTP(wr, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return wr
+}
+
+func TestGatewayRunGame(t *testing.T) {
+	exporter, _, _ := setupServer(t)
+
+	wr := sendGatewayRequest(http.MethodPost, "/v1/games:run", `{"board": "[[0,1,0],[0,1,0],[0,1,0]]", "num_gens": 1}`)
+	assert.Equal(t, http.StatusOK, wr.Code)
+	assert.Equal(t, "application/json", wr.Result().Header.Get("Content-Type"))
+	resp := &gameoflifepb.GameResponse{}
+	assert.NoError(t, protojson.Unmarshal(wr.Body.Bytes(), resp))
+	assert.Equal(t, "[[0,0,0],[1,1,1],[0,0,0]]", resp.Board)
+
+	// The span of the RPC is a child of the otelhttp span of the gateway request
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "RunGame", spans[0].Name)
+		assert.Equal(t, "POST /v1/games:run", spans[1].Name)
+		assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+		assert.Contains(t, spans[1].Attributes, attribute.String("gateway.rpc", "/gameoflifepb.GameOfLife/RunGame"))
+		assert.Contains(t, spans[1].Attributes, attribute.String("http.route", "/v1/games:run"))
+	}
+}
+
+func TestGatewayErrors(t *testing.T) {
+	var tests = []struct {
+		method string
+		target string
+		body   string
+		code   int
+	}{
+		{http.MethodPost, "/v1/games:run", `{"board": "[[1,1],[1,2]]", "num_gens": 1}`, http.StatusBadRequest},
+		{http.MethodPost, "/v1/games:run", `{"board": `, http.StatusBadRequest},
+		// Bodies over maxRequestBytes are ResourceExhausted, which the gateway returns as a 429
+		{http.MethodPost, "/v1/games:run", `{"board": "` + strings.Repeat("0", 1<<20) + `"}`, http.StatusTooManyRequests},
+		{http.MethodGet, "/v1/patterns/unknown", "", http.StatusNotFound},
+		{http.MethodGet, "/v1/jobs/unknown", "", http.StatusNotFound},
+		{http.MethodPost, "/v1/sessions/unknown:step", "", http.StatusNotFound},
+		{http.MethodGet, "/v1/games", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v %v", tt.method, tt.target)
+		t.Run(testname, func(t *testing.T) {
+			setupServer(t)
+			wr := sendGatewayRequest(tt.method, tt.target, tt.body)
+			if wr.Code != tt.code {
+				t.Errorf("Got %v %v, expected %v", wr.Code, wr.Body.String(), tt.code)
+			}
+			// Errors are the JSON of their gRPC status
+			var st struct {
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+			}
+			assert.NoError(t, json.Unmarshal(wr.Body.Bytes(), &st))
+			assert.NotZero(t, st.Code)
+			assert.NotEmpty(t, st.Message)
+		})
+	}
+}
+
+func TestGatewaySessions(t *testing.T) {
+	setupServer(t)
+
+	wr := sendGatewayRequest(http.MethodPost, "/v1/sessions", `{"board": "[[0,0,0],[1,1,1],[0,0,0]]"}`)
+	assert.Equal(t, http.StatusOK, wr.Code)
+	session := &gameoflifepb.Session{}
+	assert.NoError(t, protojson.Unmarshal(wr.Body.Bytes(), session))
+
+	// An empty body steps the session by 1 generation
+	wr = sendGatewayRequest(http.MethodPost, "/v1/sessions/"+session.Id+":step", "")
+	assert.Equal(t, http.StatusOK, wr.Code)
+	assert.NoError(t, protojson.Unmarshal(wr.Body.Bytes(), session))
+	assert.Equal(t, int32(1), session.Generation)
+	assert.Equal(t, "[[0,1,0],[0,1,0],[0,1,0]]", session.Game.GetBoard())
+
+	wr = sendGatewayRequest(http.MethodPost, "/v1/sessions/"+session.Id+":step", `{"num_gens": 3}`)
+	assert.NoError(t, protojson.Unmarshal(wr.Body.Bytes(), session))
+	assert.Equal(t, int32(4), session.Generation)
+
+	wr = sendGatewayRequest(http.MethodGet, "/v1/sessions/"+session.Id, "")
+	assert.Equal(t, http.StatusOK, wr.Code)
+	wr = sendGatewayRequest(http.MethodDelete, "/v1/sessions/"+session.Id, "")
+	assert.Equal(t, http.StatusOK, wr.Code)
+	assert.Equal(t, "{}", wr.Body.String())
+	wr = sendGatewayRequest(http.MethodGet, "/v1/sessions/"+session.Id, "")
+	assert.Equal(t, http.StatusNotFound, wr.Code)
+}
+
+func TestGatewayRunGameStream(t *testing.T) {
+	var tests = []struct {
+		body      string
+		numFrames int
+		err       string
+	}{
+		{`{"board": "[[0,1,0],[0,1,0],[0,1,0]]", "num_gens": 2}`, 3, ""},
+		{`{"board": "[[1,1],[1,2]]", "num_gens": 2}`, 0, "cells can only be 0's or 1's"},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v", tt.body)
+		t.Run(testname, func(t *testing.T) {
+			exporter, _, _ := setupServer(t)
+			wr := sendGatewayRequest(http.MethodPost, "/v1/games:stream", tt.body)
+
+			// Every frame is a line holding the frame as its result
+			numFrames := 0
+			scanner := bufio.NewScanner(wr.Body)
+			for scanner.Scan() {
+				var line struct {
+					Result json.RawMessage `json:"result"`
+					Error  *struct {
+						Message string `json:"message"`
+					} `json:"error"`
+				}
+				assert.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+				if line.Error != nil {
+					assert.Contains(t, line.Error.Message, tt.err)
+					continue
+				}
+				frame := &gameoflifepb.GenerationFrame{}
+				assert.NoError(t, protojson.Unmarshal(line.Result, frame))
+				assert.Equal(t, int32(numFrames), frame.Generation)
+				numFrames++
+			}
+			assert.Equal(t, tt.numFrames, numFrames)
+
+			spans := exporter.GetSpans()
+			if assert.Len(t, spans, 2) {
+				assert.Equal(t, "RunGameStream", spans[0].Name)
+				assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+			}
+		})
+	}
+}
@@ -2,47 +2,123 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/cache"
 	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/gameoflife"
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/health"
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/jobs"
 	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/logging"
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/sessions"
 	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
 
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	"github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/contrib/instrumentation/runtime"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	otelmetric "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/metric"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
-	grpcPort = flag.Int("grpcPort", 8081, "Port to be used by the gRPC server")
-	httpPort = flag.Int("httpPort", 8082, "Port to be used by the http server")
-	logger   *zap.Logger
-	tracer   trace.Tracer
+	grpcPort    = flag.Int("grpcPort", 8081, "Port to be used by the gRPC server")
+	httpPort    = flag.Int("httpPort", 8082, "Port to be used by the http server")
+	workers     = flag.Int("workers", 1, "Number of workers stepping each generation in parallel, each on a stripe of rows")
+	stripeSpans = flag.Bool("stripeSpans", false, "Create a child span for every stripe of every generation stepped by a worker")
+
+	maxRows            = flag.Int("maxRows", 1024, "Maximum number of rows of a board, 0 for no limit")
+	maxCols            = flag.Int("maxCols", 1024, "Maximum number of columns of a board, 0 for no limit")
+	maxCellGenerations = flag.Int64("maxCellGenerations", 1_000_000_000, "Maximum number of cells times generations of a game run with the STANDARD engine, 0 for no limit")
+	maxHashLifeNodes   = flag.Int("maxHashLifeNodes", 4_000_000, "Maximum number of quadtree nodes of a game run with the HASHLIFE engine, 0 for no limit")
+	maxStreamGens      = flag.Int("maxStreamGens", 100_000, "Maximum number of generations of a game streamed with RunGameStream, 0 for no limit")
+	maxRequestBytes    = flag.Int("maxRequestBytes", 1<<20, "Maximum size of a game request in bytes, 0 for no limit")
+	maxBatchSize       = flag.Int("maxBatchSize", 1000, "Maximum number of games of a RunGames batch, 0 for no limit")
+	batchConcurrency   = flag.Int("batchConcurrency", 8, "Number of games of a RunGames batch run concurrently")
+
+	cacheSize = flag.Int("cacheSize", 1024, "Number of game responses kept by the in-memory cache, 0 to disable the cache")
+	cacheTTL  = flag.Duration("cacheTTL", 10*time.Minute, "Time the game responses are cached for")
+	redisAddr = flag.String("redisAddr", "", "Address of a Redis server caching the game responses instead of the in-memory cache")
+
+	jobWorkers = flag.Int("jobWorkers", 2, "Number of jobs submitted with SubmitGame run concurrently")
+	maxJobs    = flag.Int("maxJobs", 1000, "Maximum number of jobs kept by the server, 0 for no limit")
+	jobTTL     = flag.Duration("jobTTL", time.Hour, "Time the finished jobs are kept for, 0 to keep them until the server stops")
+
+	sessionDir  = flag.String("sessionDir", "", "Directory storing the game sessions in files, which are kept in memory if unset")
+	maxSessions = flag.Int("maxSessions", 1000, "Maximum number of sessions kept by the server, 0 for no limit")
+	sessionTTL  = flag.Duration("sessionTTL", time.Hour, "Time a session is kept for after it was created or last stepped, 0 to keep it until it is deleted")
+
+	healthCacheTTL = flag.Duration("healthCacheTTL", 5*time.Second, "Time the result of the health checks is cached for, and the interval the gRPC health status is updated at")
+
+	logger *zap.Logger
+	tracer trace.Tracer
+	meter  otelmetric.Meter
+	// resultCache caches the responses of RunGame, and is nil if caching is disabled
+	resultCache cache.Cache
+	jobStore    *jobs.Store
+	// jobSlots holds a token for every running job, so that at most jobWorkers jobs run at a time
+	jobSlots     chan struct{}
+	sessionStore sessions.SessionStore
+	// healthChecks holds the checks of the readiness and liveness of the server, which also drive its gRPC health status
+	healthChecks = health.NewRegistry()
+	// traceExports records the result of the last export of spans
+	traceExports health.Recorder
+
+	hashLifeCacheHits   otelmetric.Int64Counter
+	hashLifeCacheMisses otelmetric.Int64Counter
+	population          otelmetric.Int64Histogram
+	births              otelmetric.Int64Histogram
+	deaths              otelmetric.Int64Histogram
+	boundingBoxWidth    otelmetric.Int64Gauge
+	boundingBoxHeight   otelmetric.Int64Gauge
+	rejectedRequests    otelmetric.Int64Counter
+	cacheHits           otelmetric.Int64Counter
+	cacheMisses         otelmetric.Int64Counter
+	cacheEvictions      otelmetric.Int64Counter
 )
 
+// recordedExporter is a span exporter recording the result of every export in traceExports
+type recordedExporter struct {
+	sdktrace.SpanExporter
+}
+
+func (e recordedExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	traceExports.Record(err)
+	return err
+}
+
 func InitTracerProvider(ctx context.Context) *sdktrace.TracerProvider {
 	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithInsecure())
 	if err != nil {
 		logger.Fatal("Constructing new exporter", zap.Error(err))
 	}
 	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
+		sdktrace.WithBatcher(recordedExporter{exporter}),
 	)
 	otel.SetTracerProvider(tp)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
@@ -59,36 +135,775 @@ func InitMeter(ctx context.Context) *metric.MeterProvider {
 	return provider
 }
 
+// InitInstruments Creates the metric instruments recorded by the server
+func InitInstruments() error {
+	var err error
+	hashLifeCacheHits, err = meter.Int64Counter("gameoflife.hashlife.cache.hits",
+		otelmetric.WithDescription("Number of HashLife successors found in the memoization cache"))
+	if err != nil {
+		return err
+	}
+	hashLifeCacheMisses, err = meter.Int64Counter("gameoflife.hashlife.cache.misses",
+		otelmetric.WithDescription("Number of HashLife successors computed because they were not in the memoization cache"))
+	if err != nil {
+		return err
+	}
+	population, err = meter.Int64Histogram("gameoflife.population",
+		otelmetric.WithDescription("Number of live cells of the last generation of a game"))
+	if err != nil {
+		return err
+	}
+	births, err = meter.Int64Histogram("gameoflife.births_since_start",
+		otelmetric.WithDescription("Number of cells alive in the last generation of a game that were dead in generation 0"))
+	if err != nil {
+		return err
+	}
+	deaths, err = meter.Int64Histogram("gameoflife.deaths_since_start",
+		otelmetric.WithDescription("Number of cells dead in the last generation of a game that were alive in generation 0"))
+	if err != nil {
+		return err
+	}
+	boundingBoxWidth, err = meter.Int64Gauge("gameoflife.bounding_box.width",
+		otelmetric.WithDescription("Width of the smallest rectangle holding the live cells of the last generation of a game"))
+	if err != nil {
+		return err
+	}
+	boundingBoxHeight, err = meter.Int64Gauge("gameoflife.bounding_box.height",
+		otelmetric.WithDescription("Height of the smallest rectangle holding the live cells of the last generation of a game"))
+	if err != nil {
+		return err
+	}
+	rejectedRequests, err = meter.Int64Counter("gameoflife.requests.rejected",
+		otelmetric.WithDescription("Number of game requests rejected for being over a limit, by reason"))
+	if err != nil {
+		return err
+	}
+	cacheHits, err = meter.Int64Counter("gameoflife.cache.hits",
+		otelmetric.WithDescription("Number of game responses served from the cache"))
+	if err != nil {
+		return err
+	}
+	cacheMisses, err = meter.Int64Counter("gameoflife.cache.misses",
+		otelmetric.WithDescription("Number of games run because their response was not in the cache"))
+	if err != nil {
+		return err
+	}
+	cacheEvictions, err = meter.Int64Counter("gameoflife.cache.evictions",
+		otelmetric.WithDescription("Number of game responses evicted from the in-memory cache, by reason"))
+	return err
+}
+
+// newResultCache Returns the cache of game responses set by the command line flags, or nil if caching is disabled
+func newResultCache() (cache.Cache, error) {
+	if *redisAddr != "" {
+		client := redis.NewClient(&redis.Options{Addr: *redisAddr})
+		if err := redisotel.InstrumentTracing(client); err != nil {
+			return nil, err
+		}
+		return cache.NewRedis(client, *cacheTTL), nil
+	}
+	if *cacheSize <= 0 {
+		return nil, nil
+	}
+	return cache.NewLRU(*cacheSize, *cacheTTL, cache.WithEvictionHook(recordEviction)), nil
+}
+
+// newSessionStore Returns the store of game sessions set by the command line flags
+func newSessionStore() (sessions.SessionStore, error) {
+	if *sessionDir != "" {
+		return sessions.NewFileStore(*sessionDir, *maxSessions, *sessionTTL)
+	}
+	return sessions.NewMemoryStore(*maxSessions, *sessionTTL), nil
+}
+
+// recordEviction Counts a response evicted from the in-memory cache
+func recordEviction(reason cache.EvictionReason) {
+	cacheEvictions.Add(context.Background(), 1, otelmetric.WithAttributes(attribute.String("reason", string(reason))))
+}
+
+// getCachedResult Returns the cached response of the game with the given cache key, and false on a miss.
+// A failing cache is a miss, so that the game is run instead.
+func getCachedResult(ctx context.Context, key string) (*gameoflifepb.GameResponse, bool) {
+	ctx, span := tracer.Start(ctx, "GetCachedResult")
+	defer span.End()
+	result, hit, err := resultCache.Get(ctx, key)
+	if err != nil {
+		span.RecordError(err)
+		logger.Warn("Getting cached result", zap.Error(err))
+	}
+	span.SetAttributes(attribute.Bool("cache.hit", hit))
+	if hit {
+		cacheHits.Add(ctx, 1)
+	} else {
+		cacheMisses.Add(ctx, 1)
+	}
+	return result, hit
+}
+
+// setCachedResult Caches the response of the game with the given cache key
+func setCachedResult(ctx context.Context, key string, result *gameoflifepb.GameResponse) {
+	ctx, span := tracer.Start(ctx, "SetCachedResult")
+	defer span.End()
+	if err := resultCache.Set(ctx, key, result); err != nil {
+		span.RecordError(err)
+		logger.Warn("Setting cached result", zap.Error(err))
+	}
+}
+
+// limitViolation is a limit set by the command line flags that a game request is over
+type limitViolation struct {
+	// reason is the limit the request is over: request_bytes, rows, cols, cell_generations, stream_generations,
+	// hashlife_nodes, batch_size, jobs or sessions
+	reason      string
+	description string
+}
+
+// checkLimits Returns the first limit the game request is over, or nil if it is within the limits.
+// The cell generations budget only applies to the STANDARD engine, as HashLife does not step every cell of every generation.
+// HashLife games are instead limited by the nodes of their quadtree, which are only known while the game runs.
+func checkLimits(gameConfiguration *gameoflifepb.GameRequest) *limitViolation {
+	if size := proto.Size(gameConfiguration); *maxRequestBytes > 0 && size > *maxRequestBytes {
+		return &limitViolation{"request_bytes", fmt.Sprintf("request has %d bytes, the limit is %d", size, *maxRequestBytes)}
+	}
+	rows, cols, err := gameoflife.BoardSize(gameConfiguration)
+	if err != nil {
+		// Invalid boards are rejected by gameoflife.Run
+		return nil
+	}
+	if *maxRows > 0 && rows > *maxRows {
+		return &limitViolation{"rows", fmt.Sprintf("board has %d rows, the limit is %d", rows, *maxRows)}
+	}
+	if *maxCols > 0 && cols > *maxCols {
+		return &limitViolation{"cols", fmt.Sprintf("board has %d columns, the limit is %d", cols, *maxCols)}
+	}
+	cellGenerations := int64(rows) * int64(cols) * int64(gameConfiguration.NumGens)
+	if *maxCellGenerations > 0 && gameConfiguration.Engine == gameoflifepb.Engine_STANDARD && cellGenerations > *maxCellGenerations {
+		return &limitViolation{"cell_generations", fmt.Sprintf("game has %d cells times generations, the limit is %d", cellGenerations, *maxCellGenerations)}
+	}
+	return nil
+}
+
+// checkStreamLimits Returns the first limit the streamed game request is over like checkLimits, including the
+// generations of the stream, as a frame is sent for every generation whatever the engine
+func checkStreamLimits(gameConfiguration *gameoflifepb.GameRequest) *limitViolation {
+	if violation := checkLimits(gameConfiguration); violation != nil {
+		return violation
+	}
+	if *maxStreamGens > 0 && int(gameConfiguration.NumGens) > *maxStreamGens {
+		return &limitViolation{"stream_generations", fmt.Sprintf("streamed game has %d generations, the limit is %d", gameConfiguration.NumGens, *maxStreamGens)}
+	}
+	return nil
+}
+
+// runViolation Returns the limit a game went over while it ran, or nil if err is not over a limit
+func runViolation(err error) *limitViolation {
+	if errors.Is(err, gameoflife.ErrNodeLimit) {
+		return &limitViolation{"hashlife_nodes", fmt.Sprintf("game needs more than the limit of %d hashlife nodes", *maxHashLifeNodes)}
+	}
+	return nil
+}
+
+// rejectRequest Records the rejection of a game request over a limit, and Returns its ResourceExhausted status error
+func rejectRequest(ctx context.Context, span trace.Span, prefix string, violation *limitViolation) error {
+	rejectedRequests.Add(ctx, 1, otelmetric.WithAttributes(attribute.String("reason", violation.reason)))
+	span.SetAttributes(attribute.String(prefix+".rejected.reason", violation.reason))
+	st := status.New(codes.ResourceExhausted, violation.description)
+	detailed, err := st.WithDetails(&errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{
+			{Subject: violation.reason, Description: violation.description},
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// recordStats Records the statistics of the last generation of a game, with the births and deaths since generation 0
+func recordStats(ctx context.Context, stats *gameoflifepb.GenerationStats) {
+	if stats == nil {
+		return
+	}
+	population.Record(ctx, int64(stats.Population))
+	births.Record(ctx, int64(stats.Births))
+	deaths.Record(ctx, int64(stats.Deaths))
+	var width, height int64
+	if box := stats.BoundingBox; box != nil {
+		width, height = int64(box.MaxCol-box.MinCol+1), int64(box.MaxRow-box.MinRow+1)
+	}
+	boundingBoxWidth.Record(ctx, width)
+	boundingBoxHeight.Record(ctx, height)
+}
+
+// recordHashLifeStats Adds the memoization statistics of a HashLife run to the cache metrics
+func recordHashLifeStats(ctx context.Context, gameConfiguration *gameoflifepb.GameRequest, stats *gameoflife.HashLifeStats) {
+	if gameConfiguration.Engine != gameoflifepb.Engine_HASHLIFE {
+		return
+	}
+	hashLifeCacheHits.Add(ctx, stats.CacheHits)
+	hashLifeCacheMisses.Add(ctx, stats.CacheMisses)
+}
+
+// recordCancellation Adds a span event for the generation reached by a cancelled game
+func recordCancellation(span trace.Span, prefix string, result *gameoflifepb.GameResponse) {
+	if result.GetCode() != gameoflifepb.ResponseCode_CANCELLED && result.GetCode() != gameoflifepb.ResponseCode_DEADLINE_EXCEEDED {
+		return
+	}
+	span.AddEvent("game_cancelled", trace.WithAttributes(
+		attribute.Int(prefix+".cancelled.generation", int(result.FinalGeneration)),
+		attribute.String(prefix+".cancelled.code", result.Code.String()),
+	))
+}
+
+// statusError Returns the gRPC status error of a failed game: InvalidArgument with the field violation
+// of an invalid request, Canceled or DeadlineExceeded with the partial result as a detail for a cancelled game,
+// and err otherwise
+func statusError(err error, result *gameoflifepb.GameResponse) error {
+	var validationErr *gameoflife.ValidationError
+	if errors.As(err, &validationErr) {
+		st := status.New(codes.InvalidArgument, err.Error())
+		detailed, detailsErr := st.WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: validationErr.Field, Description: validationErr.Err.Error()},
+			},
+		})
+		if detailsErr != nil {
+			return st.Err()
+		}
+		return detailed.Err()
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		st := status.FromContextError(err)
+		if result == nil {
+			return st.Err()
+		}
+		detailed, detailsErr := st.WithDetails(result)
+		if detailsErr != nil {
+			return st.Err()
+		}
+		return detailed.Err()
+	}
+	return err
+}
+
 type server struct {
 	gameoflifepb.UnimplementedGameOfLifeServer
 }
 
+// runOptions Returns the gameoflife options set by the command line flags,
+// collecting the HashLife statistics of the run into stats
+func runOptions(stats *gameoflife.HashLifeStats) []gameoflife.Option {
+	options := []gameoflife.Option{
+		gameoflife.WithWorkers(*workers),
+		gameoflife.WithHashLifeStats(stats),
+		gameoflife.WithMaxHashLifeNodes(*maxHashLifeNodes),
+	}
+	if *stripeSpans {
+		options = append(options, gameoflife.WithStripeHook(func(ctx context.Context, generation int, firstRow int, lastRow int) func() {
+			_, span := tracer.Start(ctx, "StepStripe")
+			span.SetAttributes(
+				attribute.Int("rungame_server.stripe.generation", generation),
+				attribute.Int("rungame_server.stripe.first_row", firstRow),
+				attribute.Int("rungame_server.stripe.last_row", lastRow),
+			)
+			return func() { span.End() }
+		}))
+	}
+	return options
+}
+
+// setRequestAttributes Sets the attributes of a game request on span, with the given attribute prefix
+func setRequestAttributes(span trace.Span, prefix string, gameConfiguration *gameoflifepb.GameRequest) {
+	span.SetAttributes(
+		attribute.String(prefix+".request.board", gameConfiguration.Board),
+		attribute.Int(prefix+".request.num_gens", int(gameConfiguration.NumGens)),
+		attribute.String(prefix+".request.rule", gameConfiguration.Rule),
+		attribute.String(prefix+".request.topology", gameConfiguration.Topology.String()),
+		attribute.String(prefix+".request.engine", gameConfiguration.Engine.String()),
+		attribute.String(prefix+".request.format", gameConfiguration.Format.String()),
+	)
+	if gameConfiguration.PatternName != "" {
+		span.SetAttributes(attribute.String(prefix+".request.pattern_name", gameConfiguration.PatternName))
+	}
+	if random := gameConfiguration.RandomBoard; random != nil {
+		span.SetAttributes(
+			attribute.Int64(prefix+".request.random_board.seed", random.Seed),
+			attribute.Int(prefix+".request.random_board.width", int(random.Width)),
+			attribute.Int(prefix+".request.random_board.height", int(random.Height)),
+			attribute.Float64(prefix+".request.random_board.density", random.Density),
+		)
+	}
+	if board := gameConfiguration.StructuredBoard; board != nil {
+		span.SetAttributes(
+			attribute.Int(prefix+".request.structured_board.width", int(board.Width)),
+			attribute.Int(prefix+".request.structured_board.height", int(board.Height)),
+		)
+	}
+}
+
+// setResponseAttributes Sets the attributes of a game response on span, with the given attribute prefix
+func setResponseAttributes(span trace.Span, prefix string, result *gameoflifepb.GameResponse) {
+	span.SetAttributes(
+		attribute.String(prefix+".response.board", result.Board),
+		attribute.String(prefix+".response.code", result.Code.String()),
+		attribute.Int(prefix+".response.final_generation", int(result.FinalGeneration)),
+		attribute.Int(prefix+".response.period", int(result.Period)),
+		attribute.Bool(prefix+".response.extinct", result.Extinct),
+	)
+}
+
+// runGame Runs a game within the limits with the given extra options, or serves its response from the cache,
+// recording it on span with the given attribute prefix
+func runGame(ctx context.Context, span trace.Span, prefix string, gameLogger *zap.Logger, gameConfiguration *gameoflifepb.GameRequest, options ...gameoflife.Option) (*gameoflifepb.GameResponse, error) {
+	gameLogger.Info("Received game configuration", zap.Any("gameConfiguration", gameConfiguration))
+	if violation := checkLimits(gameConfiguration); violation != nil {
+		gameLogger.Warn("Rejected game configuration", zap.String("reason", violation.reason), zap.String("description", violation.description))
+		return nil, rejectRequest(ctx, span, prefix, violation)
+	}
+
+	// The cache is looked up before running the game, which then fills the cache on a miss
+	key, keyErr := cache.Key(gameConfiguration)
+	cached := resultCache != nil && keyErr == nil
+	var result *gameoflifepb.GameResponse
+	hit := false
+	if cached {
+		result, hit = getCachedResult(ctx, key)
+		span.SetAttributes(attribute.Bool("cache.hit", hit))
+	}
+	if !hit {
+		var stats gameoflife.HashLifeStats
+		var summary *gameoflifepb.GenerationStats
+		options = append(options, gameoflife.WithSummaryHook(func(last *gameoflifepb.GenerationStats) { summary = last }))
+		var err error
+		result, err = gameoflife.Run(ctx, gameConfiguration, gameLogger, append(runOptions(&stats), options...)...)
+		recordHashLifeStats(ctx, gameConfiguration, &stats)
+		if violation := runViolation(err); violation != nil {
+			gameLogger.Warn("Rejected game configuration", zap.String("reason", violation.reason), zap.String("description", violation.description))
+			return nil, rejectRequest(ctx, span, prefix, violation)
+		}
+		if err != nil {
+			span.RecordError(err)
+			gameLogger.Error("Calling gameoflife.Run", zap.Error(err))
+			recordCancellation(span, prefix, result)
+			return result, statusError(err, result)
+		}
+		recordStats(ctx, summary)
+		if cached {
+			setCachedResult(ctx, key, result)
+		}
+	}
+	setResponseAttributes(span, prefix, result)
+	return result, nil
+}
+
 func (s *server) RunGame(ctx context.Context, gameConfiguration *gameoflifepb.GameRequest) (*gameoflifepb.GameResponse, error) {
 	ctx, span := tracer.Start(ctx, "RunGame")
 	defer span.End()
+	setRequestAttributes(span, "rungame_server", gameConfiguration)
+	gameLogger := logger.With(
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+	)
+
+	return runGame(ctx, span, "rungame_server", gameLogger, gameConfiguration)
+}
+
+// RunGames Runs the games of a batch, at most batchConcurrency at a time, each in its own child span.
+// A failed game only sets the status of its result, and the batch is only rejected if it is over maxBatchSize.
+func (s *server) RunGames(ctx context.Context, batch *gameoflifepb.BatchGameRequest) (*gameoflifepb.BatchGameResponse, error) {
+	ctx, span := tracer.Start(ctx, "RunGames")
+	defer span.End()
+	concurrency := max(*batchConcurrency, 1)
 	span.SetAttributes(
-		attribute.String("rungame_server.request.board", gameConfiguration.Board),
-		attribute.Int("rungame_server.request.num_gens", int(gameConfiguration.NumGens)),
+		attribute.Int("rungames_server.request.num_games", len(batch.Requests)),
+		attribute.Int("rungames_server.concurrency", concurrency),
 	)
-	logger = logger.With(
+	batchLogger := logger.With(
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 		zap.String("span_id", span.SpanContext().SpanID().String()),
 	)
 
-	logger.Info("Received game configuration", zap.Any("gameConfiguration", gameConfiguration))
+	batchLogger.Info("Received game batch", zap.Int("numGames", len(batch.Requests)))
+	if *maxBatchSize > 0 && len(batch.Requests) > *maxBatchSize {
+		violation := &limitViolation{"batch_size", fmt.Sprintf("batch has %d games, the limit is %d", len(batch.Requests), *maxBatchSize)}
+		batchLogger.Warn("Rejected game batch", zap.String("reason", violation.reason), zap.String("description", violation.description))
+		return nil, rejectRequest(ctx, span, "rungames_server", violation)
+	}
+
+	results := make([]*gameoflifepb.BatchGameResult, len(batch.Requests))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, gameConfiguration := range batch.Requests {
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			results[i] = runBatchGame(ctx, i, gameConfiguration)
+		}()
+	}
+	wg.Wait()
+
+	numFailed := 0
+	for _, result := range results {
+		if result.StatusCode != int32(codes.OK) {
+			numFailed++
+		}
+	}
+	span.SetAttributes(attribute.Int("rungames_server.response.num_failed", numFailed))
+	return &gameoflifepb.BatchGameResponse{Results: results}, nil
+}
 
-	result, err := gameoflife.Run(ctx, gameConfiguration, logger)
+// runBatchGame Runs the game of the given index of a batch in its own span, and Returns its response or status
+func runBatchGame(ctx context.Context, index int, gameConfiguration *gameoflifepb.GameRequest) *gameoflifepb.BatchGameResult {
+	ctx, span := tracer.Start(ctx, "RunBatchGame")
+	defer span.End()
+	span.SetAttributes(attribute.Int("rungames_server.game.index", index))
+	setRequestAttributes(span, "rungames_server.game", gameConfiguration)
+	gameLogger := logger.With(
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("index", index),
+	)
+
+	result, err := runGame(ctx, span, "rungames_server.game", gameLogger, gameConfiguration)
+	st := status.Convert(err)
+	return &gameoflifepb.BatchGameResult{
+		Response:     result,
+		StatusCode:   int32(st.Code()),
+		ErrorMessage: st.Message(),
+	}
+}
+
+func (s *server) RunGameStream(gameConfiguration *gameoflifepb.GameRequest, stream gameoflifepb.GameOfLife_RunGameStreamServer) error {
+	ctx, span := tracer.Start(stream.Context(), "RunGameStream")
+	defer span.End()
+	setRequestAttributes(span, "rungame_stream_server", gameConfiguration)
+	streamLogger := logger.With(
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+	)
+
+	streamLogger.Info("Received game configuration", zap.Any("gameConfiguration", gameConfiguration))
+	if violation := checkStreamLimits(gameConfiguration); violation != nil {
+		streamLogger.Warn("Rejected game configuration", zap.String("reason", violation.reason), zap.String("description", violation.description))
+		return rejectRequest(ctx, span, "rungame_stream_server", violation)
+	}
+
+	numFrames := 0
+	var stats gameoflife.HashLifeStats
+	var summary *gameoflifepb.GenerationStats
+	result, err := gameoflife.RunStream(ctx, gameConfiguration, streamLogger, func(frame *gameoflifepb.GenerationFrame) error {
+		numFrames++
+		return stream.Send(frame)
+	}, append(runOptions(&stats), gameoflife.WithSummaryHook(func(last *gameoflifepb.GenerationStats) { summary = last }))...)
+	recordHashLifeStats(ctx, gameConfiguration, &stats)
+	span.SetAttributes(attribute.Int("rungame_stream_server.response.num_frames", numFrames))
+	if violation := runViolation(err); violation != nil {
+		streamLogger.Warn("Rejected game configuration", zap.String("reason", violation.reason), zap.String("description", violation.description))
+		return rejectRequest(ctx, span, "rungame_stream_server", violation)
+	}
 	if err != nil {
 		span.RecordError(err)
-		logger.Error("Calling gameoflife.Run", zap.Error(err))
-		return result, err
+		streamLogger.Error("Calling gameoflife.RunStream", zap.Error(err))
+		recordCancellation(span, "rungame_stream_server", result)
+		return statusError(err, result)
+	}
+	setResponseAttributes(span, "rungame_stream_server", result)
+	recordStats(ctx, summary)
+
+	return nil
+}
+
+func (s *server) ListPatterns(ctx context.Context, _ *gameoflifepb.ListPatternsRequest) (*gameoflifepb.ListPatternsResponse, error) {
+	_, span := tracer.Start(ctx, "ListPatterns")
+	defer span.End()
+
+	patterns := gameoflife.Patterns()
+	span.SetAttributes(attribute.Int("listpatterns_server.response.num_patterns", len(patterns)))
+	return &gameoflifepb.ListPatternsResponse{Patterns: patterns}, nil
+}
+
+func (s *server) GetPattern(ctx context.Context, req *gameoflifepb.GetPatternRequest) (*gameoflifepb.Pattern, error) {
+	_, span := tracer.Start(ctx, "GetPattern")
+	defer span.End()
+	span.SetAttributes(attribute.String("getpattern_server.request.name", req.Name))
+
+	pattern, ok := gameoflife.GetPattern(req.Name)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unknown pattern %q", req.Name)
+		span.RecordError(err)
+		return nil, err
+	}
+	return pattern, nil
+}
+
+// SubmitGame Starts running the game in the background, and Returns its pending job. The game is run
+// in its own trace, linked to the span of the request that submitted it.
+func (s *server) SubmitGame(ctx context.Context, gameConfiguration *gameoflifepb.GameRequest) (*gameoflifepb.Job, error) {
+	ctx, span := tracer.Start(ctx, "SubmitGame")
+	defer span.End()
+	setRequestAttributes(span, "submitgame_server", gameConfiguration)
+	submitLogger := logger.With(
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+	)
+
+	if violation := checkLimits(gameConfiguration); violation != nil {
+		submitLogger.Warn("Rejected game configuration", zap.String("reason", violation.reason), zap.String("description", violation.description))
+		return nil, rejectRequest(ctx, span, "submitgame_server", violation)
+	}
+	// The job outlives the request, so it is only cancelled by CancelJob
+	jobCtx, cancel := context.WithCancel(context.Background())
+	job, err := jobStore.Create(gameConfiguration, cancel)
+	if err != nil {
+		cancel()
+		if errors.Is(err, jobs.ErrFull) {
+			violation := &limitViolation{"jobs", fmt.Sprintf("server already has the maximum of %d jobs", *maxJobs)}
+			submitLogger.Warn("Rejected game configuration", zap.String("reason", violation.reason), zap.String("description", violation.description))
+			return nil, rejectRequest(ctx, span, "submitgame_server", violation)
+		}
+		span.RecordError(err)
+		submitLogger.Error("Creating job", zap.Error(err))
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("submitgame_server.job.id", job.ID()))
+	submitLogger.Info("Submitted job", zap.String("job_id", job.ID()))
+
+	go runJob(jobCtx, trace.LinkFromContext(ctx), job, gameConfiguration)
+	return job.Proto(), nil
+}
+
+// runJob Runs the game of a job once a job worker is free, in a new trace linked to the span that submitted it
+func runJob(ctx context.Context, link trace.Link, job *jobs.Job, gameConfiguration *gameoflifepb.GameRequest) {
+	ctx, span := tracer.Start(ctx, "RunJob", trace.WithNewRoot(), trace.WithLinks(link))
+	defer span.End()
+	span.SetAttributes(attribute.String("runjob_server.job.id", job.ID()))
+	setRequestAttributes(span, "runjob_server", gameConfiguration)
+	jobLogger := logger.With(
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.String("job_id", job.ID()),
+	)
+
+	select {
+	case jobSlots <- struct{}{}:
+		defer func() { <-jobSlots }()
+	case <-ctx.Done():
+		jobLogger.Info("Job cancelled before it started")
+		job.Finish(nil, status.FromContextError(ctx.Err()).Err())
+		return
+	}
+	span.AddEvent("job_started")
+	job.Start()
+	result, err := runGame(ctx, span, "runjob_server", jobLogger, gameConfiguration, gameoflife.WithProgressHook(job.SetGeneration))
+	job.Finish(result, err)
+	jobLogger.Info("Finished job", zap.Stringer("code", status.Code(err)))
+}
+
+func (s *server) GetJob(ctx context.Context, req *gameoflifepb.GetJobRequest) (*gameoflifepb.Job, error) {
+	_, span := tracer.Start(ctx, "GetJob")
+	defer span.End()
+	span.SetAttributes(attribute.String("getjob_server.request.id", req.Id))
+
+	job, ok := jobStore.Get(req.Id)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unknown job %q", req.Id)
+		span.RecordError(err)
+		return nil, err
 	}
 	span.SetAttributes(
-		attribute.String("rungame_server.response.board", result.Board),
-		attribute.String("rungame_server.response.code", result.Code.String()),
+		attribute.String("getjob_server.response.state", job.State.String()),
+		attribute.Int("getjob_server.response.generation", int(job.Generation)),
 	)
+	return job, nil
+}
+
+func (s *server) CancelJob(ctx context.Context, req *gameoflifepb.CancelJobRequest) (*gameoflifepb.Job, error) {
+	_, span := tracer.Start(ctx, "CancelJob")
+	defer span.End()
+	span.SetAttributes(attribute.String("canceljob_server.request.id", req.Id))
+
+	job, ok := jobStore.Cancel(req.Id)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unknown job %q", req.Id)
+		span.RecordError(err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("canceljob_server.response.state", job.State.String()))
+	return job, nil
+}
 
-	return result, err
+func (s *server) ListJobs(ctx context.Context, _ *gameoflifepb.ListJobsRequest) (*gameoflifepb.ListJobsResponse, error) {
+	_, span := tracer.Start(ctx, "ListJobs")
+	defer span.End()
+
+	list := jobStore.List()
+	span.SetAttributes(attribute.Int("listjobs_server.response.num_jobs", len(list)))
+	return &gameoflifepb.ListJobsResponse{Jobs: list}, nil
+}
+
+// sessionError Returns the gRPC status error of a failed session store operation: NotFound for an unknown session,
+// and err otherwise
+func sessionError(err error, id string) error {
+	if errors.Is(err, sessions.ErrNotFound) {
+		return status.Errorf(codes.NotFound, "unknown session %q", id)
+	}
+	return err
+}
+
+// setSessionAttributes Sets the attributes of a session on span, with the given attribute prefix
+func setSessionAttributes(span trace.Span, prefix string, session *gameoflifepb.Session) {
+	span.SetAttributes(
+		attribute.String(prefix+".session.id", session.Id),
+		attribute.Int(prefix+".session.generation", int(session.Generation)),
+		attribute.Bool(prefix+".session.extinct", session.Extinct),
+	)
+}
+
+// CreateSession Stores the initial board of a game in a new session. The board is generated from the pattern
+// or random board of the game if it has one, so that every Step advances the same board.
+func (s *server) CreateSession(ctx context.Context, req *gameoflifepb.CreateSessionRequest) (*gameoflifepb.Session, error) {
+	ctx, span := tracer.Start(ctx, "CreateSession")
+	defer span.End()
+	game := req.GetGame()
+	if game == nil {
+		game = &gameoflifepb.GameRequest{}
+	}
+	setRequestAttributes(span, "createsession_server", game)
+	sessionLogger := logger.With(
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+	)
+
+	// Running the game for 0 generations validates it and returns its initial board
+	initial := proto.Clone(game).(*gameoflifepb.GameRequest)
+	initial.NumGens = 0
+	result, err := runGame(ctx, span, "createsession_server", sessionLogger, initial)
+	if err != nil {
+		return nil, err
+	}
+	now := timestamppb.Now()
+	session, err := sessionStore.Create(ctx, &gameoflifepb.Session{
+		Game: &gameoflifepb.GameRequest{
+			Board:           result.Board,
+			StructuredBoard: result.StructuredBoard,
+			Rule:            gameoflife.RequestRule(game),
+			Topology:        game.Topology,
+			Engine:          game.Engine,
+			Format:          game.Format,
+		},
+		Extinct:    result.Extinct,
+		CreateTime: now,
+		UpdateTime: now,
+	})
+	if errors.Is(err, sessions.ErrFull) {
+		violation := &limitViolation{"sessions", fmt.Sprintf("server already has the maximum of %d sessions", *maxSessions)}
+		sessionLogger.Warn("Rejected session", zap.String("reason", violation.reason), zap.String("description", violation.description))
+		return nil, rejectRequest(ctx, span, "createsession_server", violation)
+	}
+	if err != nil {
+		span.RecordError(err)
+		sessionLogger.Error("Creating session", zap.Error(err))
+		return nil, err
+	}
+	setSessionAttributes(span, "createsession_server", session)
+	sessionLogger.Info("Created session", zap.String("session_id", session.Id))
+	return session, nil
+}
+
+// Step Advances the board of a session by the given number of generations, within the limits of a game.
+// Concurrent steps of a session are applied one after the other.
+func (s *server) Step(ctx context.Context, req *gameoflifepb.StepRequest) (*gameoflifepb.Session, error) {
+	ctx, span := tracer.Start(ctx, "Step")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("step_server.request.session_id", req.SessionId),
+		attribute.Int("step_server.request.num_gens", int(req.NumGens)),
+	)
+	stepLogger := logger.With(
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.String("session_id", req.SessionId),
+	)
+
+	numGens := req.NumGens
+	if numGens < 0 {
+		err := status.Errorf(codes.InvalidArgument, "num_gens: must not be negative, got %d", numGens)
+		span.RecordError(err)
+		return nil, err
+	}
+	if numGens == 0 {
+		numGens = 1
+	}
+	session, err := sessionStore.Update(ctx, req.SessionId, func(session *gameoflifepb.Session) error {
+		game := proto.Clone(session.Game).(*gameoflifepb.GameRequest)
+		game.NumGens = numGens
+		result, err := runGame(ctx, span, "step_server", stepLogger, game)
+		if err != nil {
+			return err
+		}
+		session.Game.Board = result.Board
+		session.Game.StructuredBoard = result.StructuredBoard
+		session.Generation += numGens
+		session.Extinct = result.Extinct
+		session.UpdateTime = timestamppb.Now()
+		return nil
+	})
+	if err != nil {
+		err = sessionError(err, req.SessionId)
+		span.RecordError(err)
+		return nil, err
+	}
+	setSessionAttributes(span, "step_server", session)
+	return session, nil
+}
+
+func (s *server) GetSession(ctx context.Context, req *gameoflifepb.GetSessionRequest) (*gameoflifepb.Session, error) {
+	ctx, span := tracer.Start(ctx, "GetSession")
+	defer span.End()
+	span.SetAttributes(attribute.String("getsession_server.request.session_id", req.SessionId))
+
+	session, err := sessionStore.Get(ctx, req.SessionId)
+	if err != nil {
+		err = sessionError(err, req.SessionId)
+		span.RecordError(err)
+		return nil, err
+	}
+	setSessionAttributes(span, "getsession_server", session)
+	return session, nil
+}
+
+func (s *server) DeleteSession(ctx context.Context, req *gameoflifepb.DeleteSessionRequest) (*emptypb.Empty, error) {
+	ctx, span := tracer.Start(ctx, "DeleteSession")
+	defer span.End()
+	span.SetAttributes(attribute.String("deletesession_server.request.session_id", req.SessionId))
+
+	if err := sessionStore.Delete(ctx, req.SessionId); err != nil {
+		err = sessionError(err, req.SessionId)
+		span.RecordError(err)
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+const (
+	// selfTestBoard is a vertical blinker, whose next generation is selfTestResult
+	selfTestBoard  = "[[0,0,0,0,0],[0,0,1,0,0],[0,0,1,0,0],[0,0,1,0,0],[0,0,0,0,0]]"
+	selfTestResult = "[[0,0,0,0,0],[0,0,0,0,0],[0,1,1,1,0],[0,0,0,0,0],[0,0,0,0,0]]"
+)
+
+// selfTest Runs a generation of a blinker with the workers of the server, and Returns an error unless the blinker
+// turned horizontal
+func selfTest(ctx context.Context) error {
+	result, err := gameoflife.Run(ctx, &gameoflifepb.GameRequest{Board: selfTestBoard, NumGens: 1}, zap.NewNop(), gameoflife.WithWorkers(*workers))
+	if err != nil {
+		return fmt.Errorf("running a blinker: %w", err)
+	}
+	if result.Board != selfTestResult {
+		return fmt.Errorf("blinker stepped to %s, expected %s", result.Board, selfTestResult)
+	}
+	return nil
 }
 
 func main() {
@@ -101,7 +916,12 @@ func main() {
 	}
 	logger = logger.With(zap.String("service", "game-of-life-server"))
 
-	logger.Info("Arguments", zap.Int("grpcPort", *grpcPort), zap.Int("httpPort", *httpPort))
+	logger.Info("Arguments",
+		zap.Int("grpcPort", *grpcPort),
+		zap.Int("httpPort", *httpPort),
+		zap.Int("workers", *workers),
+		zap.Bool("stripeSpans", *stripeSpans),
+	)
 
 	ctx := context.Background()
 	tp := InitTracerProvider(ctx)
@@ -113,6 +933,24 @@ func main() {
 	tracer = tp.Tracer("game-of-life-server")
 
 	provider := InitMeter(ctx)
+	meter = provider.Meter("game-of-life-server")
+	if err = InitInstruments(); err != nil {
+		logger.Fatal("failed to create metric instruments", zap.Error(err))
+	}
+	resultCache, err = newResultCache()
+	if err != nil {
+		logger.Fatal("failed to create result cache", zap.Error(err))
+	}
+	jobStore = jobs.NewStore(*maxJobs, *jobTTL)
+	jobSlots = make(chan struct{}, max(*jobWorkers, 1))
+	sessionStore, err = newSessionStore()
+	if err != nil {
+		logger.Fatal("failed to create session store", zap.Error(err))
+	}
+	// The server is alive while its engine runs games, and ready once it also exports its spans
+	healthChecks = health.NewRegistry(health.WithCacheTTL(*healthCacheTTL))
+	healthChecks.RegisterLiveness("self_test", selfTest)
+	healthChecks.Register("otlp_trace_export", traceExports.Check)
 	defer func() {
 		ctxTimeout, cancel := context.WithTimeout(ctx, time.Second)
 		defer cancel()
@@ -142,11 +980,20 @@ func main() {
 		logger.Fatal("failed to listen", zap.Error(err))
 	}
 
-	s := grpc.NewServer(
+	options := []grpc.ServerOption{
 		grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor()),
 		grpc.StreamInterceptor(otelgrpc.StreamServerInterceptor()),
-	)
+	}
+	// Messages over the request limit are rejected before they are decoded. checkLimits still checks the size
+	// of the requests, to reject those of the gateway with a request_bytes violation.
+	if *maxRequestBytes > 0 {
+		options = append(options, grpc.MaxRecvMsgSize(*maxRequestBytes))
+	}
+	s := grpc.NewServer(options...)
 	gameoflifepb.RegisterGameOfLifeServer(s, &server{})
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	go healthChecks.UpdateServingStatus(ctx, healthServer, max(*healthCacheTTL, time.Second), gameoflifepb.GameOfLife_ServiceDesc.ServiceName)
 	reflection.Register(s)
 	if err := s.Serve(lis); err != nil {
 		logger.Fatal("failed to serve", zap.Error(err))
@@ -158,30 +1005,17 @@ func SetupHandlers() *http.ServeMux {
 
 	mux.HandleFunc("/readiness", ReadinessHandler)
 	mux.HandleFunc("/liveness", LivenessHandler)
+	mux.Handle("/v1/", newGateway(&server{}))
 
 	return mux
 }
 
+// ReadinessHandler Returns the report of the health checks, with a 503 if any of them fails
 func ReadinessHandler(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusOK)
-
-	resp := struct {
-		Readiness bool `json:"readiness"`
-	}{
-		Readiness: true,
-	}
-
-	json.NewEncoder(w).Encode(resp)
+	health.WriteReport(w, "readiness", healthChecks.Readiness(r.Context()))
 }
 
+// LivenessHandler Returns the report of the liveness checks, with a 503 if any of them fails
 func LivenessHandler(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusOK)
-
-	resp := struct {
-		Liveness bool `json:"liveness"`
-	}{
-		Liveness: true,
-	}
-
-	json.NewEncoder(w).Encode(resp)
+	health.WriteReport(w, "liveness", healthChecks.Liveness(r.Context()))
 }
@@ -2,24 +2,44 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"log"
+	"math/rand"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/cache"
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/health"
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/jobs"
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/sessions"
 	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
 )
 
+var metricReader *sdkmetric.ManualReader
+
 func startGRPCServer() *bufconn.Listener {
 	bufferSize := 1024 * 1024
 	listener := bufconn.Listen(bufferSize)
@@ -59,6 +79,13 @@ func setupServer(t *testing.T) (*tracetest.InMemoryExporter, gameoflifepb.GameOf
 	otel.SetTracerProvider(tp)
 	tracer = tp.Tracer("server_test")
 
+	metricReader = sdkmetric.NewManualReader()
+	meter = sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader)).Meter("server_test")
+	assert.NoError(t, InitInstruments())
+	jobStore = jobs.NewStore(*maxJobs, *jobTTL)
+	jobSlots = make(chan struct{}, 1)
+	sessionStore = sessions.NewMemoryStore(0, 0)
+
 	listener := startGRPCServer()
 	conn, err := grpc.DialContext(context.Background(), "", grpc.WithDialer(getBufDialer(listener)), grpc.WithInsecure())
 	assert.NoError(t, err)
@@ -86,7 +113,8 @@ func checkGrpcSpanAttributes(t *testing.T, grpcSpan tracetest.SpanStub, statusCo
 	}
 	assert.Equal(t, "gameoflifepb.GameOfLife/RunGame", grpcSpan.Name)
 	assert.Equal(t, 4, numAttributes)
-	assert.Len(t, grpcSpan.Events, 2)
+	// The interceptors of the server don't record message events
+	assert.Len(t, grpcSpan.Events, 0)
 }
 
 func checkLogFields(t *testing.T, logs *observer.ObservedLogs, span tracetest.SpanStub) {
@@ -111,8 +139,9 @@ func checkLogFields(t *testing.T, logs *observer.ObservedLogs, span tracetest.Sp
 
 func TestRunGameTrace(t *testing.T) {
 	gameRequest := gameoflifepb.GameRequest{
-		Board:   "[[1,1],[1,1]]",
-		NumGens: 1,
+		Board:    "[[1,1],[1,1]]",
+		NumGens:  1,
+		Topology: gameoflifepb.Topology_TORUS,
 	}
 	exporter, client, logs := setupServer(t)
 	resp, err := client.RunGame(context.Background(), &gameRequest)
@@ -130,16 +159,32 @@ func TestRunGameTrace(t *testing.T) {
 		case "rungame_server.request.num_gens":
 			assert.Equal(t, v.Value.AsInt64(), int64(gameRequest.NumGens))
 			numAttributes++
+		case "rungame_server.request.topology":
+			assert.Equal(t, "TORUS", v.Value.AsString())
+			numAttributes++
+		case "rungame_server.request.engine":
+			assert.Equal(t, "STANDARD", v.Value.AsString())
+			numAttributes++
 		case "rungame_server.response.board":
 			assert.Equal(t, v.Value.AsString(), resp.Board)
 			numAttributes++
 		case "rungame_server.response.code":
 			assert.Equal(t, v.Value.AsString(), resp.Code.String())
 			numAttributes++
+		case "rungame_server.response.final_generation":
+			assert.Equal(t, int64(1), v.Value.AsInt64())
+			numAttributes++
+		case "rungame_server.response.period":
+			assert.Equal(t, int64(0), v.Value.AsInt64())
+			numAttributes++
+		case "rungame_server.response.extinct":
+			// On a 2x2 torus the 3 other cells are counted as 8 live neighbors, so the block dies
+			assert.True(t, v.Value.AsBool())
+			numAttributes++
 		}
 	}
 	assert.Equal(t, "RunGame", runGameSpan.Name)
-	assert.Equal(t, 4, numAttributes)
+	assert.Equal(t, 9, numAttributes)
 	assert.Len(t, runGameSpan.Events, 0)
 
 	grpcSpan := spans[1]
@@ -154,7 +199,15 @@ func TestRunGameErrorTrace(t *testing.T) {
 	}
 	exporter, client, logs := setupServer(t)
 	_, err := client.RunGame(context.Background(), &gameRequest)
-	assert.Error(t, err)
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	// The field violation points at the invalid cell
+	if assert.Len(t, st.Details(), 1) {
+		badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+		if assert.True(t, ok) && assert.Len(t, badRequest.FieldViolations, 1) {
+			assert.Equal(t, "board[1][1]", badRequest.FieldViolations[0].Field)
+		}
+	}
 
 	spans := exporter.GetSpans()
 	assert.Len(t, spans, 2)
@@ -180,6 +233,716 @@ func TestRunGameErrorTrace(t *testing.T) {
 	assert.Equal(t, "exception", runGameSpan.Events[0].Name)
 
 	grpcSpan := spans[1]
-	checkGrpcSpanAttributes(t, grpcSpan, 2)
+	checkGrpcSpanAttributes(t, grpcSpan, 3)
 	checkLogFields(t, logs, runGameSpan)
 }
+
+func TestRunGameStreamTrace(t *testing.T) {
+	gameRequest := gameoflifepb.GameRequest{
+		Board:   "[[0,1,0],[0,1,0],[0,1,0]]",
+		NumGens: 2,
+	}
+	exporter, client, logs := setupServer(t)
+	stream, err := client.RunGameStream(context.Background(), &gameRequest)
+	assert.NoError(t, err)
+
+	var frames []*gameoflifepb.GenerationFrame
+	for {
+		frame, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		frames = append(frames, frame)
+	}
+	assert.Len(t, frames, 3)
+	for i, frame := range frames {
+		assert.Equal(t, int32(i), frame.Generation)
+	}
+	assert.Equal(t, "[[0,1,0],[0,1,0],[0,1,0]]", frames[2].Board)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+	streamSpan := spans[0]
+	numAttributes := 0
+	for _, v := range streamSpan.Attributes {
+		switch v.Key {
+		case "rungame_stream_server.request.board":
+			assert.Equal(t, gameRequest.Board, v.Value.AsString())
+			numAttributes++
+		case "rungame_stream_server.request.num_gens":
+			assert.Equal(t, int64(gameRequest.NumGens), v.Value.AsInt64())
+			numAttributes++
+		case "rungame_stream_server.response.num_frames":
+			assert.Equal(t, int64(3), v.Value.AsInt64())
+			numAttributes++
+		case "rungame_stream_server.response.board":
+			assert.Equal(t, frames[2].Board, v.Value.AsString())
+			numAttributes++
+		case "rungame_stream_server.response.code":
+			assert.Equal(t, gameoflifepb.ResponseCode_OK.String(), v.Value.AsString())
+			numAttributes++
+		case "rungame_stream_server.response.final_generation":
+			assert.Equal(t, int64(2), v.Value.AsInt64())
+			numAttributes++
+		case "rungame_stream_server.response.period":
+			assert.Equal(t, int64(2), v.Value.AsInt64())
+			numAttributes++
+		}
+	}
+	assert.Equal(t, "RunGameStream", streamSpan.Name)
+	assert.Equal(t, 7, numAttributes)
+	assert.Len(t, streamSpan.Events, 0)
+
+	grpcSpan := spans[1]
+	assert.Equal(t, "gameoflifepb.GameOfLife/RunGameStream", grpcSpan.Name)
+	assert.Contains(t, grpcSpan.Attributes, attribute.String("rpc.method", "RunGameStream"))
+	assert.Len(t, grpcSpan.Events, 0)
+	checkLogFields(t, logs, streamSpan)
+}
+
+func TestRunGameStripeSpans(t *testing.T) {
+	*workers, *stripeSpans = 2, true
+	defer func() {
+		*workers, *stripeSpans = 1, false
+	}()
+	gameRequest := gameoflifepb.GameRequest{
+		Board:   "[[0,1,0],[0,1,0],[0,1,0],[0,0,0]]",
+		NumGens: 2,
+	}
+	exporter, client, _ := setupServer(t)
+	resp, err := client.RunGame(context.Background(), &gameRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, "[[0,1,0],[0,1,0],[0,1,0],[0,0,0]]", resp.Board)
+
+	// One span per stripe for each generation, then the RunGame and gRPC spans
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 6)
+	runGameSpan := spans[4]
+	assert.Equal(t, "RunGame", runGameSpan.Name)
+	rowsStepped := map[int64]int64{}
+	for _, span := range spans[:4] {
+		assert.Equal(t, "StepStripe", span.Name)
+		assert.Equal(t, runGameSpan.SpanContext.SpanID(), span.Parent.SpanID())
+		var generation, firstRow, lastRow int64
+		for _, v := range span.Attributes {
+			switch v.Key {
+			case "rungame_server.stripe.generation":
+				generation = v.Value.AsInt64()
+			case "rungame_server.stripe.first_row":
+				firstRow = v.Value.AsInt64()
+			case "rungame_server.stripe.last_row":
+				lastRow = v.Value.AsInt64()
+			}
+		}
+		rowsStepped[generation] += lastRow - firstRow
+	}
+	assert.Equal(t, map[int64]int64{1: 4, 2: 4}, rowsStepped)
+	checkGrpcSpanAttributes(t, spans[5], 0)
+}
+
+func TestRunGameHashLifeMetrics(t *testing.T) {
+	gameRequest := gameoflifepb.GameRequest{
+		Board:   "[[0,1,0],[0,1,0],[0,1,0]]",
+		NumGens: 1_000_000,
+		Engine:  gameoflifepb.Engine_HASHLIFE,
+	}
+	exporter, client, _ := setupServer(t)
+	resp, err := client.RunGame(context.Background(), &gameRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, gameRequest.Board, resp.Board)
+
+	runGameSpan := exporter.GetSpans()[0]
+	assert.Contains(t, runGameSpan.Attributes, attribute.String("rungame_server.request.engine", "HASHLIFE"))
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, metricReader.Collect(context.Background(), &rm))
+	counters := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, dp := range sum.DataPoints {
+					counters[m.Name] += dp.Value
+				}
+			}
+		}
+	}
+	assert.Greater(t, counters["gameoflife.hashlife.cache.hits"], int64(0))
+	assert.Greater(t, counters["gameoflife.hashlife.cache.misses"], int64(0))
+}
+
+func TestRunGameStructuredBoard(t *testing.T) {
+	gameRequest := gameoflifepb.GameRequest{
+		StructuredBoard: &gameoflifepb.Board{Width: 3, Height: 2, LiveCells: []*gameoflifepb.Cell{{Row: 0, Col: 0}, {Row: 0, Col: 1}, {Row: 1, Col: 0}}},
+		NumGens:         1,
+	}
+	exporter, client, _ := setupServer(t)
+	resp, err := client.RunGame(context.Background(), &gameRequest)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Board)
+	assert.Equal(t, int32(3), resp.StructuredBoard.Width)
+	assert.Equal(t, int32(2), resp.StructuredBoard.Height)
+	assert.Len(t, resp.StructuredBoard.LiveCells, 4)
+
+	runGameSpan := exporter.GetSpans()[0]
+	assert.Contains(t, runGameSpan.Attributes, attribute.Int("rungame_server.request.structured_board.width", 3))
+	assert.Contains(t, runGameSpan.Attributes, attribute.Int("rungame_server.request.structured_board.height", 2))
+}
+
+func TestRunGameStatsMetrics(t *testing.T) {
+	// The births and deaths are counted since generation 0 even with the stats of every generation,
+	// so the blinker back in its initial phase at generation 2 has none
+	gameRequest := gameoflifepb.GameRequest{
+		Board:           "[[0,1,0,0],[0,1,0,0],[0,1,0,0],[0,0,0,0]]",
+		NumGens:         2,
+		GenerationStats: true,
+	}
+	_, client, _ := setupServer(t)
+	_, err := client.RunGame(context.Background(), &gameRequest)
+	assert.NoError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, metricReader.Collect(context.Background(), &rm))
+	values := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Histogram[int64]:
+				assert.Len(t, data.DataPoints, 1)
+				values[m.Name] = data.DataPoints[0].Sum
+			case metricdata.Gauge[int64]:
+				assert.Len(t, data.DataPoints, 1)
+				values[m.Name] = data.DataPoints[0].Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"gameoflife.population":          3,
+		"gameoflife.births_since_start":  0,
+		"gameoflife.deaths_since_start":  0,
+		"gameoflife.bounding_box.width":  1,
+		"gameoflife.bounding_box.height": 3,
+	}, values)
+}
+
+func TestRunGameDeadlineTrace(t *testing.T) {
+	// A random soup keeps evolving long after the deadline of the client
+	r := rand.New(rand.NewSource(1))
+	board := make([][]int, 200)
+	for i := range board {
+		board[i] = make([]int, 200)
+		for j := range board[i] {
+			board[i][j] = r.Intn(2)
+		}
+	}
+	data, err := json.Marshal(board)
+	assert.NoError(t, err)
+	gameRequest := gameoflifepb.GameRequest{
+		Board:   string(data),
+		NumGens: 1_000_000,
+	}
+	// The game is far over the default budget of cell generations
+	defer func(limit int64) { *maxCellGenerations = limit }(*maxCellGenerations)
+	*maxCellGenerations = 0
+	exporter, client, _ := setupServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.RunGame(ctx, &gameRequest)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	// The server span ends once the game has stopped at the deadline
+	assert.Eventually(t, func() bool { return len(exporter.GetSpans()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	runGameSpan := exporter.GetSpans()[0]
+	assert.Equal(t, "RunGame", runGameSpan.Name)
+	var names []string
+	for _, event := range runGameSpan.Events {
+		names = append(names, event.Name)
+	}
+	assert.Equal(t, []string{"exception", "game_cancelled"}, names)
+	// The server sees either its own deadline or the cancellation of the client, whichever comes first
+	var keys []attribute.Key
+	for _, attr := range runGameSpan.Events[1].Attributes {
+		keys = append(keys, attr.Key)
+	}
+	assert.Equal(t, []attribute.Key{"rungame_server.cancelled.generation", "rungame_server.cancelled.code"}, keys)
+}
+
+func TestStatusErrorCancelled(t *testing.T) {
+	// The partial result of a cancelled game is sent as a detail of the status, as gRPC drops the response of a failed call
+	partial := &gameoflifepb.GameResponse{Code: gameoflifepb.ResponseCode_DEADLINE_EXCEEDED, FinalGeneration: 3, Board: "[[0,1],[1,0]]"}
+	st := status.Convert(statusError(context.DeadlineExceeded, partial))
+	assert.Equal(t, codes.DeadlineExceeded, st.Code())
+	details := st.Details()
+	assert.Len(t, details, 1)
+	assert.True(t, proto.Equal(partial, details[0].(*gameoflifepb.GameResponse)))
+
+	st = status.Convert(statusError(context.Canceled, nil))
+	assert.Equal(t, codes.Canceled, st.Code())
+	assert.Empty(t, st.Details())
+}
+
+func TestRunGameLimits(t *testing.T) {
+	var tests = []struct {
+		gameRequest *gameoflifepb.GameRequest
+		reason      string
+	}{
+		{&gameoflifepb.GameRequest{Board: strings.Repeat(" ", 2<<20), NumGens: 1}, "request_bytes"},
+		{&gameoflifepb.GameRequest{Board: "x = 3, y = 2000\n!", Format: gameoflifepb.BoardFormat_RLE, NumGens: 1}, "rows"},
+		{&gameoflifepb.GameRequest{StructuredBoard: &gameoflifepb.Board{Width: 2000, Height: 1}, NumGens: 1}, "cols"},
+		{&gameoflifepb.GameRequest{Board: "x = 1000, y = 1000\n!", Format: gameoflifepb.BoardFormat_RLE, NumGens: 1001}, "cell_generations"},
+	}
+	for _, tt := range tests {
+		testname := tt.reason
+		t.Run(testname, func(t *testing.T) {
+			exporter, client, _ := setupServer(t)
+			_, err := client.RunGame(context.Background(), tt.gameRequest)
+			st := status.Convert(err)
+			assert.Equal(t, codes.ResourceExhausted, st.Code())
+			if assert.Len(t, st.Details(), 1) {
+				quotaFailure, ok := st.Details()[0].(*errdetails.QuotaFailure)
+				if assert.True(t, ok) && assert.Len(t, quotaFailure.Violations, 1) {
+					assert.Equal(t, tt.reason, quotaFailure.Violations[0].Subject)
+				}
+			}
+			assert.Contains(t, exporter.GetSpans()[0].Attributes, attribute.String("rungame_server.rejected.reason", tt.reason))
+
+			var rm metricdata.ResourceMetrics
+			assert.NoError(t, metricReader.Collect(context.Background(), &rm))
+			found := false
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "gameoflife.requests.rejected" {
+						continue
+					}
+					sum := m.Data.(metricdata.Sum[int64])
+					if assert.Len(t, sum.DataPoints, 1) {
+						reason, _ := sum.DataPoints[0].Attributes.Value("reason")
+						assert.Equal(t, tt.reason, reason.AsString())
+						assert.Equal(t, int64(1), sum.DataPoints[0].Value)
+						found = true
+					}
+				}
+			}
+			assert.True(t, found)
+		})
+	}
+
+	// HashLife runs huge generation counts without stepping every cell
+	_, client, _ := setupServer(t)
+	_, err := client.RunGame(context.Background(), &gameoflifepb.GameRequest{
+		Board:   "x = 1000, y = 1000\n!",
+		Format:  gameoflifepb.BoardFormat_RLE,
+		NumGens: 1_000_000,
+		Engine:  gameoflifepb.Engine_HASHLIFE,
+	})
+	assert.NoError(t, err)
+
+	// checkQuotaFailure Checks that err is a ResourceExhausted status with a violation of the given subject
+	checkQuotaFailure := func(err error, subject string) {
+		t.Helper()
+		st := status.Convert(err)
+		assert.Equal(t, codes.ResourceExhausted, st.Code())
+		if assert.Len(t, st.Details(), 1) {
+			quotaFailure, ok := st.Details()[0].(*errdetails.QuotaFailure)
+			if assert.True(t, ok) && assert.Len(t, quotaFailure.Violations, 1) {
+				assert.Equal(t, subject, quotaFailure.Violations[0].Subject)
+			}
+		}
+	}
+	// HashLife is limited by the nodes of its quadtree instead, which the R-pentomino grows past
+	defer func(limit int) { *maxHashLifeNodes = limit }(*maxHashLifeNodes)
+	*maxHashLifeNodes = 1000
+	_, err = client.RunGame(context.Background(), &gameoflifepb.GameRequest{
+		Board:   "[[0,1,1],[1,1,0],[0,1,0]]",
+		NumGens: 1000,
+		Engine:  gameoflifepb.Engine_HASHLIFE,
+	})
+	checkQuotaFailure(err, "hashlife_nodes")
+
+	// Streams send every generation, whatever the engine
+	stream, err := client.RunGameStream(context.Background(), &gameoflifepb.GameRequest{
+		Board:   "[[0,1,1],[1,1,0],[0,1,0]]",
+		NumGens: int32(*maxStreamGens) + 1,
+		Engine:  gameoflifepb.Engine_HASHLIFE,
+	})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	checkQuotaFailure(err, "stream_generations")
+}
+
+func TestRunGameCache(t *testing.T) {
+	gameRequest := gameoflifepb.GameRequest{
+		Board:   "[[0,1,0],[0,1,0],[0,1,0]]",
+		NumGens: 1,
+	}
+	exporter, client, _ := setupServer(t)
+	resultCache = cache.NewLRU(1, time.Minute, cache.WithEvictionHook(recordEviction))
+	defer func() { resultCache = nil }()
+
+	// The first game is run and cached, and the second is served from the cache
+	var responses []*gameoflifepb.GameResponse
+	for i := 0; i < 2; i++ {
+		resp, err := client.RunGame(context.Background(), &gameRequest)
+		assert.NoError(t, err)
+		responses = append(responses, resp)
+	}
+	assert.True(t, proto.Equal(responses[0], responses[1]))
+	var names []string
+	var hits []bool
+	for _, span := range exporter.GetSpans() {
+		names = append(names, span.Name)
+		for _, v := range span.Attributes {
+			if v.Key == "cache.hit" && span.Name == "RunGame" {
+				hits = append(hits, v.Value.AsBool())
+			}
+		}
+	}
+	assert.Equal(t, []string{
+		"GetCachedResult", "SetCachedResult", "RunGame", "gameoflifepb.GameOfLife/RunGame",
+		"GetCachedResult", "RunGame", "gameoflifepb.GameOfLife/RunGame",
+	}, names)
+	assert.Equal(t, []bool{false, true}, hits)
+
+	// A different game evicts the only cached response
+	gameRequest.NumGens = 2
+	_, err := client.RunGame(context.Background(), &gameRequest)
+	assert.NoError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, metricReader.Collect(context.Background(), &rm))
+	values := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && strings.HasPrefix(m.Name, "gameoflife.cache.") {
+				assert.Len(t, sum.DataPoints, 1)
+				values[m.Name] = sum.DataPoints[0].Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"gameoflife.cache.hits":      1,
+		"gameoflife.cache.misses":    2,
+		"gameoflife.cache.evictions": 1,
+	}, values)
+}
+
+func TestPatterns(t *testing.T) {
+	exporter, client, _ := setupServer(t)
+
+	listResp, err := client.ListPatterns(context.Background(), &gameoflifepb.ListPatternsRequest{})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, listResp.Patterns)
+	assert.Contains(t, exporter.GetSpans()[0].Attributes, attribute.Int("listpatterns_server.response.num_patterns", len(listResp.Patterns)))
+
+	pattern, err := client.GetPattern(context.Background(), &gameoflifepb.GetPatternRequest{Name: "blinker"})
+	assert.NoError(t, err)
+	assert.Equal(t, "blinker", pattern.Name)
+
+	_, err = client.GetPattern(context.Background(), &gameoflifepb.GetPatternRequest{Name: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// A blinker placed with a margin of one cell oscillates with period 2
+	resp, err := client.RunGame(context.Background(), &gameoflifepb.GameRequest{
+		PatternName: "blinker",
+		Placement:   &gameoflifepb.PatternPlacement{Row: 1, Col: 1},
+		NumGens:     4,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), resp.Period)
+	var patternNames []string
+	for _, span := range exporter.GetSpans() {
+		for _, v := range span.Attributes {
+			if v.Key == "rungame_server.request.pattern_name" {
+				patternNames = append(patternNames, v.Value.AsString())
+			}
+		}
+	}
+	assert.Equal(t, []string{"blinker"}, patternNames)
+}
+
+func TestRunGameRandomBoard(t *testing.T) {
+	exporter, client, _ := setupServer(t)
+
+	// The same seed gives the same game, which can be run again from the attributes of its span
+	gameRequest := gameoflifepb.GameRequest{
+		RandomBoard: &gameoflifepb.RandomBoard{Width: 40, Height: 30, Density: 0.35, Seed: 1234},
+		NumGens:     10,
+	}
+	first, err := client.RunGame(context.Background(), &gameRequest)
+	assert.NoError(t, err)
+	second, err := client.RunGame(context.Background(), &gameRequest)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(first, second))
+	assert.NotZero(t, first.Stats[0].Population)
+
+	span := exporter.GetSpans()[0]
+	assert.Equal(t, "RunGame", span.Name)
+	assert.Contains(t, span.Attributes, attribute.Int64("rungame_server.request.random_board.seed", 1234))
+	assert.Contains(t, span.Attributes, attribute.Int("rungame_server.request.random_board.width", 40))
+	assert.Contains(t, span.Attributes, attribute.Int("rungame_server.request.random_board.height", 30))
+	assert.Contains(t, span.Attributes, attribute.Float64("rungame_server.request.random_board.density", 0.35))
+}
+
+func TestRunGames(t *testing.T) {
+	exporter, client, _ := setupServer(t)
+
+	batch := &gameoflifepb.BatchGameRequest{
+		Requests: []*gameoflifepb.GameRequest{
+			{Board: "[[0,1,0],[0,1,0],[0,1,0]]", NumGens: 1},
+			{Board: "[[0,2]]", NumGens: 1},
+			{Board: "x = 3, y = 2000\n!", Format: gameoflifepb.BoardFormat_RLE, NumGens: 1},
+			{PatternName: "glider", NumGens: 4},
+		},
+	}
+	response, err := client.RunGames(context.Background(), batch)
+	assert.NoError(t, err)
+	if !assert.Len(t, response.Results, 4) {
+		return
+	}
+	assert.Equal(t, int32(codes.OK), response.Results[0].StatusCode)
+	assert.Equal(t, "[[0,0,0],[1,1,1],[0,0,0]]", response.Results[0].Response.GetBoard())
+	assert.Equal(t, int32(codes.InvalidArgument), response.Results[1].StatusCode)
+	assert.NotEmpty(t, response.Results[1].ErrorMessage)
+	assert.Equal(t, int32(codes.ResourceExhausted), response.Results[2].StatusCode)
+	assert.Nil(t, response.Results[2].Response)
+	assert.Equal(t, int32(codes.OK), response.Results[3].StatusCode)
+	assert.Equal(t, int32(4), response.Results[3].Response.GetFinalGeneration())
+
+	// Every game has its own span, a child of the span of the batch
+	spans := exporter.GetSpans()
+	batchSpan := spans[len(spans)-2]
+	assert.Equal(t, "RunGames", batchSpan.Name)
+	assert.Contains(t, batchSpan.Attributes, attribute.Int("rungames_server.request.num_games", 4))
+	assert.Contains(t, batchSpan.Attributes, attribute.Int("rungames_server.response.num_failed", 2))
+	indexes := []int64{}
+	for _, span := range spans {
+		if span.Name != "RunBatchGame" {
+			continue
+		}
+		assert.Equal(t, batchSpan.SpanContext.SpanID(), span.Parent.SpanID())
+		for _, attr := range span.Attributes {
+			if attr.Key == "rungames_server.game.index" {
+				indexes = append(indexes, attr.Value.AsInt64())
+			}
+		}
+	}
+	assert.ElementsMatch(t, []int64{0, 1, 2, 3}, indexes)
+}
+
+func TestRunGamesBatchSize(t *testing.T) {
+	_, client, _ := setupServer(t)
+	defer func(size int) { *maxBatchSize = size }(*maxBatchSize)
+	*maxBatchSize = 2
+
+	batch := &gameoflifepb.BatchGameRequest{
+		Requests: []*gameoflifepb.GameRequest{{NumGens: 1}, {NumGens: 1}, {NumGens: 1}},
+	}
+	_, err := client.RunGames(context.Background(), batch)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestJobs(t *testing.T) {
+	exporter, client, _ := setupServer(t)
+	ctx := context.Background()
+
+	job, err := client.SubmitGame(ctx, &gameoflifepb.GameRequest{PatternName: "glider", NumGens: 4})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, job.Id)
+	assert.Eventually(t, func() bool {
+		job, err = client.GetJob(ctx, &gameoflifepb.GetJobRequest{Id: job.Id})
+		return err == nil && job.State == gameoflifepb.JobState_JOB_SUCCEEDED
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(4), job.Generation)
+	assert.Equal(t, int32(4), job.Result.GetFinalGeneration())
+	assert.NotNil(t, job.FinishTime)
+
+	// The job runs in its own trace, linked to the span of the request that submitted it
+	var submitSpan, runSpan tracetest.SpanStub
+	assert.Eventually(t, func() bool {
+		for _, span := range exporter.GetSpans() {
+			switch span.Name {
+			case "SubmitGame":
+				submitSpan = span
+			case "RunJob":
+				runSpan = span
+			}
+		}
+		return runSpan.Name != ""
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Contains(t, submitSpan.Attributes, attribute.String("submitgame_server.job.id", job.Id))
+	assert.Contains(t, runSpan.Attributes, attribute.String("runjob_server.job.id", job.Id))
+	assert.NotEqual(t, submitSpan.SpanContext.TraceID(), runSpan.SpanContext.TraceID())
+	if assert.Len(t, runSpan.Links, 1) {
+		assert.Equal(t, submitSpan.SpanContext.SpanID(), runSpan.Links[0].SpanContext.SpanID())
+	}
+
+	// A job waiting for a worker is cancelled without running
+	jobSlots <- struct{}{}
+	pending, err := client.SubmitGame(ctx, &gameoflifepb.GameRequest{PatternName: "glider", NumGens: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, gameoflifepb.JobState_JOB_PENDING, pending.State)
+	_, err = client.CancelJob(ctx, &gameoflifepb.CancelJobRequest{Id: pending.Id})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		pending, err = client.GetJob(ctx, &gameoflifepb.GetJobRequest{Id: pending.Id})
+		return err == nil && pending.State == gameoflifepb.JobState_JOB_CANCELLED
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(codes.Canceled), pending.StatusCode)
+	<-jobSlots
+
+	list, err := client.ListJobs(ctx, &gameoflifepb.ListJobsRequest{})
+	assert.NoError(t, err)
+	if assert.Len(t, list.Jobs, 2) {
+		assert.Equal(t, job.Id, list.Jobs[0].Id)
+		assert.Equal(t, pending.Id, list.Jobs[1].Id)
+		assert.Nil(t, list.Jobs[0].Result)
+	}
+
+	_, err = client.GetJob(ctx, &gameoflifepb.GetJobRequest{Id: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.CancelJob(ctx, &gameoflifepb.CancelJobRequest{Id: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSubmitGameLimits(t *testing.T) {
+	_, client, _ := setupServer(t)
+	jobStore = jobs.NewStore(1, time.Minute)
+	jobSlots <- struct{}{}
+	defer func() { <-jobSlots }()
+
+	// Jobs are rejected over the limits of RunGame, and once the server holds maxJobs jobs
+	_, err := client.SubmitGame(context.Background(), &gameoflifepb.GameRequest{Board: "x = 3, y = 2000\n!", Format: gameoflifepb.BoardFormat_RLE, NumGens: 1})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	job, err := client.SubmitGame(context.Background(), &gameoflifepb.GameRequest{PatternName: "glider", NumGens: 1})
+	assert.NoError(t, err)
+	defer client.CancelJob(context.Background(), &gameoflifepb.CancelJobRequest{Id: job.GetId()})
+	_, err = client.SubmitGame(context.Background(), &gameoflifepb.GameRequest{PatternName: "glider", NumGens: 1})
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	if assert.Len(t, st.Details(), 1) {
+		assert.Equal(t, "jobs", st.Details()[0].(*errdetails.QuotaFailure).Violations[0].Subject)
+	}
+}
+
+func TestSessions(t *testing.T) {
+	exporter, client, _ := setupServer(t)
+	ctx := context.Background()
+
+	// The board of a pattern is generated once, and the rule of its RLE header is kept by the session
+	session, err := client.CreateSession(ctx, &gameoflifepb.CreateSessionRequest{
+		Game: &gameoflifepb.GameRequest{PatternName: "blinker", Placement: &gameoflifepb.PatternPlacement{Row: 1, Col: 1}, NumGens: 10},
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, session.Id)
+	assert.Equal(t, int32(0), session.Generation)
+	assert.Empty(t, session.Game.PatternName)
+	assert.Nil(t, session.Game.Placement)
+	assert.Equal(t, "B3/S23", session.Game.Rule)
+	assert.Equal(t, int32(0), session.Game.NumGens)
+	initialBoard := session.Game.Board
+
+	session, err = client.Step(ctx, &gameoflifepb.StepRequest{SessionId: session.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), session.Generation)
+	assert.NotEqual(t, initialBoard, session.Game.Board)
+	session, err = client.Step(ctx, &gameoflifepb.StepRequest{SessionId: session.Id, NumGens: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), session.Generation)
+	assert.Equal(t, initialBoard, session.Game.Board)
+
+	got, err := client.GetSession(ctx, &gameoflifepb.GetSessionRequest{SessionId: session.Id})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(session, got))
+
+	var stepSpan tracetest.SpanStub
+	for _, span := range exporter.GetSpans() {
+		if span.Name == "Step" {
+			stepSpan = span
+		}
+	}
+	assert.Contains(t, stepSpan.Attributes, attribute.String("step_server.session.id", session.Id))
+	assert.Contains(t, stepSpan.Attributes, attribute.Int("step_server.session.generation", 4))
+
+	_, err = client.Step(ctx, &gameoflifepb.StepRequest{SessionId: session.Id, NumGens: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.DeleteSession(ctx, &gameoflifepb.DeleteSessionRequest{SessionId: session.Id})
+	assert.NoError(t, err)
+	_, err = client.GetSession(ctx, &gameoflifepb.GetSessionRequest{SessionId: session.Id})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.Step(ctx, &gameoflifepb.StepRequest{SessionId: session.Id})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.DeleteSession(ctx, &gameoflifepb.DeleteSessionRequest{SessionId: session.Id})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCreateSessionLimits(t *testing.T) {
+	_, client, _ := setupServer(t)
+	sessionStore = sessions.NewMemoryStore(1, time.Minute)
+
+	// Sessions are rejected once the server holds maxSessions sessions
+	_, err := client.CreateSession(context.Background(), &gameoflifepb.CreateSessionRequest{Game: &gameoflifepb.GameRequest{PatternName: "glider"}})
+	assert.NoError(t, err)
+	_, err = client.CreateSession(context.Background(), &gameoflifepb.CreateSessionRequest{Game: &gameoflifepb.GameRequest{PatternName: "glider"}})
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	if assert.Len(t, st.Details(), 1) {
+		assert.Equal(t, "sessions", st.Details()[0].(*errdetails.QuotaFailure).Violations[0].Subject)
+	}
+}
+
+func TestSessionsInvalidGame(t *testing.T) {
+	_, client, _ := setupServer(t)
+	_, err := client.CreateSession(context.Background(), &gameoflifepb.CreateSessionRequest{
+		Game: &gameoflifepb.GameRequest{Board: "[[1,2]]"},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.CreateSession(context.Background(), &gameoflifepb.CreateSessionRequest{
+		Game: &gameoflifepb.GameRequest{Board: "x = 3, y = 2000\n!", Format: gameoflifepb.BoardFormat_RLE},
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestSelfTest(t *testing.T) {
+	assert.NoError(t, selfTest(context.Background()))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, selfTest(ctx))
+}
+
+func TestHealthHandlers(t *testing.T) {
+	setupServer(t)
+	healthChecks = health.NewRegistry()
+	t.Cleanup(func() { healthChecks = health.NewRegistry() })
+	healthChecks.RegisterLiveness("self_test", selfTest)
+	var exports health.Recorder
+	exports.Record(errors.New("connection refused"))
+	healthChecks.Register("otlp_trace_export", exports.Check)
+
+	// A failed export makes the server unready, but a restart wouldn't fix it
+	wr := httptest.NewRecorder()
+	SetupHandlers().ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/readiness", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, wr.Code)
+	var readiness struct {
+		Readiness bool            `json:"readiness"`
+		Checks    []health.Result `json:"checks"`
+	}
+	assert.NoError(t, json.NewDecoder(wr.Body).Decode(&readiness))
+	assert.False(t, readiness.Readiness)
+	if assert.Len(t, readiness.Checks, 2) {
+		assert.True(t, readiness.Checks[0].Healthy)
+		assert.Contains(t, readiness.Checks[1].Error, "connection refused")
+	}
+
+	wr = httptest.NewRecorder()
+	SetupHandlers().ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/liveness", nil))
+	assert.Equal(t, http.StatusOK, wr.Code)
+	var liveness struct {
+		Liveness bool            `json:"liveness"`
+		Checks   []health.Result `json:"checks"`
+	}
+	assert.NoError(t, json.NewDecoder(wr.Body).Decode(&liveness))
+	assert.True(t, liveness.Liveness)
+	if assert.Len(t, liveness.Checks, 1) {
+		assert.Equal(t, "self_test", liveness.Checks[0].Name)
+	}
+}
@@ -0,0 +1,239 @@
+package sessions
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// FileStore is a SessionStore keeping every session in a JSON file of a directory, named after the id
+// of the session, so that the sessions outlive the server. Only one FileStore must use a directory at a time.
+// A session expires with the modification time of its file.
+type FileStore struct {
+	dir         string
+	maxSessions int
+	ttl         time.Duration
+	now         func() time.Time
+
+	mu sync.Mutex
+	// locks holds the lock of every stored session used since the store was created, held while it is written
+	locks map[string]*sync.Mutex
+}
+
+// NewFileStore Returns a store of up to maxSessions sessions in dir, or any number of sessions if maxSessions
+// is 0, keeping every session for ttl after it was last created or updated, or until it is deleted if ttl is 0.
+// The directory is created if it doesn't exist.
+func NewFileStore(dir string, maxSessions int, ttl time.Duration) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStore{
+		dir:         dir,
+		maxSessions: maxSessions,
+		ttl:         ttl,
+		now:         time.Now,
+		locks:       make(map[string]*sync.Mutex),
+	}, nil
+}
+
+// path Returns the path of the file of the session with the given id, and ErrNotFound if the id is not
+// a session id, so that an id can't name a file outside of the directory
+func (s *FileStore) path(id string) (string, error) {
+	if _, err := hex.DecodeString(id); err != nil || len(id) != 16 {
+		return "", ErrNotFound
+	}
+	return filepath.Join(s.dir, id+".json"), nil
+}
+
+// lock Locks the session with the given id, and Returns the function unlocking it
+func (s *FileStore) lock(id string) func() {
+	s.mu.Lock()
+	l, ok := s.locks[id]
+	if !ok {
+		l = &sync.Mutex{}
+		s.locks[id] = l
+	}
+	s.mu.Unlock()
+	l.Lock()
+	return l.Unlock
+}
+
+// expired Returns true if the session of a file last modified at modTime is older than the TTL
+func (s *FileStore) expired(modTime time.Time) bool {
+	return s.ttl > 0 && !s.now().Before(modTime.Add(s.ttl))
+}
+
+// count Removes the files of the expired sessions, and Returns the number of sessions left. s.mu must be held.
+func (s *FileStore) count() (int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if s.expired(info.ModTime()) {
+			if err := os.Remove(filepath.Join(s.dir, entry.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return 0, err
+			}
+			delete(s.locks, id)
+			continue
+		}
+		n++
+	}
+	return n, nil
+}
+
+// forget Removes the lock of a session that is not stored. The callers already waiting for the lock still get it,
+// and find that the session is not stored either, as session ids are never reused.
+func (s *FileStore) forget(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.locks, id)
+}
+
+// read Returns the session stored in the file at path, and ErrNotFound if it expired
+func (s *FileStore) read(path string) (*gameoflifepb.Session, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && s.expired(info.ModTime())) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	session := &gameoflifepb.Session{}
+	if err := protojson.Unmarshal(data, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// write Stores the session in the file at path. The session is written to a temporary file renamed to path,
+// so that readers never see a partially written session.
+func (s *FileStore) write(path string, session *gameoflifepb.Session) error {
+	data, err := protojson.Marshal(session)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *FileStore) Create(_ context.Context, session *gameoflifepb.Session) (*gameoflifepb.Session, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	created := proto.Clone(session).(*gameoflifepb.Session)
+	created.Id = id
+	// The store is locked while the sessions are counted, so that concurrent sessions can't go over the limit
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.maxSessions > 0 || s.ttl > 0 {
+		n, err := s.count()
+		if err != nil {
+			return nil, err
+		}
+		if s.maxSessions > 0 && n >= s.maxSessions {
+			return nil, ErrFull
+		}
+	}
+	if err := s.write(path, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *FileStore) Get(_ context.Context, id string) (*gameoflifepb.Session, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.read(path)
+}
+
+func (s *FileStore) Update(_ context.Context, id string, update UpdateFunc) (*gameoflifepb.Session, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	unlock := s.lock(id)
+	defer unlock()
+	session, err := s.read(path)
+	if errors.Is(err, ErrNotFound) {
+		s.forget(id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := update(session); err != nil {
+		return nil, err
+	}
+	session.Id = id
+	if err := s.write(path, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *FileStore) Delete(_ context.Context, id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	unlock := s.lock(id)
+	defer unlock()
+	defer s.forget(id)
+	if info, err := os.Stat(path); err == nil && s.expired(info.ModTime()) {
+		os.Remove(path)
+		return ErrNotFound
+	}
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,126 @@
+package sessions
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// memoryEntry is a session stored in memory, locked while it is updated
+type memoryEntry struct {
+	// writeTime is the time the session was last written, in Unix nanoseconds, read without the lock of the entry
+	writeTime atomic.Int64
+
+	mu      sync.Mutex
+	session *gameoflifepb.Session
+}
+
+// MemoryStore is a SessionStore keeping the sessions in memory, until they are deleted, expire or the server stops
+type MemoryStore struct {
+	maxSessions int
+	ttl         time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+}
+
+// NewMemoryStore Returns an empty in-memory store of up to maxSessions sessions, or any number of sessions if
+// maxSessions is 0, keeping every session for ttl after it was last created or updated, or until it is deleted
+// if ttl is 0
+func NewMemoryStore(maxSessions int, ttl time.Duration) *MemoryStore {
+	return &MemoryStore{
+		maxSessions: maxSessions,
+		ttl:         ttl,
+		now:         time.Now,
+		entries:     make(map[string]*memoryEntry),
+	}
+}
+
+func (s *MemoryStore) Create(_ context.Context, session *gameoflifepb.Session) (*gameoflifepb.Session, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	session = proto.Clone(session).(*gameoflifepb.Session)
+	session.Id = id
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeExpired()
+	if s.maxSessions > 0 && len(s.entries) >= s.maxSessions {
+		return nil, ErrFull
+	}
+	entry := &memoryEntry{session: session}
+	entry.writeTime.Store(s.now().UnixNano())
+	s.entries[id] = entry
+	return proto.Clone(session).(*gameoflifepb.Session), nil
+}
+
+// entry Returns the entry of the session with the given id
+func (s *MemoryStore) entry(id string) (*memoryEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeExpired()
+	entry, ok := s.entries[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return entry, nil
+}
+
+func (s *MemoryStore) Get(_ context.Context, id string) (*gameoflifepb.Session, error) {
+	entry, err := s.entry(id)
+	if err != nil {
+		return nil, err
+	}
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	return proto.Clone(entry.session).(*gameoflifepb.Session), nil
+}
+
+// Update Applies update while only holding the lock of the session, so that other sessions can be used meanwhile
+func (s *MemoryStore) Update(_ context.Context, id string, update UpdateFunc) (*gameoflifepb.Session, error) {
+	entry, err := s.entry(id)
+	if err != nil {
+		return nil, err
+	}
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	session := proto.Clone(entry.session).(*gameoflifepb.Session)
+	if err := update(session); err != nil {
+		return nil, err
+	}
+	session.Id = id
+	entry.session = session
+	entry.writeTime.Store(s.now().UnixNano())
+	return proto.Clone(session).(*gameoflifepb.Session), nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeExpired()
+	if _, ok := s.entries[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.entries, id)
+	return nil
+}
+
+// removeExpired Removes the sessions not written for longer than the TTL. s.mu must be held.
+func (s *MemoryStore) removeExpired() {
+	if s.ttl <= 0 {
+		return
+	}
+	oldest := s.now().Add(-s.ttl).UnixNano()
+	for id, entry := range s.entries {
+		if entry.writeTime.Load() <= oldest {
+			delete(s.entries, id)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package sessions
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+)
+
+// ErrNotFound is returned for a session id that is not in the store, or whose session expired
+var ErrNotFound = errors.New("session not found")
+
+// ErrFull is returned by Create when the store already holds its maximum number of sessions
+var ErrFull = errors.New("too many sessions")
+
+// UpdateFunc changes a session in place, or Returns an error to leave it unchanged
+type UpdateFunc func(session *gameoflifepb.Session) error
+
+// SessionStore stores game sessions by id. The sessions passed to and returned by a store are copies,
+// so they can be modified by the caller.
+type SessionStore interface {
+	// Create Stores a new session, and Returns it with its id, or ErrFull if the store is full
+	Create(ctx context.Context, session *gameoflifepb.Session) (*gameoflifepb.Session, error)
+	// Get Returns the session with the given id, or ErrNotFound
+	Get(ctx context.Context, id string) (*gameoflifepb.Session, error)
+	// Update Applies update to the session with the given id, and Returns the updated session, or ErrNotFound.
+	// The updates of a session are applied one at a time, so update can read and write the session safely.
+	Update(ctx context.Context, id string, update UpdateFunc) (*gameoflifepb.Session, error)
+	// Delete Removes the session with the given id, or Returns ErrNotFound
+	Delete(ctx context.Context, id string) error
+}
+
+// newID Returns a random session id of 16 hexadecimal digits
+func newID() (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,210 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
+)
+
+// newStores Returns an empty store of every implementation, by name, with the given limits
+func newStores(t *testing.T, maxSessions int, ttl time.Duration) map[string]SessionStore {
+	fileStore, err := NewFileStore(t.TempDir(), maxSessions, ttl)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	return map[string]SessionStore{
+		"memory": NewMemoryStore(maxSessions, ttl),
+		"file":   fileStore,
+	}
+}
+
+func TestSessionStore(t *testing.T) {
+	ctx := context.Background()
+	for name, s := range newStores(t, 0, 0) {
+		t.Run(name, func(t *testing.T) {
+			created, err := s.Create(ctx, &gameoflifepb.Session{Game: &gameoflifepb.GameRequest{Board: "[[1]]"}})
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			if len(created.Id) != 16 {
+				t.Errorf("Got id %q, expected 16 hexadecimal digits", created.Id)
+			}
+
+			updated, err := s.Update(ctx, created.Id, func(session *gameoflifepb.Session) error {
+				session.Generation += 2
+				session.Extinct = true
+				return nil
+			})
+			if err != nil || updated.Generation != 2 || !updated.Extinct {
+				t.Errorf("Got %v, %v, expected the session at generation 2", updated, err)
+			}
+
+			// A failed update leaves the session unchanged
+			errUpdate := errors.New("update failed")
+			if _, err := s.Update(ctx, created.Id, func(session *gameoflifepb.Session) error {
+				session.Generation = 10
+				return errUpdate
+			}); err != errUpdate {
+				t.Errorf("Got %v, expected %v", err, errUpdate)
+			}
+			got, err := s.Get(ctx, created.Id)
+			if err != nil || got.Generation != 2 || got.Game.GetBoard() != "[[1]]" {
+				t.Errorf("Got %v, %v, expected the session at generation 2", got, err)
+			}
+
+			if err := s.Delete(ctx, created.Id); err != nil {
+				t.Errorf("Error: %v", err)
+			}
+			if _, err := s.Get(ctx, created.Id); err != ErrNotFound {
+				t.Errorf("Got %v, expected %v", err, ErrNotFound)
+			}
+		})
+	}
+}
+
+func TestSessionStoreNotFound(t *testing.T) {
+	ctx := context.Background()
+	update := func(session *gameoflifepb.Session) error { return nil }
+	for name, s := range newStores(t, 0, 0) {
+		for _, id := range []string{"0123456789abcdef", "unknown", "../../etc/passwd", ""} {
+			testname := fmt.Sprintf("%v/%q", name, id)
+			t.Run(testname, func(t *testing.T) {
+				if _, err := s.Get(ctx, id); err != ErrNotFound {
+					t.Errorf("Got %v, expected %v", err, ErrNotFound)
+				}
+				if _, err := s.Update(ctx, id, update); err != ErrNotFound {
+					t.Errorf("Got %v, expected %v", err, ErrNotFound)
+				}
+				if err := s.Delete(ctx, id); err != ErrNotFound {
+					t.Errorf("Got %v, expected %v", err, ErrNotFound)
+				}
+			})
+		}
+	}
+}
+
+func TestSessionStoreConcurrentUpdates(t *testing.T) {
+	ctx := context.Background()
+	for name, s := range newStores(t, 0, 0) {
+		t.Run(name, func(t *testing.T) {
+			created, err := s.Create(ctx, &gameoflifepb.Session{})
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if _, err := s.Update(ctx, created.Id, func(session *gameoflifepb.Session) error {
+						session.Generation++
+						return nil
+					}); err != nil {
+						t.Errorf("Error: %v", err)
+					}
+				}()
+			}
+			wg.Wait()
+			if got, _ := s.Get(ctx, created.Id); got.GetGeneration() != 20 {
+				t.Errorf("Got %v, expected generation 20", got)
+			}
+		})
+	}
+}
+
+func TestFileStorePersists(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := NewFileStore(dir, 0, 0)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	created, err := s.Create(ctx, &gameoflifepb.Session{Generation: 5})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	// A new store on the same directory, as after a restart of the server, finds the session
+	reopened, err := NewFileStore(dir, 0, 0)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if got, err := reopened.Get(ctx, created.Id); err != nil || got.Generation != 5 {
+		t.Errorf("Got %v, %v, expected the session at generation 5", got, err)
+	}
+}
+
+func TestSessionStoreLimits(t *testing.T) {
+	ctx := context.Background()
+	for name, s := range newStores(t, 2, time.Hour) {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			switch s := s.(type) {
+			case *MemoryStore:
+				s.now = func() time.Time { return now }
+			case *FileStore:
+				s.now = func() time.Time { return now }
+			}
+			first, err := s.Create(ctx, &gameoflifepb.Session{})
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			if _, err := s.Create(ctx, &gameoflifepb.Session{}); err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			if _, err := s.Create(ctx, &gameoflifepb.Session{}); err != ErrFull {
+				t.Errorf("Got %v, expected %v", err, ErrFull)
+			}
+
+			// Deleting a session makes room for another one
+			if err := s.Delete(ctx, first.Id); err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+			last, err := s.Create(ctx, &gameoflifepb.Session{})
+			if err != nil {
+				t.Fatalf("Error: %v", err)
+			}
+
+			// The sessions expire once they haven't been written for the TTL
+			now = now.Add(2 * time.Hour)
+			if _, err := s.Get(ctx, last.Id); err != ErrNotFound {
+				t.Errorf("Got %v, expected %v", err, ErrNotFound)
+			}
+			for i := 0; i < 2; i++ {
+				if _, err := s.Create(ctx, &gameoflifepb.Session{}); err != nil {
+					t.Errorf("Error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestFileStoreForgetsLocks(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewFileStore(t.TempDir(), 0, 0)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	created, err := s.Create(ctx, &gameoflifepb.Session{})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	update := func(session *gameoflifepb.Session) error { return nil }
+	if _, err := s.Update(ctx, created.Id, update); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if err := s.Delete(ctx, created.Id); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if _, err := s.Update(ctx, "0123456789abcdef", update); err != ErrNotFound {
+		t.Errorf("Got %v, expected %v", err, ErrNotFound)
+	}
+	if len(s.locks) != 0 {
+		t.Errorf("Got %v locks, expected none once the sessions are gone", len(s.locks))
+	}
+}
@@ -1,16 +1,25 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/client"
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/gameoflife"
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/health"
 	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/logging"
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/render"
 	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
@@ -25,23 +34,44 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
 	httpPort         = flag.Int("httpPort", 8080, "Port for webapp frontend")
 	host             = flag.String("host", "localhost:8081", "Host address for gRPC server")
 	resources        = flag.String("resources", "webapp/resources", "Filepath of webapp resources folder")
+	maxRequestBytes  = flag.Int64("maxRequestBytes", 1<<20, "Maximum size of the body of a game request in bytes, 0 for no limit")
+	maxGifFrames     = flag.Int("maxGifFrames", 500, "Maximum number of generations of an animated GIF of a game")
+	healthCacheTTL   = flag.Duration("healthCacheTTL", 5*time.Second, "Time the result of the health checks is cached for")
 	logger           *zap.Logger
 	gameOfLifeClient client.Client
+	// healthChecks holds the checks of the readiness and liveness of the webapp
+	healthChecks = health.NewRegistry()
+	// traceExports records the result of the last export of spans
+	traceExports health.Recorder
 )
 
+// recordedExporter is a span exporter recording the result of every export in traceExports
+type recordedExporter struct {
+	sdktrace.SpanExporter
+}
+
+func (e recordedExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	traceExports.Record(err)
+	return err
+}
+
 func InitTracerProvider(ctx context.Context) *sdktrace.TracerProvider {
 	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithInsecure())
 	if err != nil {
 		logger.Fatal("Constructing new exporter", zap.Error(err))
 	}
 	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
+		sdktrace.WithBatcher(recordedExporter{exporter}),
 	)
 	otel.SetTracerProvider(tp)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
@@ -101,6 +131,11 @@ func main() {
 		logger.Fatal("Did not connect", zap.Error(err))
 	}
 
+	// The webapp is ready once it is connected to the server and exporting its spans
+	healthChecks = health.NewRegistry(health.WithCacheTTL(*healthCacheTTL))
+	healthChecks.Register("grpc_connection", gameOfLifeClient.CheckConnection)
+	healthChecks.Register("otlp_trace_export", traceExports.Check)
+
 	// Start HTTP server
 	mux := SetupHandlers()
 
@@ -110,24 +145,50 @@ func main() {
 	}
 }
 
+// requestLogger Returns the logger of the request of ctx, with the trace and span ids of its span
+func requestLogger(ctx context.Context) *zap.Logger {
+	span := trace.SpanFromContext(ctx)
+	return logger.With(
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+	)
+}
+
 // run Runs the game of life program with the given game configuration
-func run(ctx context.Context, board string, numGens int32) (*gameoflifepb.GameResponse, error) {
-	gameConfig := &gameoflifepb.GameRequest{
-		Board:   board,
-		NumGens: numGens,
-	}
-	logger.Info("Running game", zap.Any("gameConfig", gameConfig))
+func run(ctx context.Context, gameConfig *gameoflifepb.GameRequest) (*gameoflifepb.GameResponse, error) {
+	reqLogger := requestLogger(ctx)
+	reqLogger.Info("Running game", zap.Any("gameConfig", gameConfig))
 	r, err := gameOfLifeClient.RunGame(ctx, gameConfig)
 	if err != nil {
-		logger.Error("Calling gameOfLifeClient.RunGame",
+		reqLogger.Error("Calling gameOfLifeClient.RunGame",
 			zap.Error(err),
 		)
 	} else {
-		logger.Info("Finished running game", zap.Any("resultBoard", r.GetBoard()))
+		reqLogger.Info("Finished running game", zap.Any("resultBoard", r.GetBoard()))
 	}
 	return r, err
 }
 
+// asciiRows Returns every row of the board with its cells separated by spaces. The cells of multi-state rules
+// are padded to the width of the highest state, so that the columns line up.
+func asciiRows(board [][]int) []string {
+	width := 1
+	for _, row := range board {
+		for _, cell := range row {
+			width = max(width, len(strconv.Itoa(cell)))
+		}
+	}
+	rows := make([]string, len(board))
+	for i, row := range board {
+		cells := make([]string, len(row))
+		for j, cell := range row {
+			cells[j] = fmt.Sprintf("%*d", width, cell)
+		}
+		rows[i] = strings.Join(cells, " ")
+	}
+	return rows
+}
+
 // boardToAscii Converts the given board string to a readable ASCII format
 func boardToAscii(board string) (string, error) {
 	boardList := make([][]int, 1)
@@ -136,22 +197,109 @@ func boardToAscii(board string) (string, error) {
 		return "", err
 	}
 
+	return cellsToAscii(boardList), nil
+}
+
+// cellsToAscii Returns the given cells in a readable ASCII format, one bracketed row per line
+func cellsToAscii(cells [][]int) string {
 	result := ""
-	for _, v := range boardList {
-		result += fmt.Sprintf("%v \n ", v)
+	for _, row := range asciiRows(cells) {
+		result += fmt.Sprintf("[%s] \n ", row)
 	}
-	return result, nil
+	return result
+}
+
+// problem is an error response of the webapp, as an RFC 7807 problem details document
+type problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+	// Violations are the invalid fields or the limits exceeded by a game request, as reported by the gRPC server
+	Violations []fieldViolation `json:"violations,omitempty"`
 }
 
-func writeError(w http.ResponseWriter, encoder *json.Encoder, code int, err error, message string) {
+// writeProblem Writes an application/problem+json error response with the given status code. The problems
+// of the webapp have no type of their own, so the title is the text of the status code.
+func writeProblem(w http.ResponseWriter, encoder *json.Encoder, code int, detail string, violations []fieldViolation) {
+	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(code)
-	resp := struct {
-		Error error `json:"error"`
-	}{
-		Error: err,
+	encoder.Encode(problem{
+		Type:       "about:blank",
+		Title:      http.StatusText(code),
+		Status:     code,
+		Detail:     detail,
+		Violations: violations,
+	})
+}
+
+// writeError Writes the error of the request of ctx as a problem with the given status code, and logs it with message
+func writeError(ctx context.Context, w http.ResponseWriter, encoder *json.Encoder, code int, err error, message string) {
+	writeProblem(w, encoder, code, err.Error(), nil)
+	requestLogger(ctx).Error(message, zap.Error(err))
+}
+
+// fieldViolation is an invalid field or a limit exceeded by the game request, as reported by the gRPC server
+type fieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// statusClientClosedRequest is the status code of a request canceled by its client, as logged by nginx. The client
+// doesn't read the response, but the status code tells the canceled requests apart from the errors of the server.
+const statusClientClosedRequest = 499
+
+// storeFullRetryAfter is the Retry-After of a request rejected because the jobs or sessions of the server are full
+const storeFullRetryAfter = "30"
+
+// writeStatusError Writes the error of a failed gRPC call: a 400 with the field violations of an invalid request,
+// a 413 with the limits exceeded by a request over the limits of the server, a 503 with a Retry-After if the
+// jobs or sessions of the server are full, a 404 for an unknown pattern, job or session, a 504 if the game ran
+// out of time, and a 500 otherwise
+func writeStatusError(ctx context.Context, w http.ResponseWriter, encoder *json.Encoder, err error) {
+	st := status.Convert(err)
+	var code int
+	switch st.Code() {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.ResourceExhausted:
+		code = http.StatusRequestEntityTooLarge
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.DeadlineExceeded:
+		code = http.StatusGatewayTimeout
+	case codes.Canceled:
+		code = statusClientClosedRequest
+	default:
+		writeError(ctx, w, encoder, http.StatusInternalServerError, err, "Internal server error")
+		return
 	}
-	encoder.Encode(resp)
-	logger.Error(message, zap.Error(err))
+	var violations []fieldViolation
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range detail.GetFieldViolations() {
+				violations = append(violations, fieldViolation{Field: v.GetField(), Description: v.GetDescription()})
+			}
+		case *errdetails.QuotaFailure:
+			for _, v := range detail.GetViolations() {
+				violations = append(violations, fieldViolation{Field: v.GetSubject(), Description: v.GetDescription()})
+				// A full store is not a fault of the request, which can be retried once jobs or sessions expire
+				if v.GetSubject() == "jobs" || v.GetSubject() == "sessions" {
+					code = http.StatusServiceUnavailable
+				}
+			}
+		}
+	}
+	if code == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", storeFullRetryAfter)
+	}
+	writeProblem(w, encoder, code, st.Message(), violations)
+	requestLogger(ctx).Error("Request failed",
+		zap.Int("httpStatus", code),
+		zap.Stringer("grpcCode", st.Code()),
+		zap.Any("violations", violations),
+	)
 }
 
 func SetupHandlers() *http.ServeMux {
@@ -160,6 +308,17 @@ func SetupHandlers() *http.ServeMux {
 	mux.HandleFunc("/readiness", ReadinessHandler)
 	mux.HandleFunc("/liveness", LivenessHandler)
 	mux.Handle("/rungame", otelhttp.NewHandler(http.HandlerFunc(RunGameHandler), "RunGameHandler"))
+	mux.Handle("POST /rungame/live", otelhttp.NewHandler(http.HandlerFunc(RunGameLiveHandler), "RunGameLiveHandler"))
+	mux.Handle("GET /patterns", otelhttp.NewHandler(http.HandlerFunc(ListPatternsHandler), "ListPatternsHandler"))
+	mux.Handle("GET /patterns/{name}", otelhttp.NewHandler(http.HandlerFunc(GetPatternHandler), "GetPatternHandler"))
+	mux.Handle("POST /jobs", otelhttp.NewHandler(http.HandlerFunc(SubmitJobHandler), "SubmitJobHandler"))
+	mux.Handle("GET /jobs", otelhttp.NewHandler(http.HandlerFunc(ListJobsHandler), "ListJobsHandler"))
+	mux.Handle("GET /jobs/{id}", otelhttp.NewHandler(http.HandlerFunc(GetJobHandler), "GetJobHandler"))
+	mux.Handle("POST /jobs/{id}/cancel", otelhttp.NewHandler(http.HandlerFunc(CancelJobHandler), "CancelJobHandler"))
+	mux.Handle("POST /sessions", otelhttp.NewHandler(http.HandlerFunc(CreateSessionHandler), "CreateSessionHandler"))
+	mux.Handle("GET /sessions/{id}", otelhttp.NewHandler(http.HandlerFunc(GetSessionHandler), "GetSessionHandler"))
+	mux.Handle("POST /sessions/{id}/step", otelhttp.NewHandler(http.HandlerFunc(StepSessionHandler), "StepSessionHandler"))
+	mux.Handle("DELETE /sessions/{id}", otelhttp.NewHandler(http.HandlerFunc(DeleteSessionHandler), "DeleteSessionHandler"))
 	mux.Handle("/", http.FileServer(http.Dir(*resources)))
 
 	mux.HandleFunc("/config.js", ConfigHandler)
@@ -181,72 +340,839 @@ func RunGameHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	span := trace.SpanFromContext(ctx)
 	defer span.End()
-	logger = logger.With(
-		zap.String("trace_id", span.SpanContext().TraceID().String()),
-		zap.String("span_id", span.SpanContext().SpanID().String()),
-	)
+	reqLogger := requestLogger(ctx)
 
 	var body gameoflifepb.GameRequest
 	encoder := json.NewEncoder(w)
-	err := json.NewDecoder(r.Body).Decode(&body)
-	if err != nil {
-		span.RecordError(err)
-		writeError(w, encoder, http.StatusBadRequest, err, "Bad request error")
+	if !decodeGameRequest(w, r, encoder, &body) {
 		return
 	}
 
-	logger.Info("Received request", zap.Any("body", &body))
+	reqLogger.Info("Received request", zap.Any("body", &body))
 	span.SetAttributes(
 		attribute.String("rungame_handler.request.board", body.GetBoard()),
 		attribute.Int("rungame_handler.request.num_gens", int(body.GetNumGens())),
+		attribute.String("rungame_handler.request.rule", body.GetRule()),
+		attribute.String("rungame_handler.request.topology", body.GetTopology().String()),
+		attribute.String("rungame_handler.request.engine", body.GetEngine().String()),
+		attribute.String("rungame_handler.request.format", body.GetFormat().String()),
 	)
-	result, err := run(ctx, body.GetBoard(), body.GetNumGens())
+	w.Header().Set("Vary", "Accept")
+	contentType, err := responseType(r)
 	if err != nil {
-		writeError(w, encoder, http.StatusInternalServerError, err, "Internal server error")
+		span.RecordError(err)
+		if errors.Is(err, errNotAcceptable) {
+			writeError(ctx, w, encoder, http.StatusNotAcceptable, err, "Not acceptable error")
+			return
+		}
+		writeError(ctx, w, encoder, http.StatusBadRequest, err, "Bad request error")
+		return
+	}
+	span.SetAttributes(attribute.String("rungame_handler.response.content_type", contentType))
+	if strings.HasPrefix(contentType, "image/") {
+		options, err := renderOptions(r)
+		if err != nil {
+			span.RecordError(err)
+			writeError(ctx, w, encoder, http.StatusBadRequest, err, "Bad request error")
+			return
+		}
+		renderGame(ctx, w, encoder, &body, contentType, options)
 		return
 	}
+	result, err := run(ctx, &body)
+	if err != nil {
+		writeStatusError(ctx, w, encoder, err)
+		return
+	}
+	span.SetAttributes(
+		attribute.Int("rungame_handler.response.final_generation", int(result.GetFinalGeneration())),
+		attribute.Int("rungame_handler.response.period", int(result.GetPeriod())),
+		attribute.Bool("rungame_handler.response.extinct", result.GetExtinct()),
+	)
 
-	ascii, asciiErr := boardToAscii(result.GetBoard())
-	if asciiErr != nil {
-		writeError(w, encoder, http.StatusBadRequest, asciiErr, "Bad request error")
+	if contentType != jsonType {
+		text, err := boardText(contentType, &body, result)
+		if err != nil {
+			writeError(ctx, w, encoder, http.StatusInternalServerError, err, "Internal server error")
+			return
+		}
+		w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, text)
+		reqLogger.Info("Sending result board",
+			zap.Int("httpStatus", http.StatusOK),
+			zap.String("contentType", contentType),
+			zap.String("resultBoard", text),
+		)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
-	resp := struct {
-		ResultBoard string `json:"resultBoard"`
-	}{
-		ResultBoard: ascii,
+
+	resp, err := newGameResult(body.GetFormat(), result)
+	if err != nil {
+		writeError(ctx, w, encoder, http.StatusInternalServerError, err, "Internal server error")
+		return
 	}
-	logger.Info("Sending result board",
+	w.Header().Set("Content-Type", jsonType)
+	w.WriteHeader(http.StatusOK)
+	reqLogger.Info("Sending result board",
 		zap.Int("httpStatus", http.StatusOK),
 		zap.Any("resultBoard", resp.ResultBoard),
 	)
-	span.SetAttributes(
-		attribute.String("rungame_handler.response.ascii_board", ascii),
-	)
+	span.SetAttributes(attribute.String("rungame_handler.response.ascii_board", resp.ResultBoard))
 	encoder.Encode(resp)
 }
 
-func ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+// Media types of the responses of /rungame besides images
+const (
+	jsonType = "application/json"
+	textType = "text/plain"
+	rleType  = "application/x-life-rle"
+)
+
+// responseTypes are the media types of the responses of /rungame, by the value of the format query parameter
+// choosing them
+var responseTypes = map[string]string{
+	"json": jsonType,
+	"text": textType,
+	"rle":  rleType,
+	"png":  "image/png",
+	"svg":  "image/svg+xml",
+	"gif":  "image/gif",
+}
+
+// errNotAcceptable is returned by responseType if the Accept header of the request accepts none of the responseTypes
+var errNotAcceptable = errors.New("the Accept header must accept JSON, plain text, RLE, PNG, SVG or GIF")
+
+// acceptedType Returns the media type of responseTypes matched by a media range of an Accept header, where */* and
+// application/* match JSON, text/* plain text and image/* PNG, or "" if it matches none of them
+func acceptedType(mediaRange string) string {
+	switch mediaRange {
+	case "*/*", "application/*":
+		return jsonType
+	case "text/*":
+		return textType
+	case "image/*":
+		return responseTypes["png"]
+	}
+	for _, contentType := range responseTypes {
+		if mediaRange == contentType {
+			return contentType
+		}
+	}
+	return ""
+}
+
+// responseType Returns the media type of the response to the game request r, chosen by the format query parameter,
+// or else by the Accept header: the type of responseTypes with the highest quality, the first listed one among
+// those of the same quality. Requests without an Accept header get JSON.
+func responseType(r *http.Request) (string, error) {
+	if format := strings.ToLower(r.URL.Query().Get("format")); format != "" {
+		contentType, ok := responseTypes[format]
+		if !ok {
+			return "", fmt.Errorf("format must be json, text, rle, png, svg or gif, got %q", format)
+		}
+		return contentType, nil
+	}
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return jsonType, nil
+	}
+	best, bestQuality := "", 0.0
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(mediaRange)
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if contentType := acceptedType(mediaType); contentType != "" && quality > bestQuality {
+			best, bestQuality = contentType, quality
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("%w, got %q", errNotAcceptable, accept)
+	}
+	return best, nil
+}
+
+// boardText Returns the final board of the game as ASCII art for text/plain, or in RLE for application/x-life-rle
+// with the rule the game was run with, whatever the format of the request
+func boardText(contentType string, body *gameoflifepb.GameRequest, result *gameoflifepb.GameResponse) (string, error) {
+	cells, err := gameoflife.BoardCells(result.GetBoard(), result.GetStructuredBoard(), body.GetFormat())
+	if err != nil {
+		return "", err
+	}
+	if contentType == rleType {
+		return gameoflife.CellsRLE(cells, gameoflife.RequestRule(body))
+	}
+	return strings.Join(asciiRows(cells), "\n") + "\n", nil
+}
+
+// renderOptions Returns the rendering options of the query parameters of r: cell_size in pixels, the alive and
+// dead colors as 6 hexadecimal digits, and the delay between the generations of a GIF in milliseconds
+func renderOptions(r *http.Request) ([]render.Option, error) {
+	query := r.URL.Query()
+	var options []render.Option
+	if s := query.Get("cell_size"); s != "" {
+		size, err := strconv.Atoi(s)
+		if err != nil || size < 1 || size > render.MaxCellSize {
+			return nil, fmt.Errorf("cell_size must be between 1 and %d, got %q", render.MaxCellSize, s)
+		}
+		options = append(options, render.WithCellSize(size))
+	}
+	if s := query.Get("alive"); s != "" {
+		alive, err := render.ParseColor(s)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, render.WithAliveColor(alive))
+	}
+	if s := query.Get("dead"); s != "" {
+		dead, err := render.ParseColor(s)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, render.WithDeadColor(dead))
+	}
+	if s := query.Get("delay"); s != "" {
+		delay, err := strconv.Atoi(s)
+		if err != nil || delay < 0 {
+			return nil, fmt.Errorf("delay must be a number of milliseconds, got %q", s)
+		}
+		options = append(options, render.WithFrameDelay(time.Duration(delay)*time.Millisecond))
+	}
+	return options, nil
+}
+
+// gameGenerations Returns the state of every cell of every generation of the game, streamed from the gRPC server
+func gameGenerations(ctx context.Context, body *gameoflifepb.GameRequest) ([]render.Board, error) {
+	// Cancelling the context stops the game on the gRPC server if a generation can't be read
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := gameOfLifeClient.RunGameStream(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	var generations []render.Board
+	for {
+		frame, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return generations, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		cells, err := gameoflife.BoardCells(frame.GetBoard(), frame.GetStructuredBoard(), body.GetFormat())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		generations = append(generations, render.NewBoard(cells))
+	}
+}
+
+// renderGame Writes the image of the final board of the game of the request, or for a GIF the animation of all its
+// generations, which must be at most maxGifFrames. The image is rendered under a RenderBoard span.
+func renderGame(ctx context.Context, w http.ResponseWriter, encoder *json.Encoder, body *gameoflifepb.GameRequest, contentType string, options []render.Option) {
+	span := trace.SpanFromContext(ctx)
+	frames := 1
+	if contentType == responseTypes["gif"] {
+		if int(body.GetNumGens()) >= *maxGifFrames {
+			err := fmt.Errorf("an animation has at most %d generations, got %d", *maxGifFrames, body.GetNumGens()+1)
+			span.RecordError(err)
+			writeError(ctx, w, encoder, http.StatusRequestEntityTooLarge, err, "Request too large error")
+			return
+		}
+		frames = int(body.GetNumGens()) + 1
+	}
+	// The image is as large as the initial board, so it is rejected before the game is run if it is too large.
+	// Invalid boards are rejected by the gRPC server.
+	if rows, cols, err := gameoflife.BoardSize(body); err == nil {
+		if err := render.CheckSize(rows, cols, frames, options...); err != nil {
+			span.RecordError(err)
+			writeError(ctx, w, encoder, http.StatusRequestEntityTooLarge, err, "Image too large error")
+			return
+		}
+	}
+	var generations []render.Board
+	if contentType == responseTypes["gif"] {
+		var err error
+		generations, err = gameGenerations(ctx, body)
+		if err != nil {
+			writeStatusError(ctx, w, encoder, err)
+			return
+		}
+	} else {
+		result, err := run(ctx, body)
+		if err != nil {
+			writeStatusError(ctx, w, encoder, err)
+			return
+		}
+		cells, err := gameoflife.BoardCells(result.GetBoard(), result.GetStructuredBoard(), body.GetFormat())
+		if err != nil {
+			writeError(ctx, w, encoder, http.StatusInternalServerError, err, "Internal server error")
+			return
+		}
+		generations = []render.Board{render.NewBoard(cells)}
+		span.SetAttributes(attribute.Int("rungame_handler.response.final_generation", int(result.GetFinalGeneration())))
+	}
+
+	_, renderSpan := otel.Tracer("game-of-life-webapp").Start(ctx, "RenderBoard")
+	renderSpan.SetAttributes(
+		attribute.String("render_board.content_type", contentType),
+		attribute.Int("render_board.num_frames", len(generations)),
+	)
+	var buf bytes.Buffer
+	var err error
+	switch contentType {
+	case responseTypes["png"]:
+		err = render.PNG(&buf, generations[0], options...)
+	case responseTypes["svg"]:
+		err = render.SVG(&buf, generations[0], options...)
+	default:
+		err = render.GIF(&buf, generations, options...)
+	}
+	if err != nil {
+		renderSpan.RecordError(err)
+	}
+	renderSpan.SetAttributes(attribute.Int("render_board.bytes", buf.Len()))
+	renderSpan.End()
+	if errors.Is(err, render.ErrTooLarge) {
+		writeError(ctx, w, encoder, http.StatusRequestEntityTooLarge, err, "Image too large error")
+		return
+	}
+	if err != nil {
+		writeError(ctx, w, encoder, http.StatusBadRequest, err, "Bad request error")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
 	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+	requestLogger(ctx).Info("Sending result image",
+		zap.Int("httpStatus", http.StatusOK),
+		zap.String("contentType", contentType),
+		zap.Int("numFrames", len(generations)),
+		zap.Int("bytes", buf.Len()),
+	)
+}
 
-	resp := struct {
-		Readiness bool `json:"readiness"`
+// liveFrame is a generation of a game as pushed by the live endpoint
+type liveFrame struct {
+	Generation int32  `json:"generation"`
+	Board      string `json:"board"`
+	Population int32  `json:"population"`
+}
+
+// writeEvent Writes a Server-Sent Event with the given name and data encoded as JSON, and flushes it
+// to the browser right away
+func writeEvent(w http.ResponseWriter, event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	return http.NewResponseController(w).Flush()
+}
+
+// writeErrorEvent Writes the error of a game failing after its first generation as an error event
+func writeErrorEvent(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	writeEvent(w, "error", struct {
+		Error string `json:"error"`
+		Code  string `json:"code"`
 	}{
-		Readiness: true,
+		Error: st.Message(),
+		Code:  st.Code().String(),
+	})
+}
+
+// RunGameLiveHandler Streams every generation of the game request of the body to the browser as Server-Sent Events,
+// as the gRPC server computes them: a generation event for every generation, then a done event, or an error event
+// if the game fails. A game failing before its first generation, such as an invalid one, gets the same error
+// response as from /rungame instead. The endpoint is a POST, read by the browser with fetch rather than EventSource,
+// so that the RUM SDK adds the trace context of the browser session to the request.
+func RunGameLiveHandler(w http.ResponseWriter, r *http.Request) {
+	// Cancelling the context stops the game on the gRPC server, once the browser went away or the handler returns
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	span := trace.SpanFromContext(ctx)
+	liveLogger := requestLogger(ctx)
+
+	var body gameoflifepb.GameRequest
+	encoder := json.NewEncoder(w)
+	if !decodeGameRequest(w, r, encoder, &body) {
+		return
 	}
+	span.SetAttributes(
+		attribute.String("rungamelive_handler.request.board", body.GetBoard()),
+		attribute.Int("rungamelive_handler.request.num_gens", int(body.GetNumGens())),
+		attribute.String("rungamelive_handler.request.rule", body.GetRule()),
+		attribute.String("rungamelive_handler.request.format", body.GetFormat().String()),
+	)
 
-	json.NewEncoder(w).Encode(resp)
+	stream, err := gameOfLifeClient.RunGameStream(ctx, &body)
+	if err != nil {
+		writeStatusError(ctx, w, encoder, err)
+		return
+	}
+	frame, err := stream.Recv()
+	if err != nil {
+		writeStatusError(ctx, w, encoder, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	numFrames := 0
+	defer func() {
+		span.SetAttributes(attribute.Int("rungamelive_handler.response.num_frames", numFrames))
+	}()
+	for {
+		board, err := formatBoard(body.GetFormat(), frame.GetBoard(), frame.GetStructuredBoard())
+		if err != nil {
+			span.RecordError(err)
+			liveLogger.Error("Formatting live board", zap.Error(err))
+			writeErrorEvent(w, err)
+			return
+		}
+		err = writeEvent(w, "generation", liveFrame{
+			Generation: frame.GetGeneration(),
+			Board:      board,
+			Population: frame.GetStats().GetPopulation(),
+		})
+		if err != nil {
+			span.RecordError(err)
+			liveLogger.Warn("Browser went away", zap.Error(err), zap.Int("numFrames", numFrames))
+			return
+		}
+		numFrames++
+
+		frame, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			writeEvent(w, "done", struct {
+				NumFrames int `json:"numFrames"`
+			}{
+				NumFrames: numFrames,
+			})
+			liveLogger.Info("Finished live game", zap.Int("numFrames", numFrames))
+			return
+		}
+		if err != nil {
+			span.RecordError(err)
+			liveLogger.Error("Live game failed", zap.Stringer("grpcCode", status.Code(err)), zap.Error(err))
+			writeErrorEvent(w, err)
+			return
+		}
+	}
 }
 
-func LivenessHandler(w http.ResponseWriter, r *http.Request) {
+// decodeGameRequest Decodes the game request in the body of r into body, and Returns false after writing
+// a 413 if the body is over maxRequestBytes, or a 400 if it is not a game request
+func decodeGameRequest(w http.ResponseWriter, r *http.Request, encoder *json.Encoder, body *gameoflifepb.GameRequest) bool {
+	ctx := r.Context()
+	span := trace.SpanFromContext(ctx)
+	if *maxRequestBytes > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, *maxRequestBytes)
+	}
+	err := json.NewDecoder(r.Body).Decode(body)
+	if err != nil {
+		span.RecordError(err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(ctx, w, encoder, http.StatusRequestEntityTooLarge, err, "Request too large error")
+			return false
+		}
+		writeError(ctx, w, encoder, http.StatusBadRequest, err, "Bad request error")
+		return false
+	}
+	return true
+}
+
+// gameResult is the result of a game as returned by the webapp
+type gameResult struct {
+	ResultBoard string `json:"resultBoard"`
+	// Board is the state of every cell of the final board, whatever the format of the request
+	Board           [][]int           `json:"board"`
+	FinalGeneration int32             `json:"finalGeneration"`
+	Period          int32             `json:"period"`
+	Extinct         bool              `json:"extinct"`
+	Stats           []generationStats `json:"stats,omitempty"`
+}
+
+// generationStats is the statistics of a generation as returned by the webapp
+type generationStats struct {
+	Generation int32 `json:"generation"`
+	Population int32 `json:"population"`
+	Births     int32 `json:"births"`
+	Deaths     int32 `json:"deaths"`
+	// BoundingBox is the smallest rectangle holding all live cells, as [minRow, minCol, maxRow, maxCol],
+	// and is unset if the board is empty
+	BoundingBox []int32 `json:"boundingBox,omitempty"`
+}
+
+// formatBoard Returns a board in the given format, or the structured board if it is set, as returned by the webapp.
+// RLE and plaintext boards are returned as is, and JSON and structured boards as ASCII, read with
+// gameoflife.BoardCells like the cells of the results.
+func formatBoard(format gameoflifepb.BoardFormat, board string, structured *gameoflifepb.Board) (string, error) {
+	if structured == nil && format != gameoflifepb.BoardFormat_JSON {
+		return board, nil
+	}
+	cells, err := gameoflife.BoardCells(board, structured, format)
+	if err != nil {
+		return "", err
+	}
+	return cellsToAscii(cells), nil
+}
+
+// newGameResult Returns the result of a game whose request had the given board format
+func newGameResult(format gameoflifepb.BoardFormat, result *gameoflifepb.GameResponse) (gameResult, error) {
+	ascii, err := formatBoard(format, result.GetBoard(), result.GetStructuredBoard())
+	if err != nil {
+		return gameResult{}, err
+	}
+	cells, err := gameoflife.BoardCells(result.GetBoard(), result.GetStructuredBoard(), format)
+	if err != nil {
+		return gameResult{}, err
+	}
+	var stats []generationStats
+	for _, s := range result.GetStats() {
+		generation := generationStats{
+			Generation: s.GetGeneration(),
+			Population: s.GetPopulation(),
+			Births:     s.GetBirths(),
+			Deaths:     s.GetDeaths(),
+		}
+		if box := s.GetBoundingBox(); box != nil {
+			generation.BoundingBox = []int32{box.GetMinRow(), box.GetMinCol(), box.GetMaxRow(), box.GetMaxCol()}
+		}
+		stats = append(stats, generation)
+	}
+	return gameResult{
+		ResultBoard:     ascii,
+		Board:           cells,
+		FinalGeneration: result.GetFinalGeneration(),
+		Period:          result.GetPeriod(),
+		Extinct:         result.GetExtinct(),
+		Stats:           stats,
+	}, nil
+}
+
+// patternResponse is a built-in pattern as returned by the pattern endpoints
+type patternResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Width       int32  `json:"width"`
+	Height      int32  `json:"height"`
+	RLE         string `json:"rle"`
+}
+
+func newPatternResponse(pattern *gameoflifepb.Pattern) patternResponse {
+	return patternResponse{
+		Name:        pattern.GetName(),
+		Description: pattern.GetDescription(),
+		Width:       pattern.GetBoard().GetWidth(),
+		Height:      pattern.GetBoard().GetHeight(),
+		RLE:         pattern.GetRle(),
+	}
+}
+
+// ListPatternsHandler Returns the built-in patterns of the gRPC server
+func ListPatternsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := trace.SpanFromContext(ctx)
+	encoder := json.NewEncoder(w)
+
+	result, err := gameOfLifeClient.ListPatterns(ctx, &gameoflifepb.ListPatternsRequest{})
+	if err != nil {
+		writeStatusError(ctx, w, encoder, err)
+		return
+	}
+	patterns := make([]patternResponse, 0, len(result.GetPatterns()))
+	for _, pattern := range result.GetPatterns() {
+		patterns = append(patterns, newPatternResponse(pattern))
+	}
+	span.SetAttributes(attribute.Int("listpatterns_handler.response.num_patterns", len(patterns)))
 	w.WriteHeader(http.StatusOK)
+	resp := struct {
+		Patterns []patternResponse `json:"patterns"`
+	}{
+		Patterns: patterns,
+	}
+	encoder.Encode(resp)
+}
+
+// GetPatternHandler Returns the built-in pattern of the gRPC server named in the path
+func GetPatternHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := trace.SpanFromContext(ctx)
+	encoder := json.NewEncoder(w)
+	name := r.PathValue("name")
+	span.SetAttributes(attribute.String("getpattern_handler.request.name", name))
+
+	pattern, err := gameOfLifeClient.GetPattern(ctx, &gameoflifepb.GetPatternRequest{Name: name})
+	if err != nil {
+		writeStatusError(ctx, w, encoder, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(newPatternResponse(pattern))
+}
+
+// jobResponse is a game job of the gRPC server as returned by the job endpoints
+type jobResponse struct {
+	ID         string `json:"id"`
+	State      string `json:"state"`
+	Generation int32  `json:"generation"`
+	// NumGens is the number of generations of the game, unset in lists of jobs
+	NumGens    int32       `json:"numGens,omitempty"`
+	Result     *gameResult `json:"result,omitempty"`
+	Error      string      `json:"error,omitempty"`
+	CreateTime time.Time   `json:"createTime"`
+	FinishTime *time.Time  `json:"finishTime,omitempty"`
+}
+
+// newJobResponse Returns the job with its result if it has one, and an error if its result can't be formatted
+func newJobResponse(job *gameoflifepb.Job) (jobResponse, error) {
+	resp := jobResponse{
+		ID:         job.GetId(),
+		State:      strings.TrimPrefix(job.GetState().String(), "JOB_"),
+		Generation: job.GetGeneration(),
+		NumGens:    job.GetRequest().GetNumGens(),
+		Error:      job.GetErrorMessage(),
+		CreateTime: job.GetCreateTime().AsTime(),
+	}
+	if job.GetFinishTime() != nil {
+		finishTime := job.GetFinishTime().AsTime()
+		resp.FinishTime = &finishTime
+	}
+	if job.GetResult() != nil {
+		result, err := newGameResult(job.GetRequest().GetFormat(), job.GetResult())
+		if err != nil {
+			return jobResponse{}, err
+		}
+		resp.Result = &result
+	}
+	return resp, nil
+}
+
+// writeJob Writes the job with the given HTTP status code
+func writeJob(ctx context.Context, w http.ResponseWriter, encoder *json.Encoder, code int, job *gameoflifepb.Job) {
+	resp, err := newJobResponse(job)
+	if err != nil {
+		writeError(ctx, w, encoder, http.StatusInternalServerError, err, "Internal server error")
+		return
+	}
+	w.WriteHeader(code)
+	encoder.Encode(resp)
+}
 
+// SubmitJobHandler Submits the game request of the body as a job of the gRPC server, and Returns the pending job
+func SubmitJobHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := trace.SpanFromContext(ctx)
+	var body gameoflifepb.GameRequest
+	encoder := json.NewEncoder(w)
+	if !decodeGameRequest(w, r, encoder, &body) {
+		return
+	}
+	span.SetAttributes(attribute.Int("submitjob_handler.request.num_gens", int(body.GetNumGens())))
+
+	job, err := gameOfLifeClient.SubmitGame(ctx, &body)
+	if err != nil {
+		writeStatusError(ctx, w, encoder, err)
+		return
+	}
+	span.SetAttributes(attribute.String("submitjob_handler.response.id", job.GetId()))
+	w.Header().Set("Location", "/jobs/"+job.GetId())
+	writeJob(ctx, w, encoder, http.StatusAccepted, job)
+}
+
+// ListJobsHandler Returns the jobs of the gRPC server, without their results
+func ListJobsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := trace.SpanFromContext(ctx)
+	encoder := json.NewEncoder(w)
+
+	result, err := gameOfLifeClient.ListJobs(ctx, &gameoflifepb.ListJobsRequest{})
+	if err != nil {
+		writeStatusError(ctx, w, encoder, err)
+		return
+	}
+	jobs := make([]jobResponse, 0, len(result.GetJobs()))
+	for _, job := range result.GetJobs() {
+		resp, err := newJobResponse(job)
+		if err != nil {
+			writeError(ctx, w, encoder, http.StatusInternalServerError, err, "Internal server error")
+			return
+		}
+		jobs = append(jobs, resp)
+	}
+	span.SetAttributes(attribute.Int("listjobs_handler.response.num_jobs", len(jobs)))
+	w.WriteHeader(http.StatusOK)
 	resp := struct {
-		Liveness bool `json:"liveness"`
+		Jobs []jobResponse `json:"jobs"`
 	}{
-		Liveness: true,
+		Jobs: jobs,
+	}
+	encoder.Encode(resp)
+}
+
+// GetJobHandler Returns the job of the gRPC server with the id in the path, with its result once it is done
+func GetJobHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := trace.SpanFromContext(ctx)
+	encoder := json.NewEncoder(w)
+	id := r.PathValue("id")
+	span.SetAttributes(attribute.String("getjob_handler.request.id", id))
+
+	job, err := gameOfLifeClient.GetJob(ctx, &gameoflifepb.GetJobRequest{Id: id})
+	if err != nil {
+		writeStatusError(ctx, w, encoder, err)
+		return
+	}
+	writeJob(ctx, w, encoder, http.StatusOK, job)
+}
+
+// CancelJobHandler Cancels the job of the gRPC server with the id in the path
+func CancelJobHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := trace.SpanFromContext(ctx)
+	encoder := json.NewEncoder(w)
+	id := r.PathValue("id")
+	span.SetAttributes(attribute.String("canceljob_handler.request.id", id))
+
+	job, err := gameOfLifeClient.CancelJob(ctx, &gameoflifepb.CancelJobRequest{Id: id})
+	if err != nil {
+		writeStatusError(ctx, w, encoder, err)
+		return
+	}
+	writeJob(ctx, w, encoder, http.StatusOK, job)
+}
+
+// sessionResponse is a game session of the gRPC server as returned by the session endpoints
+type sessionResponse struct {
+	ID         string    `json:"id"`
+	Generation int32     `json:"generation"`
+	Board      string    `json:"board"`
+	Rule       string    `json:"rule"`
+	Extinct    bool      `json:"extinct"`
+	CreateTime time.Time `json:"createTime"`
+	UpdateTime time.Time `json:"updateTime"`
+}
+
+// writeSession Writes the session with the given HTTP status code
+func writeSession(ctx context.Context, w http.ResponseWriter, encoder *json.Encoder, code int, session *gameoflifepb.Session) {
+	board, err := formatBoard(session.GetGame().GetFormat(), session.GetGame().GetBoard(), session.GetGame().GetStructuredBoard())
+	if err != nil {
+		writeError(ctx, w, encoder, http.StatusInternalServerError, err, "Internal server error")
+		return
+	}
+	w.WriteHeader(code)
+	encoder.Encode(sessionResponse{
+		ID:         session.GetId(),
+		Generation: session.GetGeneration(),
+		Board:      board,
+		Rule:       session.GetGame().GetRule(),
+		Extinct:    session.GetExtinct(),
+		CreateTime: session.GetCreateTime().AsTime(),
+		UpdateTime: session.GetUpdateTime().AsTime(),
+	})
+}
+
+// CreateSessionHandler Creates a session of the gRPC server holding the board of the game request of the body
+func CreateSessionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := trace.SpanFromContext(ctx)
+	var body gameoflifepb.GameRequest
+	encoder := json.NewEncoder(w)
+	if !decodeGameRequest(w, r, encoder, &body) {
+		return
 	}
 
-	json.NewEncoder(w).Encode(resp)
+	session, err := gameOfLifeClient.CreateSession(ctx, &gameoflifepb.CreateSessionRequest{Game: &body})
+	if err != nil {
+		writeStatusError(ctx, w, encoder, err)
+		return
+	}
+	span.SetAttributes(attribute.String("createsession_handler.response.id", session.GetId()))
+	w.Header().Set("Location", "/sessions/"+session.GetId())
+	writeSession(ctx, w, encoder, http.StatusCreated, session)
+}
+
+// GetSessionHandler Returns the session of the gRPC server with the id in the path, with its current board
+func GetSessionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := trace.SpanFromContext(ctx)
+	encoder := json.NewEncoder(w)
+	id := r.PathValue("id")
+	span.SetAttributes(attribute.String("getsession_handler.request.id", id))
+
+	session, err := gameOfLifeClient.GetSession(ctx, &gameoflifepb.GetSessionRequest{SessionId: id})
+	if err != nil {
+		writeStatusError(ctx, w, encoder, err)
+		return
+	}
+	writeSession(ctx, w, encoder, http.StatusOK, session)
+}
+
+// StepSessionHandler Advances the session of the gRPC server with the id in the path by the num_gens
+// of the body, or by one generation if the body is empty
+func StepSessionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := trace.SpanFromContext(ctx)
+	encoder := json.NewEncoder(w)
+	id := r.PathValue("id")
+
+	var body struct {
+		NumGens int32 `json:"num_gens"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		span.RecordError(err)
+		writeError(ctx, w, encoder, http.StatusBadRequest, err, "Bad request error")
+		return
+	}
+	span.SetAttributes(
+		attribute.String("stepsession_handler.request.id", id),
+		attribute.Int("stepsession_handler.request.num_gens", int(body.NumGens)),
+	)
+
+	session, err := gameOfLifeClient.Step(ctx, &gameoflifepb.StepRequest{SessionId: id, NumGens: body.NumGens})
+	if err != nil {
+		writeStatusError(ctx, w, encoder, err)
+		return
+	}
+	span.SetAttributes(attribute.Int("stepsession_handler.response.generation", int(session.GetGeneration())))
+	writeSession(ctx, w, encoder, http.StatusOK, session)
+}
+
+// DeleteSessionHandler Deletes the session of the gRPC server with the id in the path
+func DeleteSessionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := trace.SpanFromContext(ctx)
+	encoder := json.NewEncoder(w)
+	id := r.PathValue("id")
+	span.SetAttributes(attribute.String("deletesession_handler.request.id", id))
+
+	if _, err := gameOfLifeClient.DeleteSession(ctx, &gameoflifepb.DeleteSessionRequest{SessionId: id}); err != nil {
+		writeStatusError(ctx, w, encoder, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ReadinessHandler Returns the report of the health checks, with a 503 if any of them fails
+func ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	health.WriteReport(w, "readiness", healthChecks.Readiness(r.Context()))
+}
+
+// LivenessHandler Returns the report of the liveness checks, with a 503 if any of them fails
+func LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	health.WriteReport(w, "liveness", healthChecks.Liveness(r.Context()))
 }
@@ -1,24 +1,38 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/client"
+	"github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/game-of-life-otel/health"
 	gameoflifepb "github.com/DataDog/opentelemetry-examples/apps/game-of-life/go/pb"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func gameRequestToJSONAPI(board string, numGens int32) string {
@@ -126,6 +140,17 @@ func TestRunGameTrace(t *testing.T) {
 	assert.Len(t, span.Events, 0)
 
 	checkLogFields(t, logs, span)
+
+	// The logs of the next request only have the trace and span ids of its own span
+	logs.TakeAll()
+	exporter.Reset()
+	grpcClient.EXPECT().RunGame(gomock.Any(), gomock.Any(), gomock.Any()).Return(&gameoflifepb.GameResponse{
+		Code:  gameoflifepb.ResponseCode_OK,
+		Board: "[[1,1],[1,1]]",
+	}, nil)
+	_, spans = sendRequest(gameRequestToJSONAPI(gameRequest.Board, gameRequest.NumGens), exporter)
+	assert.NotEqual(t, span.SpanContext.TraceID(), spans[0].SpanContext.TraceID())
+	checkLogFields(t, logs, spans[0])
 }
 
 func TestRunGameDecodeErrorTrace(t *testing.T) {
@@ -202,3 +227,671 @@ func TestRunGameInternalErrorTrace(t *testing.T) {
 
 	checkLogFields(t, logs, span)
 }
+
+func TestRunGameInvalidResult(t *testing.T) {
+	exporter, grpcClient, _ := setupWebapp(t)
+
+	// A result board that the webapp can't read is an error of the server, not of the request
+	grpcClient.EXPECT().RunGame(gomock.Any(), gomock.Any(), gomock.Any()).Return(&gameoflifepb.GameResponse{
+		Code:  gameoflifepb.ResponseCode_OK,
+		Board: "[[1,1],",
+	}, nil)
+
+	wr, _ := sendRequest(gameRequestToJSONAPI("[[1,1],[1,0]]", 1), exporter)
+	assert.Equal(t, http.StatusInternalServerError, wr.Result().StatusCode)
+	assert.Equal(t, "application/problem+json", wr.Result().Header.Get("Content-Type"))
+}
+
+func TestRunGameFormat(t *testing.T) {
+	exporter, grpcClient, _ := setupWebapp(t)
+
+	resultBoard := "x = 3, y = 3, rule = B3/S23\n$3o!\n"
+	grpcClient.EXPECT().RunGame(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, in *gameoflifepb.GameRequest, opts ...grpc.CallOption) (*gameoflifepb.GameResponse, error) {
+			assert.Equal(t, gameoflifepb.BoardFormat_RLE, in.Format)
+			assert.Equal(t, "x = 3, y = 3\nbo$bo$bo!", in.Board)
+			return &gameoflifepb.GameResponse{
+				Code:  gameoflifepb.ResponseCode_OK,
+				Board: resultBoard,
+			}, nil
+		})
+
+	wr, spans := sendRequest(`{"board":"x = 3, y = 3\nbo$bo$bo!", "num_gens":1, "format":1}`, exporter)
+	assert.Equal(t, http.StatusOK, wr.Result().StatusCode)
+	var resp struct {
+		ResultBoard string `json:"resultBoard"`
+	}
+	assert.NoError(t, json.NewDecoder(wr.Body).Decode(&resp))
+	// The board is returned in the format of the request instead of as ASCII
+	assert.Equal(t, resultBoard, resp.ResultBoard)
+	assert.Contains(t, spans[0].Attributes, attribute.String("rungame_handler.request.format", "RLE"))
+}
+
+func TestRunGameContentNegotiation(t *testing.T) {
+	result := &gameoflifepb.GameResponse{
+		Board:           "[[0,0,0],[1,1,1],[0,0,0]]",
+		FinalGeneration: 1,
+		Period:          2,
+		Stats: []*gameoflifepb.GenerationStats{
+			{Generation: 0, Population: 3, BoundingBox: &gameoflifepb.BoundingBox{MaxRow: 2, MinCol: 1, MaxCol: 1}},
+			{Generation: 1, Population: 3, Births: 2, Deaths: 2, BoundingBox: &gameoflifepb.BoundingBox{MinRow: 1, MaxRow: 1, MaxCol: 2}},
+		},
+	}
+	var tests = []struct {
+		query       string
+		accept      string
+		contentType string
+		body        string
+	}{
+		{"", "", "application/json", ""},
+		{"", "*/*", "application/json", ""},
+		{"", "text/plain", "text/plain; charset=utf-8", "0 0 0\n1 1 1\n0 0 0\n"},
+		{"", "application/x-life-rle", "application/x-life-rle; charset=utf-8", "x = 3, y = 3, rule = B36/S23\n$3o!\n"},
+		{"", "application/json;q=0.5, text/*", "text/plain; charset=utf-8", "0 0 0\n1 1 1\n0 0 0\n"},
+		{"", "text/html, application/x-life-rle;q=0.8, application/json;q=0.8", "application/x-life-rle; charset=utf-8", "x = 3, y = 3, rule = B36/S23\n$3o!\n"},
+		{"?format=text", "application/json", "text/plain; charset=utf-8", "0 0 0\n1 1 1\n0 0 0\n"},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%q,%q", tt.query, tt.accept)
+		t.Run(testname, func(t *testing.T) {
+			exporter, grpcClient, _ := setupWebapp(t)
+			grpcClient.EXPECT().RunGame(gomock.Any(), gomock.Any(), gomock.Any()).Return(result, nil)
+
+			wr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/rungame"+tt.query, strings.NewReader(`{"board":"[[0,1,0],[0,1,0],[0,1,0]]", "num_gens":1, "rule":"B36/S23"}`))
+			req.Header.Set("Accept", tt.accept)
+			SetupHandlers().ServeHTTP(wr, req)
+			assert.Equal(t, http.StatusOK, wr.Code)
+			assert.Equal(t, tt.contentType, wr.Result().Header.Get("Content-Type"))
+			assert.Equal(t, "Accept", wr.Result().Header.Get("Vary"))
+			spans := exporter.GetSpans()
+			if assert.Len(t, spans, 1) {
+				assert.Contains(t, spans[0].Attributes, attribute.String("rungame_handler.response.content_type", strings.Split(tt.contentType, ";")[0]))
+			}
+			if tt.body != "" {
+				assert.Equal(t, tt.body, wr.Body.String())
+				return
+			}
+
+			// The JSON result holds the board as a matrix and the stats of every generation
+			var resp gameResult
+			assert.NoError(t, json.NewDecoder(wr.Body).Decode(&resp))
+			assert.Equal(t, gameResult{
+				ResultBoard:     "[0 0 0] \n [1 1 1] \n [0 0 0] \n ",
+				Board:           [][]int{{0, 0, 0}, {1, 1, 1}, {0, 0, 0}},
+				FinalGeneration: 1,
+				Period:          2,
+				Stats: []generationStats{
+					{Generation: 0, Population: 3, BoundingBox: []int32{0, 1, 2, 1}},
+					{Generation: 1, Population: 3, Births: 2, Deaths: 2, BoundingBox: []int32{1, 0, 1, 2}},
+				},
+			}, resp)
+		})
+	}
+}
+
+func TestRunGameNotAcceptable(t *testing.T) {
+	exporter, _, _ := setupWebapp(t)
+
+	wr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/rungame", strings.NewReader(gameRequestToJSONAPI("[[1]]", 1)))
+	req.Header.Set("Accept", "text/html, application/xml;q=0.9")
+	SetupHandlers().ServeHTTP(wr, req)
+	assert.Equal(t, http.StatusNotAcceptable, wr.Code)
+	assert.Equal(t, "application/problem+json", wr.Result().Header.Get("Content-Type"))
+	var resp problem
+	assert.NoError(t, json.NewDecoder(wr.Body).Decode(&resp))
+	assert.Equal(t, "Not Acceptable", resp.Title)
+	assert.Equal(t, http.StatusNotAcceptable, resp.Status)
+	assert.Contains(t, resp.Detail, "text/html")
+	assert.Len(t, exporter.GetSpans(), 1)
+}
+
+func TestRunGameInvalidArgument(t *testing.T) {
+	exporter, grpcClient, _ := setupWebapp(t)
+
+	st, err := status.New(codes.InvalidArgument, "board[1][1]: cells can only be 0's or 1's").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "board[1][1]", Description: "cells can only be 0's or 1's, cell (1, 1) is 2"},
+		},
+	})
+	assert.NoError(t, err)
+	grpcClient.EXPECT().RunGame(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, st.Err())
+
+	wr, _ := sendRequest(gameRequestToJSONAPI("[[1,1],[1,2]]", 1), exporter)
+	assert.Equal(t, http.StatusBadRequest, wr.Result().StatusCode)
+	assert.Equal(t, "application/problem+json", wr.Result().Header.Get("Content-Type"))
+	var resp problem
+	assert.NoError(t, json.NewDecoder(wr.Body).Decode(&resp))
+	assert.Equal(t, problem{
+		Type:       "about:blank",
+		Title:      "Bad Request",
+		Status:     http.StatusBadRequest,
+		Detail:     st.Message(),
+		Violations: []fieldViolation{{Field: "board[1][1]", Description: "cells can only be 0's or 1's, cell (1, 1) is 2"}},
+	}, resp)
+}
+
+func TestRunGameRequestTooLarge(t *testing.T) {
+	exporter, _, _ := setupWebapp(t)
+
+	board := "[" + strings.Repeat("[0,1,0],", 200_000) + "[0,1,0]]"
+	wr, _ := sendRequest(gameRequestToJSONAPI(board, 1), exporter)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, wr.Result().StatusCode)
+}
+
+func TestResourceExhausted(t *testing.T) {
+	tests := []struct {
+		subject    string
+		code       int
+		retryAfter string
+	}{
+		{"rows", http.StatusRequestEntityTooLarge, ""},
+		{"request_bytes", http.StatusRequestEntityTooLarge, ""},
+		{"jobs", http.StatusServiceUnavailable, storeFullRetryAfter},
+		{"sessions", http.StatusServiceUnavailable, storeFullRetryAfter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			_, grpcClient, _ := setupWebapp(t)
+			st, err := status.New(codes.ResourceExhausted, "too many "+tt.subject).WithDetails(&errdetails.QuotaFailure{
+				Violations: []*errdetails.QuotaFailure_Violation{{Subject: tt.subject, Description: "too many " + tt.subject}},
+			})
+			assert.NoError(t, err)
+			grpcClient.EXPECT().SubmitGame(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, st.Err())
+
+			wr := httptest.NewRecorder()
+			SetupHandlers().ServeHTTP(wr, httptest.NewRequest(http.MethodPost, "/jobs", strings.NewReader(gameRequestToJSONAPI("[[1]]", 1))))
+			assert.Equal(t, tt.code, wr.Result().StatusCode)
+			assert.Equal(t, tt.retryAfter, wr.Result().Header.Get("Retry-After"))
+			var resp problem
+			assert.NoError(t, json.NewDecoder(wr.Body).Decode(&resp))
+			assert.Equal(t, []fieldViolation{{Field: tt.subject, Description: "too many " + tt.subject}}, resp.Violations)
+		})
+	}
+}
+
+func TestStatusErrorCodes(t *testing.T) {
+	tests := []struct {
+		code     codes.Code
+		expected int
+	}{
+		{codes.InvalidArgument, http.StatusBadRequest},
+		{codes.ResourceExhausted, http.StatusRequestEntityTooLarge},
+		{codes.NotFound, http.StatusNotFound},
+		{codes.DeadlineExceeded, http.StatusGatewayTimeout},
+		{codes.Canceled, statusClientClosedRequest},
+		{codes.Unavailable, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			_, grpcClient, _ := setupWebapp(t)
+			grpcClient.EXPECT().GetJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, status.Error(tt.code, "failed"))
+
+			wr := httptest.NewRecorder()
+			SetupHandlers().ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/jobs/0123456789abcdef", nil))
+			assert.Equal(t, tt.expected, wr.Result().StatusCode)
+		})
+	}
+}
+
+func TestPatternHandlers(t *testing.T) {
+	_, grpcClient, _ := setupWebapp(t)
+
+	pattern := &gameoflifepb.Pattern{
+		Name:        "blinker",
+		Description: "Period 2 oscillator",
+		Rle:         "x = 3, y = 1, rule = B3/S23\n3o!\n",
+		Board:       &gameoflifepb.Board{Width: 3, Height: 1},
+	}
+	grpcClient.EXPECT().ListPatterns(gomock.Any(), gomock.Any(), gomock.Any()).Return(&gameoflifepb.ListPatternsResponse{
+		Patterns: []*gameoflifepb.Pattern{pattern},
+	}, nil)
+	grpcClient.EXPECT().GetPattern(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, in *gameoflifepb.GetPatternRequest, opts ...grpc.CallOption) (*gameoflifepb.Pattern, error) {
+			if in.Name != "blinker" {
+				return nil, status.Errorf(codes.NotFound, "unknown pattern %q", in.Name)
+			}
+			return pattern, nil
+		}).Times(2)
+
+	expected := patternResponse{Name: "blinker", Description: "Period 2 oscillator", Width: 3, Height: 1, RLE: pattern.Rle}
+	handler := SetupHandlers()
+
+	wr := httptest.NewRecorder()
+	handler.ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/patterns", nil))
+	assert.Equal(t, http.StatusOK, wr.Result().StatusCode)
+	var list struct {
+		Patterns []patternResponse `json:"patterns"`
+	}
+	assert.NoError(t, json.NewDecoder(wr.Body).Decode(&list))
+	assert.Equal(t, []patternResponse{expected}, list.Patterns)
+
+	wr = httptest.NewRecorder()
+	handler.ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/patterns/blinker", nil))
+	assert.Equal(t, http.StatusOK, wr.Result().StatusCode)
+	var got patternResponse
+	assert.NoError(t, json.NewDecoder(wr.Body).Decode(&got))
+	assert.Equal(t, expected, got)
+
+	wr = httptest.NewRecorder()
+	handler.ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/patterns/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, wr.Result().StatusCode)
+}
+
+func TestBoardToAscii(t *testing.T) {
+	setupWebapp(t)
+	var tests = []struct {
+		board    string
+		expected string
+	}{
+		{"[[1,1],[1,0]]", "[1 1] \n [1 0] \n "},
+		// Multi-state cells are padded so that the columns line up
+		{"[[0,12,1],[2,0,0]]", "[ 0 12  1] \n [ 2  0  0] \n "},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%+v", &tt)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := boardToAscii(tt.board)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ans)
+		})
+	}
+}
+
+func TestJobHandlers(t *testing.T) {
+	_, grpcClient, _ := setupWebapp(t)
+
+	createTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	job := &gameoflifepb.Job{
+		Id:         "0123456789abcdef",
+		State:      gameoflifepb.JobState_JOB_SUCCEEDED,
+		Request:    &gameoflifepb.GameRequest{Board: "[[0,1,0],[0,1,0],[0,1,0]]", NumGens: 1},
+		Generation: 1,
+		Result:     &gameoflifepb.GameResponse{Board: "[[0,0,0],[1,1,1],[0,0,0]]", FinalGeneration: 1},
+		CreateTime: timestamppb.New(createTime),
+		FinishTime: timestamppb.New(createTime.Add(time.Second)),
+	}
+	pending := &gameoflifepb.Job{Id: job.Id, Request: job.Request, CreateTime: job.CreateTime}
+	grpcClient.EXPECT().SubmitGame(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, in *gameoflifepb.GameRequest, opts ...grpc.CallOption) (*gameoflifepb.Job, error) {
+			assert.Equal(t, job.Request.Board, in.Board)
+			return pending, nil
+		})
+	grpcClient.EXPECT().GetJob(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, in *gameoflifepb.GetJobRequest, opts ...grpc.CallOption) (*gameoflifepb.Job, error) {
+			if in.Id != job.Id {
+				return nil, status.Errorf(codes.NotFound, "unknown job %q", in.Id)
+			}
+			return job, nil
+		}).Times(2)
+	grpcClient.EXPECT().CancelJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(job, nil)
+	grpcClient.EXPECT().ListJobs(gomock.Any(), gomock.Any(), gomock.Any()).Return(&gameoflifepb.ListJobsResponse{
+		Jobs: []*gameoflifepb.Job{{Id: job.Id, State: job.State, Generation: 1, CreateTime: job.CreateTime}},
+	}, nil)
+	handler := SetupHandlers()
+
+	wr := httptest.NewRecorder()
+	handler.ServeHTTP(wr, httptest.NewRequest(http.MethodPost, "/jobs", strings.NewReader(gameRequestToJSONAPI(job.Request.Board, 1))))
+	assert.Equal(t, http.StatusAccepted, wr.Result().StatusCode)
+	assert.Equal(t, "/jobs/"+job.Id, wr.Result().Header.Get("Location"))
+	var got jobResponse
+	assert.NoError(t, json.NewDecoder(wr.Body).Decode(&got))
+	assert.Equal(t, jobResponse{ID: job.Id, State: "PENDING", NumGens: 1, CreateTime: createTime}, got)
+
+	finishTime := createTime.Add(time.Second)
+	expected := jobResponse{
+		ID:         job.Id,
+		State:      "SUCCEEDED",
+		Generation: 1,
+		NumGens:    1,
+		Result: &gameResult{
+			ResultBoard:     "[0 0 0] \n [1 1 1] \n [0 0 0] \n ",
+			Board:           [][]int{{0, 0, 0}, {1, 1, 1}, {0, 0, 0}},
+			FinalGeneration: 1,
+		},
+		CreateTime: createTime,
+		FinishTime: &finishTime,
+	}
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/jobs/"+job.Id, nil),
+		httptest.NewRequest(http.MethodPost, "/jobs/"+job.Id+"/cancel", nil),
+	} {
+		wr = httptest.NewRecorder()
+		handler.ServeHTTP(wr, req)
+		assert.Equal(t, http.StatusOK, wr.Result().StatusCode)
+		got = jobResponse{}
+		assert.NoError(t, json.NewDecoder(wr.Body).Decode(&got))
+		assert.Equal(t, expected, got)
+	}
+
+	wr = httptest.NewRecorder()
+	handler.ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/jobs", nil))
+	assert.Equal(t, http.StatusOK, wr.Result().StatusCode)
+	var list struct {
+		Jobs []jobResponse `json:"jobs"`
+	}
+	assert.NoError(t, json.NewDecoder(wr.Body).Decode(&list))
+	assert.Equal(t, []jobResponse{{ID: job.Id, State: "SUCCEEDED", Generation: 1, CreateTime: createTime}}, list.Jobs)
+
+	wr = httptest.NewRecorder()
+	handler.ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/jobs/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, wr.Result().StatusCode)
+}
+
+func TestSessionHandlers(t *testing.T) {
+	_, grpcClient, _ := setupWebapp(t)
+
+	createTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	session := &gameoflifepb.Session{
+		Id:         "0123456789abcdef",
+		Game:       &gameoflifepb.GameRequest{Board: "[[0,1,0],[0,1,0],[0,1,0]]", Rule: "B3/S23"},
+		CreateTime: timestamppb.New(createTime),
+		UpdateTime: timestamppb.New(createTime),
+	}
+	stepped := &gameoflifepb.Session{
+		Id:         session.Id,
+		Generation: 2,
+		Game:       &gameoflifepb.GameRequest{Board: "[[0,0,0],[1,1,1],[0,0,0]]", Rule: "B3/S23"},
+		CreateTime: session.CreateTime,
+		UpdateTime: timestamppb.New(createTime.Add(time.Second)),
+	}
+	grpcClient.EXPECT().CreateSession(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, in *gameoflifepb.CreateSessionRequest, opts ...grpc.CallOption) (*gameoflifepb.Session, error) {
+			assert.Equal(t, session.Game.Board, in.Game.GetBoard())
+			return session, nil
+		})
+	var stepGens []int32
+	grpcClient.EXPECT().Step(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, in *gameoflifepb.StepRequest, opts ...grpc.CallOption) (*gameoflifepb.Session, error) {
+			assert.Equal(t, session.Id, in.SessionId)
+			stepGens = append(stepGens, in.NumGens)
+			return stepped, nil
+		}).Times(2)
+	grpcClient.EXPECT().GetSession(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, in *gameoflifepb.GetSessionRequest, opts ...grpc.CallOption) (*gameoflifepb.Session, error) {
+			if in.SessionId != session.Id {
+				return nil, status.Errorf(codes.NotFound, "unknown session %q", in.SessionId)
+			}
+			return stepped, nil
+		}).Times(2)
+	grpcClient.EXPECT().DeleteSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(&emptypb.Empty{}, nil)
+	handler := SetupHandlers()
+
+	wr := httptest.NewRecorder()
+	handler.ServeHTTP(wr, httptest.NewRequest(http.MethodPost, "/sessions", strings.NewReader(gameRequestToJSONAPI(session.Game.Board, 0))))
+	assert.Equal(t, http.StatusCreated, wr.Result().StatusCode)
+	assert.Equal(t, "/sessions/"+session.Id, wr.Result().Header.Get("Location"))
+	var got sessionResponse
+	assert.NoError(t, json.NewDecoder(wr.Body).Decode(&got))
+	assert.Equal(t, sessionResponse{
+		ID:         session.Id,
+		Board:      "[0 1 0] \n [0 1 0] \n [0 1 0] \n ",
+		Rule:       "B3/S23",
+		CreateTime: createTime,
+		UpdateTime: createTime,
+	}, got)
+
+	// The number of generations of a step is optional
+	expected := sessionResponse{
+		ID:         session.Id,
+		Generation: 2,
+		Board:      "[0 0 0] \n [1 1 1] \n [0 0 0] \n ",
+		Rule:       "B3/S23",
+		CreateTime: createTime,
+		UpdateTime: createTime.Add(time.Second),
+	}
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/sessions/"+session.Id+"/step", nil),
+		httptest.NewRequest(http.MethodPost, "/sessions/"+session.Id+"/step", strings.NewReader(`{"num_gens":3}`)),
+		httptest.NewRequest(http.MethodGet, "/sessions/"+session.Id, nil),
+	} {
+		wr = httptest.NewRecorder()
+		handler.ServeHTTP(wr, req)
+		assert.Equal(t, http.StatusOK, wr.Result().StatusCode)
+		got = sessionResponse{}
+		assert.NoError(t, json.NewDecoder(wr.Body).Decode(&got))
+		assert.Equal(t, expected, got)
+	}
+	assert.Equal(t, []int32{0, 3}, stepGens)
+
+	wr = httptest.NewRecorder()
+	handler.ServeHTTP(wr, httptest.NewRequest(http.MethodPost, "/sessions/"+session.Id+"/step", strings.NewReader(`{"num_gens":"x"}`)))
+	assert.Equal(t, http.StatusBadRequest, wr.Result().StatusCode)
+
+	wr = httptest.NewRecorder()
+	handler.ServeHTTP(wr, httptest.NewRequest(http.MethodDelete, "/sessions/"+session.Id, nil))
+	assert.Equal(t, http.StatusNoContent, wr.Result().StatusCode)
+
+	wr = httptest.NewRecorder()
+	handler.ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/sessions/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, wr.Result().StatusCode)
+}
+
+func TestSessionStructuredBoard(t *testing.T) {
+	_, grpcClient, _ := setupWebapp(t)
+	// The session of a game on a structured board holds the board in the same form
+	board := &gameoflifepb.Board{Width: 3, Height: 2, LiveCells: []*gameoflifepb.Cell{{Row: 0, Col: 1}, {Row: 1, Col: 2}}}
+	grpcClient.EXPECT().GetSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(&gameoflifepb.Session{
+		Id:   "0123456789abcdef",
+		Game: &gameoflifepb.GameRequest{StructuredBoard: board},
+	}, nil)
+
+	wr := httptest.NewRecorder()
+	SetupHandlers().ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/sessions/0123456789abcdef", nil))
+	assert.Equal(t, http.StatusOK, wr.Result().StatusCode)
+	var got sessionResponse
+	assert.NoError(t, json.NewDecoder(wr.Body).Decode(&got))
+	assert.Equal(t, "[0 1 0] \n [0 0 1] \n ", got.Board)
+}
+
+// fakeGameStream is a stream of the given frames, ending with err
+type fakeGameStream struct {
+	grpc.ClientStream
+	frames []*gameoflifepb.GenerationFrame
+	err    error
+}
+
+func (s *fakeGameStream) Recv() (*gameoflifepb.GenerationFrame, error) {
+	if len(s.frames) == 0 {
+		return nil, s.err
+	}
+	frame := s.frames[0]
+	s.frames = s.frames[1:]
+	return frame, nil
+}
+
+func TestRunGameLiveHandler(t *testing.T) {
+	frames := []*gameoflifepb.GenerationFrame{
+		{Generation: 0, Board: "[[0,1,0],[0,1,0],[0,1,0]]", Stats: &gameoflifepb.GenerationStats{Population: 3}},
+		{Generation: 1, Board: "[[0,0,0],[1,1,1],[0,0,0]]", Stats: &gameoflifepb.GenerationStats{Generation: 1, Population: 3}},
+	}
+	var tests = []struct {
+		err  error
+		code int
+		body string
+	}{
+		{io.EOF, http.StatusOK, "event: generation\ndata: {\"generation\":0,\"board\":\"[0 1 0] \\n [0 1 0] \\n [0 1 0] \\n \",\"population\":3}\n\n" +
+			"event: generation\ndata: {\"generation\":1,\"board\":\"[0 0 0] \\n [1 1 1] \\n [0 0 0] \\n \",\"population\":3}\n\n" +
+			"event: done\ndata: {\"numFrames\":2}\n\n"},
+		{status.Error(codes.Canceled, "context canceled"), http.StatusOK, "event: generation\ndata: {\"generation\":0,\"board\":\"[0 1 0] \\n [0 1 0] \\n [0 1 0] \\n \",\"population\":3}\n\n" +
+			"event: generation\ndata: {\"generation\":1,\"board\":\"[0 0 0] \\n [1 1 1] \\n [0 0 0] \\n \",\"population\":3}\n\n" +
+			"event: error\ndata: {\"error\":\"context canceled\",\"code\":\"Canceled\"}\n\n"},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v", tt.err)
+		t.Run(testname, func(t *testing.T) {
+			exporter, grpcClient, _ := setupWebapp(t)
+			grpcClient.EXPECT().RunGameStream(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+				&fakeGameStream{frames: slices.Clone(frames), err: tt.err}, nil)
+
+			wr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/rungame/live", strings.NewReader(gameRequestToJSONAPI(frames[0].Board, 1)))
+			SetupHandlers().ServeHTTP(wr, req)
+			if wr.Code != tt.code || wr.Body.String() != tt.body {
+				t.Errorf("Got %v %q, expected %v %q", wr.Code, wr.Body.String(), tt.code, tt.body)
+			}
+			assert.Equal(t, "text/event-stream", wr.Result().Header.Get("Content-Type"))
+			spans := exporter.GetSpans()
+			if assert.Len(t, spans, 1) {
+				assert.Contains(t, spans[0].Attributes, attribute.Int("rungamelive_handler.response.num_frames", 2))
+			}
+		})
+	}
+}
+
+func TestRunGameLiveHandlerStructuredBoard(t *testing.T) {
+	_, grpcClient, _ := setupWebapp(t)
+	// The frames of a game on a structured board hold the board in the same form
+	board := &gameoflifepb.Board{Width: 3, Height: 2, LiveCells: []*gameoflifepb.Cell{{Row: 0, Col: 1}, {Row: 1, Col: 2}}}
+	grpcClient.EXPECT().RunGameStream(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		&fakeGameStream{frames: []*gameoflifepb.GenerationFrame{
+			{Generation: 0, StructuredBoard: board, Stats: &gameoflifepb.GenerationStats{Population: 2}},
+		}, err: io.EOF}, nil)
+
+	wr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/rungame/live", strings.NewReader(`{"structured_board":{"width":3,"height":2,"live_cells":[{"col":1},{"row":1,"col":2}]},"num_gens":1}`))
+	SetupHandlers().ServeHTTP(wr, req)
+	expected := "event: generation\ndata: {\"generation\":0,\"board\":\"[0 1 0] \\n [0 0 1] \\n \",\"population\":2}\n\n" +
+		"event: done\ndata: {\"numFrames\":1}\n\n"
+	if wr.Code != http.StatusOK || wr.Body.String() != expected {
+		t.Errorf("Got %v %q, expected %v %q", wr.Code, wr.Body.String(), http.StatusOK, expected)
+	}
+}
+
+func TestRunGameLiveHandlerErrors(t *testing.T) {
+	exporter, grpcClient, _ := setupWebapp(t)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	// A game failing before its first generation gets the error response of /rungame
+	grpcClient.EXPECT().RunGameStream(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		&fakeGameStream{err: status.Error(codes.InvalidArgument, "board: invalid")}, nil)
+	wr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/rungame/live", strings.NewReader(gameRequestToJSONAPI("[[2]]", 1)))
+	// The trace context added by the RUM SDK of the browser is the parent of the handler span
+	req.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	SetupHandlers().ServeHTTP(wr, req)
+	assert.Equal(t, http.StatusBadRequest, wr.Result().StatusCode)
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", spans[0].SpanContext.TraceID().String())
+		assert.Equal(t, "b7ad6b7169203331", spans[0].Parent.SpanID().String())
+	}
+}
+
+func TestRunGameImage(t *testing.T) {
+	frames := []*gameoflifepb.GenerationFrame{
+		{Generation: 0, Board: "[[0,1,0],[0,1,0],[0,1,0]]"},
+		{Generation: 1, Board: "[[0,0,0],[1,1,1],[0,0,0]]"},
+	}
+	var tests = []struct {
+		query       string
+		accept      string
+		contentType string
+		magic       string
+	}{
+		{"?format=png", "", "image/png", "\x89PNG"},
+		{"?format=SVG&cell_size=4&alive=ff0000", "", "image/svg+xml", "<svg"},
+		{"?format=gif&delay=50", "", "image/gif", "GIF89a"},
+		{"", "image/png", "image/png", "\x89PNG"},
+		{"", "text/html, image/svg+xml;q=0.9", "image/svg+xml", "<svg"},
+		// The format query parameter takes precedence over the Accept header
+		{"?format=gif", "image/png", "image/gif", "GIF89a"},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%q,%q", tt.query, tt.accept)
+		t.Run(testname, func(t *testing.T) {
+			exporter, grpcClient, _ := setupWebapp(t)
+			grpcClient.EXPECT().RunGame(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+				&gameoflifepb.GameResponse{Board: frames[1].Board, FinalGeneration: 1}, nil).AnyTimes()
+			grpcClient.EXPECT().RunGameStream(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+				&fakeGameStream{frames: slices.Clone(frames), err: io.EOF}, nil).AnyTimes()
+
+			wr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/rungame"+tt.query, strings.NewReader(gameRequestToJSONAPI(frames[0].Board, 1)))
+			req.Header.Set("Accept", tt.accept)
+			SetupHandlers().ServeHTTP(wr, req)
+			assert.Equal(t, http.StatusOK, wr.Code)
+			assert.Equal(t, tt.contentType, wr.Result().Header.Get("Content-Type"))
+			assert.True(t, strings.HasPrefix(wr.Body.String(), tt.magic))
+
+			// The image is rendered under a child span of the handler span
+			spans := exporter.GetSpans()
+			if assert.Len(t, spans, 2) {
+				assert.Equal(t, "RenderBoard", spans[0].Name)
+				assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+				assert.Contains(t, spans[0].Attributes, attribute.String("render_board.content_type", tt.contentType))
+			}
+		})
+	}
+}
+
+func TestRunGameImageErrors(t *testing.T) {
+	var tests = []struct {
+		query string
+		body  string
+		code  int
+	}{
+		{"?format=bmp", gameRequestToJSONAPI("[[1]]", 1), http.StatusBadRequest},
+		{"?format=png&cell_size=0", gameRequestToJSONAPI("[[1]]", 1), http.StatusBadRequest},
+		{"?format=png&alive=red", gameRequestToJSONAPI("[[1]]", 1), http.StatusBadRequest},
+		{"?format=gif&delay=-1", gameRequestToJSONAPI("[[1]]", 1), http.StatusBadRequest},
+		{"?format=gif", gameRequestToJSONAPI("[[1]]", 500), http.StatusRequestEntityTooLarge},
+		// The animation is rejected from the size of the board, without running the game
+		{"?format=gif", `{"random_board": {"width": 512, "height": 512, "density": 0.3}, "num_gens": 400}`, http.StatusRequestEntityTooLarge},
+		{"?format=png&cell_size=64", `{"random_board": {"width": 1024, "height": 1024, "density": 0.3}, "num_gens": 1}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v", tt.query)
+		t.Run(testname, func(t *testing.T) {
+			setupWebapp(t)
+			wr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/rungame"+tt.query, strings.NewReader(tt.body))
+			SetupHandlers().ServeHTTP(wr, req)
+			if wr.Code != tt.code {
+				t.Errorf("Got %v, expected %v", wr.Code, tt.code)
+			}
+		})
+	}
+}
+
+func TestHealthHandlers(t *testing.T) {
+	_, grpcClient, _ := setupWebapp(t)
+	healthChecks = health.NewRegistry()
+	t.Cleanup(func() { healthChecks = health.NewRegistry() })
+	grpcClient.EXPECT().CheckConnection(gomock.Any()).Return(errors.New("connection to localhost:8081 is TRANSIENT_FAILURE"))
+	healthChecks.Register("grpc_connection", grpcClient.CheckConnection)
+	handler := SetupHandlers()
+
+	// The webapp isn't ready without a connection to the server, but is still alive
+	wr := httptest.NewRecorder()
+	handler.ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/readiness", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, wr.Code)
+	var readiness struct {
+		Readiness bool            `json:"readiness"`
+		Checks    []health.Result `json:"checks"`
+	}
+	assert.NoError(t, json.NewDecoder(wr.Body).Decode(&readiness))
+	assert.False(t, readiness.Readiness)
+	if assert.Len(t, readiness.Checks, 1) {
+		assert.Equal(t, "grpc_connection", readiness.Checks[0].Name)
+		assert.Contains(t, readiness.Checks[0].Error, "TRANSIENT_FAILURE")
+	}
+
+	// The result of the checks is cached
+	wr = httptest.NewRecorder()
+	handler.ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/readiness", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, wr.Code)
+
+	wr = httptest.NewRecorder()
+	handler.ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/liveness", nil))
+	assert.Equal(t, http.StatusOK, wr.Code)
+	var liveness struct {
+		Liveness bool            `json:"liveness"`
+		Checks   []health.Result `json:"checks"`
+	}
+	assert.NoError(t, json.NewDecoder(wr.Body).Decode(&liveness))
+	assert.True(t, liveness.Liveness)
+	assert.Empty(t, liveness.Checks)
+}
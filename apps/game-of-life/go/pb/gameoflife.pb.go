@@ -9,6 +9,8 @@ package pb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,12 +22,179 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Text format of a board
+type BoardFormat int32
+
+const (
+	// JSON 2D array of 0's and 1's, e.g. [[0,1],[1,0]], or of the states of the cells for rules with more than 2 states
+	BoardFormat_JSON BoardFormat = 0
+	// Run length encoded pattern, with a header such as x = 3, y = 3, rule = B3/S23.
+	// The rule of the header is used if the request has no rule. For rules with more than 2 states,
+	// cells are written . for dead and A, B, ..., X, pA, ... for the other states, as in Golly.
+	BoardFormat_RLE BoardFormat = 1
+	// Plaintext (.cells) pattern, with one line per row of '.' for dead and 'O' for live cells.
+	// Only supported for rules with 2 states.
+	BoardFormat_PLAINTEXT BoardFormat = 2
+)
+
+// Enum value maps for BoardFormat.
+var (
+	BoardFormat_name = map[int32]string{
+		0: "JSON",
+		1: "RLE",
+		2: "PLAINTEXT",
+	}
+	BoardFormat_value = map[string]int32{
+		"JSON":      0,
+		"RLE":       1,
+		"PLAINTEXT": 2,
+	}
+)
+
+func (x BoardFormat) Enum() *BoardFormat {
+	p := new(BoardFormat)
+	*p = x
+	return p
+}
+
+func (x BoardFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BoardFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_gameoflife_proto_enumTypes[0].Descriptor()
+}
+
+func (BoardFormat) Type() protoreflect.EnumType {
+	return &file_gameoflife_proto_enumTypes[0]
+}
+
+func (x BoardFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BoardFormat.Descriptor instead.
+func (BoardFormat) EnumDescriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{0}
+}
+
+// Engine used to advance the board
+type Engine int32
+
+const (
+	// Steps the board one generation at a time
+	Engine_STANDARD Engine = 0
+	// Memoized quadtree engine that can advance patterns by millions of generations.
+	// The board is the top left corner of an unbounded plane, so only the BOUNDED topology is supported.
+	Engine_HASHLIFE Engine = 1
+)
+
+// Enum value maps for Engine.
+var (
+	Engine_name = map[int32]string{
+		0: "STANDARD",
+		1: "HASHLIFE",
+	}
+	Engine_value = map[string]int32{
+		"STANDARD": 0,
+		"HASHLIFE": 1,
+	}
+)
+
+func (x Engine) Enum() *Engine {
+	p := new(Engine)
+	*p = x
+	return p
+}
+
+func (x Engine) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Engine) Descriptor() protoreflect.EnumDescriptor {
+	return file_gameoflife_proto_enumTypes[1].Descriptor()
+}
+
+func (Engine) Type() protoreflect.EnumType {
+	return &file_gameoflife_proto_enumTypes[1]
+}
+
+func (x Engine) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Engine.Descriptor instead.
+func (Engine) EnumDescriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{1}
+}
+
+// Topology of the board edges
+type Topology int32
+
+const (
+	// Cells outside the board are dead
+	Topology_BOUNDED Topology = 0
+	// Both axes wrap around
+	Topology_TORUS Topology = 1
+	// Columns wrap around, rows wrap around with the columns mirrored
+	Topology_KLEIN_BOTTLE Topology = 2
+	// Columns wrap around, rows are bounded
+	Topology_CYLINDER Topology = 3
+)
+
+// Enum value maps for Topology.
+var (
+	Topology_name = map[int32]string{
+		0: "BOUNDED",
+		1: "TORUS",
+		2: "KLEIN_BOTTLE",
+		3: "CYLINDER",
+	}
+	Topology_value = map[string]int32{
+		"BOUNDED":      0,
+		"TORUS":        1,
+		"KLEIN_BOTTLE": 2,
+		"CYLINDER":     3,
+	}
+)
+
+func (x Topology) Enum() *Topology {
+	p := new(Topology)
+	*p = x
+	return p
+}
+
+func (x Topology) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Topology) Descriptor() protoreflect.EnumDescriptor {
+	return file_gameoflife_proto_enumTypes[2].Descriptor()
+}
+
+func (Topology) Type() protoreflect.EnumType {
+	return &file_gameoflife_proto_enumTypes[2]
+}
+
+func (x Topology) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Topology.Descriptor instead.
+func (Topology) EnumDescriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{2}
+}
+
 type ResponseCode int32
 
 const (
 	ResponseCode_UNKNOWN     ResponseCode = 0
 	ResponseCode_OK          ResponseCode = 1
 	ResponseCode_BAD_REQUEST ResponseCode = 2
+	// The game was cancelled. The response holds the board of final_generation, the last generation reached.
+	ResponseCode_CANCELLED ResponseCode = 3
+	// The deadline of the game expired. The response holds the board of final_generation, the last generation reached.
+	ResponseCode_DEADLINE_EXCEEDED ResponseCode = 4
 )
 
 // Enum value maps for ResponseCode.
@@ -34,11 +203,15 @@ var (
 		0: "UNKNOWN",
 		1: "OK",
 		2: "BAD_REQUEST",
+		3: "CANCELLED",
+		4: "DEADLINE_EXCEEDED",
 	}
 	ResponseCode_value = map[string]int32{
-		"UNKNOWN":     0,
-		"OK":          1,
-		"BAD_REQUEST": 2,
+		"UNKNOWN":           0,
+		"OK":                1,
+		"BAD_REQUEST":       2,
+		"CANCELLED":         3,
+		"DEADLINE_EXCEEDED": 4,
 	}
 )
 
@@ -53,11 +226,11 @@ func (x ResponseCode) String() string {
 }
 
 func (ResponseCode) Descriptor() protoreflect.EnumDescriptor {
-	return file_gameoflife_proto_enumTypes[0].Descriptor()
+	return file_gameoflife_proto_enumTypes[3].Descriptor()
 }
 
 func (ResponseCode) Type() protoreflect.EnumType {
-	return &file_gameoflife_proto_enumTypes[0]
+	return &file_gameoflife_proto_enumTypes[3]
 }
 
 func (x ResponseCode) Number() protoreflect.EnumNumber {
@@ -66,7 +239,63 @@ func (x ResponseCode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ResponseCode.Descriptor instead.
 func (ResponseCode) EnumDescriptor() ([]byte, []int) {
-	return file_gameoflife_proto_rawDescGZIP(), []int{0}
+	return file_gameoflife_proto_rawDescGZIP(), []int{3}
+}
+
+type JobState int32
+
+const (
+	// Waiting for a free job worker
+	JobState_JOB_PENDING   JobState = 0
+	JobState_JOB_RUNNING   JobState = 1
+	JobState_JOB_SUCCEEDED JobState = 2
+	JobState_JOB_FAILED    JobState = 3
+	JobState_JOB_CANCELLED JobState = 4
+)
+
+// Enum value maps for JobState.
+var (
+	JobState_name = map[int32]string{
+		0: "JOB_PENDING",
+		1: "JOB_RUNNING",
+		2: "JOB_SUCCEEDED",
+		3: "JOB_FAILED",
+		4: "JOB_CANCELLED",
+	}
+	JobState_value = map[string]int32{
+		"JOB_PENDING":   0,
+		"JOB_RUNNING":   1,
+		"JOB_SUCCEEDED": 2,
+		"JOB_FAILED":    3,
+		"JOB_CANCELLED": 4,
+	}
+)
+
+func (x JobState) Enum() *JobState {
+	p := new(JobState)
+	*p = x
+	return p
+}
+
+func (x JobState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobState) Descriptor() protoreflect.EnumDescriptor {
+	return file_gameoflife_proto_enumTypes[4].Descriptor()
+}
+
+func (JobState) Type() protoreflect.EnumType {
+	return &file_gameoflife_proto_enumTypes[4]
+}
+
+func (x JobState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobState.Descriptor instead.
+func (JobState) EnumDescriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{4}
 }
 
 type GameRequest struct {
@@ -74,8 +303,35 @@ type GameRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// Board in the given format, JSON by default. Ignored if structured_board, pattern_name or random_board is set.
 	Board   string `protobuf:"bytes,1,opt,name=board,proto3" json:"board,omitempty"`
 	NumGens int32  `protobuf:"varint,2,opt,name=num_gens,json=numGens,proto3" json:"num_gens,omitempty"`
+	// Rule of the game, which defaults to Conway's Life, B3/S23. Supported rules are
+	//   - Life-like rules in B/S notation, e.g. B36/S23
+	//   - Generations rules in S/B/C notation, e.g. /2/3 for Brian's Brain, or B/S/C notation, e.g. B2/S/C3,
+	//     where live cells that don't survive go through C-2 dying states before they are dead
+	//   - Larger than Life rules, e.g. R5,C0,M1,S34..58,B34..45,NM, with a range of up to 50 cells
+	// Life-like and Generations rules use the von Neumann neighborhood if the rule ends with V, e.g. B2/S013V.
+	// Boards of rules with more than 2 states hold the state of every cell, from 0 for dead and 1 for
+	// alive to the number of states minus 1.
+	Rule     string   `protobuf:"bytes,3,opt,name=rule,proto3" json:"rule,omitempty"`
+	Topology Topology `protobuf:"varint,4,opt,name=topology,proto3,enum=gameoflifepb.Topology" json:"topology,omitempty"`
+	Engine   Engine   `protobuf:"varint,5,opt,name=engine,proto3,enum=gameoflifepb.Engine" json:"engine,omitempty"`
+	// Takes precedence over board if set. The response and every frame then hold the board in
+	// structured_board, with live_cells if the request used live_cells and rows otherwise,
+	// and leave board empty.
+	StructuredBoard *Board `protobuf:"bytes,6,opt,name=structured_board,json=structuredBoard,proto3" json:"structured_board,omitempty"`
+	// Format of board, also used for the board of the response and of every frame
+	Format BoardFormat `protobuf:"varint,7,opt,name=format,proto3,enum=gameoflifepb.BoardFormat" json:"format,omitempty"`
+	// Name of a built-in pattern to run instead of board, placed on an empty board by placement.
+	// The rule of the pattern is used if the request has no rule. Ignored if structured_board is set.
+	PatternName string            `protobuf:"bytes,8,opt,name=pattern_name,json=patternName,proto3" json:"pattern_name,omitempty"`
+	Placement   *PatternPlacement `protobuf:"bytes,9,opt,name=placement,proto3" json:"placement,omitempty"`
+	// Random board generated by the server instead of board. Ignored if structured_board or pattern_name is set.
+	RandomBoard *RandomBoard `protobuf:"bytes,10,opt,name=random_board,json=randomBoard,proto3" json:"random_board,omitempty"`
+	// Include the stats of every generation in the response instead of only generation 0 and the last generation,
+	// for games of at most 10000 generations
+	GenerationStats bool `protobuf:"varint,11,opt,name=generation_stats,json=generationStats,proto3" json:"generation_stats,omitempty"`
 }
 
 func (x *GameRequest) Reset() {
@@ -124,18 +380,85 @@ func (x *GameRequest) GetNumGens() int32 {
 	return 0
 }
 
-type GameResponse struct {
+func (x *GameRequest) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *GameRequest) GetTopology() Topology {
+	if x != nil {
+		return x.Topology
+	}
+	return Topology_BOUNDED
+}
+
+func (x *GameRequest) GetEngine() Engine {
+	if x != nil {
+		return x.Engine
+	}
+	return Engine_STANDARD
+}
+
+func (x *GameRequest) GetStructuredBoard() *Board {
+	if x != nil {
+		return x.StructuredBoard
+	}
+	return nil
+}
+
+func (x *GameRequest) GetFormat() BoardFormat {
+	if x != nil {
+		return x.Format
+	}
+	return BoardFormat_JSON
+}
+
+func (x *GameRequest) GetPatternName() string {
+	if x != nil {
+		return x.PatternName
+	}
+	return ""
+}
+
+func (x *GameRequest) GetPlacement() *PatternPlacement {
+	if x != nil {
+		return x.Placement
+	}
+	return nil
+}
+
+func (x *GameRequest) GetRandomBoard() *RandomBoard {
+	if x != nil {
+		return x.RandomBoard
+	}
+	return nil
+}
+
+func (x *GameRequest) GetGenerationStats() bool {
+	if x != nil {
+		return x.GenerationStats
+	}
+	return false
+}
+
+// Board of width x height cells, each alive with probability density. The board is generated
+// deterministically from the seed, so the same request always gives the same board.
+type RandomBoard struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Code         ResponseCode `protobuf:"varint,1,opt,name=code,proto3,enum=gameoflifepb.ResponseCode" json:"code,omitempty"`
-	ErrorMessage string       `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	Board        string       `protobuf:"bytes,3,opt,name=board,proto3" json:"board,omitempty"`
+	Width  int32 `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// Probability of a cell being alive, from 0 to 1
+	Density float64 `protobuf:"fixed64,3,opt,name=density,proto3" json:"density,omitempty"`
+	Seed    int64   `protobuf:"varint,4,opt,name=seed,proto3" json:"seed,omitempty"`
 }
 
-func (x *GameResponse) Reset() {
-	*x = GameResponse{}
+func (x *RandomBoard) Reset() {
+	*x = RandomBoard{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_gameoflife_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -143,13 +466,13 @@ func (x *GameResponse) Reset() {
 	}
 }
 
-func (x *GameResponse) String() string {
+func (x *RandomBoard) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GameResponse) ProtoMessage() {}
+func (*RandomBoard) ProtoMessage() {}
 
-func (x *GameResponse) ProtoReflect() protoreflect.Message {
+func (x *RandomBoard) ProtoReflect() protoreflect.Message {
 	mi := &file_gameoflife_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -161,114 +484,2292 @@ func (x *GameResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GameResponse.ProtoReflect.Descriptor instead.
-func (*GameResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RandomBoard.ProtoReflect.Descriptor instead.
+func (*RandomBoard) Descriptor() ([]byte, []int) {
 	return file_gameoflife_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *GameResponse) GetCode() ResponseCode {
+func (x *RandomBoard) GetWidth() int32 {
 	if x != nil {
-		return x.Code
+		return x.Width
 	}
-	return ResponseCode_UNKNOWN
+	return 0
 }
 
-func (x *GameResponse) GetErrorMessage() string {
+func (x *RandomBoard) GetHeight() int32 {
 	if x != nil {
-		return x.ErrorMessage
+		return x.Height
 	}
-	return ""
+	return 0
 }
 
-func (x *GameResponse) GetBoard() string {
+func (x *RandomBoard) GetDensity() float64 {
 	if x != nil {
-		return x.Board
+		return x.Density
 	}
-	return ""
+	return 0
 }
 
-var File_gameoflife_proto protoreflect.FileDescriptor
+func (x *RandomBoard) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
 
-var file_gameoflife_proto_rawDesc = []byte{
-	0x0a, 0x10, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x0c, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62,
-	0x22, 0x3e, 0x0a, 0x0b, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x14, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x75, 0x6d, 0x5f, 0x67, 0x65, 0x6e,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6e, 0x75, 0x6d, 0x47, 0x65, 0x6e, 0x73,
-	0x22, 0x79, 0x0a, 0x0c, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x2e, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a,
-	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65,
-	0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x2a, 0x34, 0x0a, 0x0c, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55,
-	0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x01,
-	0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x41, 0x44, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10,
-	0x02, 0x32, 0x4e, 0x0a, 0x0a, 0x47, 0x61, 0x6d, 0x65, 0x4f, 0x66, 0x4c, 0x69, 0x66, 0x65, 0x12,
-	0x40, 0x0a, 0x07, 0x52, 0x75, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x2e, 0x67, 0x61, 0x6d,
-	0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
-	0x66, 0x65, 0x70, 0x62, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x42, 0x43, 0x5a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x44, 0x61, 0x74, 0x61, 0x44, 0x6f, 0x67, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x74, 0x65, 0x6c, 0x65,
-	0x6d, 0x65, 0x74, 0x72, 0x79, 0x2d, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x61,
-	0x70, 0x70, 0x73, 0x2f, 0x67, 0x61, 0x6d, 0x65, 0x2d, 0x6f, 0x66, 0x2d, 0x6c, 0x69, 0x66, 0x65,
-	0x2f, 0x67, 0x6f, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+// Placement of a named pattern on an empty board
+type PatternPlacement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Row and column of the top left cell of the pattern on the board
+	Row int32 `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Col int32 `protobuf:"varint,2,opt,name=col,proto3" json:"col,omitempty"`
+	// Size of the board, which defaults to the size of the pattern with a margin of row rows
+	// above and below it and of col columns on its left and right
+	Width  int32 `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
 }
 
-var (
-	file_gameoflife_proto_rawDescOnce sync.Once
-	file_gameoflife_proto_rawDescData = file_gameoflife_proto_rawDesc
-)
+func (x *PatternPlacement) Reset() {
+	*x = PatternPlacement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_gameoflife_proto_rawDescGZIP() []byte {
-	file_gameoflife_proto_rawDescOnce.Do(func() {
-		file_gameoflife_proto_rawDescData = protoimpl.X.CompressGZIP(file_gameoflife_proto_rawDescData)
-	})
-	return file_gameoflife_proto_rawDescData
+func (x *PatternPlacement) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_gameoflife_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_gameoflife_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_gameoflife_proto_goTypes = []interface{}{
-	(ResponseCode)(0),    // 0: gameoflifepb.ResponseCode
-	(*GameRequest)(nil),  // 1: gameoflifepb.GameRequest
-	(*GameResponse)(nil), // 2: gameoflifepb.GameResponse
+func (*PatternPlacement) ProtoMessage() {}
+
+func (x *PatternPlacement) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_gameoflife_proto_depIdxs = []int32{
-	0, // 0: gameoflifepb.GameResponse.code:type_name -> gameoflifepb.ResponseCode
-	1, // 1: gameoflifepb.GameOfLife.RunGame:input_type -> gameoflifepb.GameRequest
-	2, // 2: gameoflifepb.GameOfLife.RunGame:output_type -> gameoflifepb.GameResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+
+// Deprecated: Use PatternPlacement.ProtoReflect.Descriptor instead.
+func (*PatternPlacement) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{2}
 }
 
-func init() { file_gameoflife_proto_init() }
-func file_gameoflife_proto_init() {
-	if File_gameoflife_proto != nil {
-		return
+func (x *PatternPlacement) GetRow() int32 {
+	if x != nil {
+		return x.Row
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_gameoflife_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GameRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_gameoflife_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GameResponse); i {
+	return 0
+}
+
+func (x *PatternPlacement) GetCol() int32 {
+	if x != nil {
+		return x.Col
+	}
+	return 0
+}
+
+func (x *PatternPlacement) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *PatternPlacement) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// Built-in pattern
+type Pattern struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name used as pattern_name, e.g. glider
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Pattern in RLE format, with its rule in the header
+	Rle string `protobuf:"bytes,3,opt,name=rle,proto3" json:"rle,omitempty"`
+	// Pattern as a structured board of its live cells
+	Board *Board `protobuf:"bytes,4,opt,name=board,proto3" json:"board,omitempty"`
+}
+
+func (x *Pattern) Reset() {
+	*x = Pattern{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Pattern) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pattern) ProtoMessage() {}
+
+func (x *Pattern) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pattern.ProtoReflect.Descriptor instead.
+func (*Pattern) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Pattern) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Pattern) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Pattern) GetRle() string {
+	if x != nil {
+		return x.Rle
+	}
+	return ""
+}
+
+func (x *Pattern) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+type ListPatternsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListPatternsRequest) Reset() {
+	*x = ListPatternsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPatternsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPatternsRequest) ProtoMessage() {}
+
+func (x *ListPatternsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPatternsRequest.ProtoReflect.Descriptor instead.
+func (*ListPatternsRequest) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{4}
+}
+
+type ListPatternsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Patterns []*Pattern `protobuf:"bytes,1,rep,name=patterns,proto3" json:"patterns,omitempty"`
+}
+
+func (x *ListPatternsResponse) Reset() {
+	*x = ListPatternsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPatternsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPatternsResponse) ProtoMessage() {}
+
+func (x *ListPatternsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPatternsResponse.ProtoReflect.Descriptor instead.
+func (*ListPatternsResponse) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListPatternsResponse) GetPatterns() []*Pattern {
+	if x != nil {
+		return x.Patterns
+	}
+	return nil
+}
+
+type GetPatternRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetPatternRequest) Reset() {
+	*x = GetPatternRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPatternRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPatternRequest) ProtoMessage() {}
+
+func (x *GetPatternRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPatternRequest.ProtoReflect.Descriptor instead.
+func (*GetPatternRequest) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetPatternRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Board of width x height cells. The live cells are given either as packed rows or, for sparse
+// boards, as a list of coordinates. Setting both is an error, and setting neither gives an empty board.
+type Board struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Width  int32 `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// One entry of (width + 7) / 8 bytes per row, with the cell of column c in bit c % 8 of byte c / 8.
+	// The bits past the last column must be 0.
+	Rows      [][]byte `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"`
+	LiveCells []*Cell  `protobuf:"bytes,4,rep,name=live_cells,json=liveCells,proto3" json:"live_cells,omitempty"`
+	// Number of states of the cells, only set in responses for rules with more than 2 states
+	NumStates int32 `protobuf:"varint,5,opt,name=num_states,json=numStates,proto3" json:"num_states,omitempty"`
+	// For rules with more than 2 states, one byte per cell, row by row, holding the state of the cell.
+	// Only one of rows, live_cells and states can be set.
+	States []byte `protobuf:"bytes,6,opt,name=states,proto3" json:"states,omitempty"`
+}
+
+func (x *Board) Reset() {
+	*x = Board{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Board) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Board) ProtoMessage() {}
+
+func (x *Board) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Board.ProtoReflect.Descriptor instead.
+func (*Board) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Board) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Board) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Board) GetRows() [][]byte {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *Board) GetLiveCells() []*Cell {
+	if x != nil {
+		return x.LiveCells
+	}
+	return nil
+}
+
+func (x *Board) GetNumStates() int32 {
+	if x != nil {
+		return x.NumStates
+	}
+	return 0
+}
+
+func (x *Board) GetStates() []byte {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+type Cell struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Row int32 `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Col int32 `protobuf:"varint,2,opt,name=col,proto3" json:"col,omitempty"`
+	// State of the cell, which is alive (1) if unset. Only set for the dying states of rules with more than 2 states.
+	State int32 `protobuf:"varint,3,opt,name=state,proto3" json:"state,omitempty"`
+}
+
+func (x *Cell) Reset() {
+	*x = Cell{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cell) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cell) ProtoMessage() {}
+
+func (x *Cell) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cell.ProtoReflect.Descriptor instead.
+func (*Cell) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Cell) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *Cell) GetCol() int32 {
+	if x != nil {
+		return x.Col
+	}
+	return 0
+}
+
+func (x *Cell) GetState() int32 {
+	if x != nil {
+		return x.State
+	}
+	return 0
+}
+
+type GameResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code         ResponseCode `protobuf:"varint,1,opt,name=code,proto3,enum=gameoflifepb.ResponseCode" json:"code,omitempty"`
+	ErrorMessage string       `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Board        string       `protobuf:"bytes,3,opt,name=board,proto3" json:"board,omitempty"`
+	// Generation at which the run stopped. It is num_gens unless the board repeated an earlier
+	// generation, in which case the board of generation num_gens is found from the cycle.
+	FinalGeneration int32 `protobuf:"varint,4,opt,name=final_generation,json=finalGeneration,proto3" json:"final_generation,omitempty"`
+	// Period of the cycle the board entered, 1 for still lifes, or 0 if no repeat was found
+	Period int32 `protobuf:"varint,5,opt,name=period,proto3" json:"period,omitempty"`
+	// True if the board has no live cells
+	Extinct bool `protobuf:"varint,6,opt,name=extinct,proto3" json:"extinct,omitempty"`
+	// Set instead of board if the request had a structured_board
+	StructuredBoard *Board `protobuf:"bytes,7,opt,name=structured_board,json=structuredBoard,proto3" json:"structured_board,omitempty"`
+	// Statistics of generation 0 and of final_generation, whose births and deaths are counted since generation 0,
+	// or of every generation from 0 to final_generation if the request set generation_stats. With the HashLife
+	// engine, only generation 0 and the last generation are included unless the game is streamed.
+	Stats []*GenerationStats `protobuf:"bytes,8,rep,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *GameResponse) Reset() {
+	*x = GameResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameResponse) ProtoMessage() {}
+
+func (x *GameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameResponse.ProtoReflect.Descriptor instead.
+func (*GameResponse) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GameResponse) GetCode() ResponseCode {
+	if x != nil {
+		return x.Code
+	}
+	return ResponseCode_UNKNOWN
+}
+
+func (x *GameResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *GameResponse) GetBoard() string {
+	if x != nil {
+		return x.Board
+	}
+	return ""
+}
+
+func (x *GameResponse) GetFinalGeneration() int32 {
+	if x != nil {
+		return x.FinalGeneration
+	}
+	return 0
+}
+
+func (x *GameResponse) GetPeriod() int32 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+func (x *GameResponse) GetExtinct() bool {
+	if x != nil {
+		return x.Extinct
+	}
+	return false
+}
+
+func (x *GameResponse) GetStructuredBoard() *Board {
+	if x != nil {
+		return x.StructuredBoard
+	}
+	return nil
+}
+
+func (x *GameResponse) GetStats() []*GenerationStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type BatchGameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Requests []*GameRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+}
+
+func (x *BatchGameRequest) Reset() {
+	*x = BatchGameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGameRequest) ProtoMessage() {}
+
+func (x *BatchGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGameRequest.ProtoReflect.Descriptor instead.
+func (*BatchGameRequest) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BatchGameRequest) GetRequests() []*GameRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type BatchGameResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Result of every game, in the order of the requests
+	Results []*BatchGameResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *BatchGameResponse) Reset() {
+	*x = BatchGameResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGameResponse) ProtoMessage() {}
+
+func (x *BatchGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGameResponse.ProtoReflect.Descriptor instead.
+func (*BatchGameResponse) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchGameResponse) GetResults() []*BatchGameResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type BatchGameResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Response of the game, unset if the game was rejected or invalid
+	Response *GameResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	// gRPC status code of the game, 0 (OK) if it succeeded, as returned by RunGame for the same request
+	StatusCode   int32  `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	ErrorMessage string `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (x *BatchGameResult) Reset() {
+	*x = BatchGameResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchGameResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGameResult) ProtoMessage() {}
+
+func (x *BatchGameResult) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGameResult.ProtoReflect.Descriptor instead.
+func (*BatchGameResult) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchGameResult) GetResponse() *GameResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *BatchGameResult) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *BatchGameResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// Game run in the background by SubmitGame. Finished jobs are kept by the server for a limited time.
+type Job struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	State   JobState     `protobuf:"varint,2,opt,name=state,proto3,enum=gameoflifepb.JobState" json:"state,omitempty"`
+	Request *GameRequest `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	// Last generation computed, to follow the progress of the game up to request.num_gens
+	Generation int32 `protobuf:"varint,4,opt,name=generation,proto3" json:"generation,omitempty"`
+	// Response of the game, set once the job succeeded or was cancelled
+	Result *GameResponse `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+	// gRPC status code of a failed or cancelled job, as returned by RunGame for the same request
+	StatusCode   int32                  `protobuf:"varint,6,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	ErrorMessage string                 `protobuf:"bytes,7,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	CreateTime   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// Unset until the job is finished
+	FinishTime *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=finish_time,json=finishTime,proto3" json:"finish_time,omitempty"`
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Job) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Job) GetState() JobState {
+	if x != nil {
+		return x.State
+	}
+	return JobState_JOB_PENDING
+}
+
+func (x *Job) GetRequest() *GameRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *Job) GetGeneration() int32 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *Job) GetResult() *GameResponse {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *Job) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *Job) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *Job) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Job) GetFinishTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishTime
+	}
+	return nil
+}
+
+type GetJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CancelJobRequest) Reset() {
+	*x = CancelJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobRequest) ProtoMessage() {}
+
+func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CancelJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListJobsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{16}
+}
+
+type ListJobsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Jobs from the oldest to the newest
+	Jobs []*Job `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+// Game whose board is kept by the server between the generations stepped by the client
+type Session struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Number of generations stepped since the session was created
+	Generation int32 `protobuf:"varint,2,opt,name=generation,proto3" json:"generation,omitempty"`
+	// Game of the session, with the board of the current generation in board, or in structured_board if the
+	// session was created with a structured board. The rule is set even if the session was created with the
+	// rule of an RLE header or a pattern, and num_gens is unset.
+	Game *GameRequest `protobuf:"bytes,3,opt,name=game,proto3" json:"game,omitempty"`
+	// True if the board of the current generation has no live cells
+	Extinct    bool                   `protobuf:"varint,4,opt,name=extinct,proto3" json:"extinct,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetGeneration() int32 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *Session) GetGame() *GameRequest {
+	if x != nil {
+		return x.Game
+	}
+	return nil
+}
+
+func (x *Session) GetExtinct() bool {
+	if x != nil {
+		return x.Extinct
+	}
+	return false
+}
+
+func (x *Session) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Session) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+type CreateSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Initial board of the session, given like the board of a game, and its rule, topology, engine and format.
+	// The num_gens of the game is ignored.
+	Game *GameRequest `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`
+}
+
+func (x *CreateSessionRequest) Reset() {
+	*x = CreateSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSessionRequest) ProtoMessage() {}
+
+func (x *CreateSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSessionRequest) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateSessionRequest) GetGame() *GameRequest {
+	if x != nil {
+		return x.Game
+	}
+	return nil
+}
+
+type StepRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Number of generations to advance the board by, 1 if unset
+	NumGens int32 `protobuf:"varint,2,opt,name=num_gens,json=numGens,proto3" json:"num_gens,omitempty"`
+}
+
+func (x *StepRequest) Reset() {
+	*x = StepRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepRequest) ProtoMessage() {}
+
+func (x *StepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepRequest.ProtoReflect.Descriptor instead.
+func (*StepRequest) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StepRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *StepRequest) GetNumGens() int32 {
+	if x != nil {
+		return x.NumGens
+	}
+	return 0
+}
+
+type GetSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *GetSessionRequest) Reset() {
+	*x = GetSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSessionRequest) ProtoMessage() {}
+
+func (x *GetSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetSessionRequest) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type DeleteSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *DeleteSessionRequest) Reset() {
+	*x = DeleteSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSessionRequest) ProtoMessage() {}
+
+func (x *DeleteSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSessionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSessionRequest) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeleteSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type GenerationStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Generation int32 `protobuf:"varint,1,opt,name=generation,proto3" json:"generation,omitempty"`
+	// Number of live cells, not counting the dying cells of rules with more than 2 states
+	Population int32 `protobuf:"varint,2,opt,name=population,proto3" json:"population,omitempty"`
+	// Cells born and died since the previous entry of the stats, 0 for generation 0
+	Births int32 `protobuf:"varint,3,opt,name=births,proto3" json:"births,omitempty"`
+	Deaths int32 `protobuf:"varint,4,opt,name=deaths,proto3" json:"deaths,omitempty"`
+	// Smallest rectangle holding all live cells, unset if the board is empty
+	BoundingBox *BoundingBox `protobuf:"bytes,5,opt,name=bounding_box,json=boundingBox,proto3" json:"bounding_box,omitempty"`
+}
+
+func (x *GenerationStats) Reset() {
+	*x = GenerationStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenerationStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerationStats) ProtoMessage() {}
+
+func (x *GenerationStats) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerationStats.ProtoReflect.Descriptor instead.
+func (*GenerationStats) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GenerationStats) GetGeneration() int32 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetPopulation() int32 {
+	if x != nil {
+		return x.Population
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetBirths() int32 {
+	if x != nil {
+		return x.Births
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetDeaths() int32 {
+	if x != nil {
+		return x.Deaths
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetBoundingBox() *BoundingBox {
+	if x != nil {
+		return x.BoundingBox
+	}
+	return nil
+}
+
+// Rectangle of cells from (min_row, min_col) to (max_row, max_col) included
+type BoundingBox struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MinRow int32 `protobuf:"varint,1,opt,name=min_row,json=minRow,proto3" json:"min_row,omitempty"`
+	MinCol int32 `protobuf:"varint,2,opt,name=min_col,json=minCol,proto3" json:"min_col,omitempty"`
+	MaxRow int32 `protobuf:"varint,3,opt,name=max_row,json=maxRow,proto3" json:"max_row,omitempty"`
+	MaxCol int32 `protobuf:"varint,4,opt,name=max_col,json=maxCol,proto3" json:"max_col,omitempty"`
+}
+
+func (x *BoundingBox) Reset() {
+	*x = BoundingBox{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BoundingBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoundingBox) ProtoMessage() {}
+
+func (x *BoundingBox) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoundingBox.ProtoReflect.Descriptor instead.
+func (*BoundingBox) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *BoundingBox) GetMinRow() int32 {
+	if x != nil {
+		return x.MinRow
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMinCol() int32 {
+	if x != nil {
+		return x.MinCol
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMaxRow() int32 {
+	if x != nil {
+		return x.MaxRow
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMaxCol() int32 {
+	if x != nil {
+		return x.MaxCol
+	}
+	return 0
+}
+
+type GenerationFrame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Generation int32  `protobuf:"varint,1,opt,name=generation,proto3" json:"generation,omitempty"`
+	Board      string `protobuf:"bytes,2,opt,name=board,proto3" json:"board,omitempty"`
+	// Set instead of board if the request had a structured_board
+	StructuredBoard *Board           `protobuf:"bytes,3,opt,name=structured_board,json=structuredBoard,proto3" json:"structured_board,omitempty"`
+	Stats           *GenerationStats `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *GenerationFrame) Reset() {
+	*x = GenerationFrame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenerationFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerationFrame) ProtoMessage() {}
+
+func (x *GenerationFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerationFrame.ProtoReflect.Descriptor instead.
+func (*GenerationFrame) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GenerationFrame) GetGeneration() int32 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *GenerationFrame) GetBoard() string {
+	if x != nil {
+		return x.Board
+	}
+	return ""
+}
+
+func (x *GenerationFrame) GetStructuredBoard() *Board {
+	if x != nil {
+		return x.StructuredBoard
+	}
+	return nil
+}
+
+func (x *GenerationFrame) GetStats() *GenerationStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+var File_gameoflife_proto protoreflect.FileDescriptor
+
+var file_gameoflife_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0c, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62,
+	0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf1,
+	0x03, 0x0a, 0x0b, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x75, 0x6d, 0x5f, 0x67, 0x65, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6e, 0x75, 0x6d, 0x47, 0x65, 0x6e, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72,
+	0x75, 0x6c, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x08, 0x74,
+	0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12, 0x2c, 0x0a, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66,
+	0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x52, 0x06, 0x65,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x3e, 0x0a, 0x10, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75,
+	0x72, 0x65, 0x64, 0x5f, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x42,
+	0x6f, 0x61, 0x72, 0x64, 0x52, 0x0f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x64,
+	0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x31, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x70, 0x62, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3c, 0x0a, 0x09, 0x70,
+	0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x50, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09,
+	0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x0c, 0x72, 0x61, 0x6e,
+	0x64, 0x6f, 0x6d, 0x5f, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x52,
+	0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x0b, 0x72, 0x61, 0x6e, 0x64,
+	0x6f, 0x6d, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x67, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x22, 0x69, 0x0a, 0x0b, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x42, 0x6f, 0x61, 0x72,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x64, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x07, 0x64, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x22, 0x64, 0x0a,
+	0x10, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
+	0x72, 0x6f, 0x77, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x03, 0x63, 0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x22, 0x7c, 0x0a, 0x07, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x72, 0x6c, 0x65, 0x12, 0x29, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x70, 0x62, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72,
+	0x64, 0x22, 0x15, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x49, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x31, 0x0a, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70,
+	0x62, 0x2e, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x52, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65,
+	0x72, 0x6e, 0x73, 0x22, 0x27, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xb3, 0x01, 0x0a,
+	0x05, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x31, 0x0a, 0x0a, 0x6c, 0x69, 0x76, 0x65,
+	0x5f, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67,
+	0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x65, 0x6c, 0x6c,
+	0x52, 0x09, 0x6c, 0x69, 0x76, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6e,
+	0x75, 0x6d, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x6e, 0x75, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x73, 0x22, 0x40, 0x0a, 0x04, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x6f,
+	0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x72, 0x6f, 0x77, 0x12, 0x10, 0x0a, 0x03,
+	0x63, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x63, 0x6f, 0x6c, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x22, 0xcb, 0x02, 0x0a, 0x0c, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65,
+	0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x52,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x12, 0x29, 0x0a, 0x10, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x66, 0x69, 0x6e, 0x61,
+	0x6c, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x78, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x12, 0x3e, 0x0a,
+	0x10, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x64, 0x5f, 0x62, 0x6f, 0x61, 0x72,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66,
+	0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x0f, 0x73, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x64, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x33, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67,
+	0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x22, 0x49, 0x0a, 0x10, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x61, 0x6d, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f,
+	0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x4c, 0x0a,
+	0x11, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x37, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65,
+	0x70, 0x62, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x8f, 0x01, 0x0a, 0x0f,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x36, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62,
+	0x2e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x8c, 0x03,
+	0x0a, 0x03, 0x4a, 0x6f, 0x62, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2c, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66,
+	0x65, 0x70, 0x62, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66,
+	0x65, 0x70, 0x62, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f,
+	0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x3b, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x1f, 0x0a, 0x0d,
+	0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x22, 0x0a,
+	0x10, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x11, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x39, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c,
+	0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x22,
+	0xfc, 0x01, 0x0a, 0x07, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x67,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x04, 0x67,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x61, 0x6d, 0x65,
+	0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x04, 0x67, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78,
+	0x74, 0x69, 0x6e, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x78, 0x74,
+	0x69, 0x6e, 0x63, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x45,
+	0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x04, 0x67, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66,
+	0x65, 0x70, 0x62, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x04, 0x67, 0x61, 0x6d, 0x65, 0x22, 0x47, 0x0a, 0x0b, 0x53, 0x74, 0x65, 0x70, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x75, 0x6d, 0x5f, 0x67, 0x65, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6e, 0x75, 0x6d, 0x47, 0x65, 0x6e, 0x73, 0x22, 0x32,
+	0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x22, 0x35, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xbf, 0x01, 0x0a, 0x0f, 0x47, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a,
+	0x0a, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x62, 0x69, 0x72, 0x74, 0x68, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62,
+	0x69, 0x72, 0x74, 0x68, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x61, 0x74, 0x68, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x64, 0x65, 0x61, 0x74, 0x68, 0x73, 0x12, 0x3c, 0x0a,
+	0x0c, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x62, 0x6f, 0x78, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65,
+	0x70, 0x62, 0x2e, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x52, 0x0b,
+	0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x22, 0x71, 0x0a, 0x0b, 0x42,
+	0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x69,
+	0x6e, 0x5f, 0x72, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x69, 0x6e,
+	0x52, 0x6f, 0x77, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x6c, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x43, 0x6f, 0x6c, 0x12, 0x17, 0x0a, 0x07,
+	0x6d, 0x61, 0x78, 0x5f, 0x72, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d,
+	0x61, 0x78, 0x52, 0x6f, 0x77, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6c,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6c, 0x22, 0xbc,
+	0x01, 0x0a, 0x0f, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x72, 0x61,
+	0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x3e, 0x0a, 0x10, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x75, 0x72, 0x65, 0x64, 0x5f, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70,
+	0x62, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x0f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75,
+	0x72, 0x65, 0x64, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x33, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66,
+	0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2a, 0x2f, 0x0a,
+	0x0b, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x08, 0x0a, 0x04,
+	0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x4c, 0x45, 0x10, 0x01, 0x12,
+	0x0d, 0x0a, 0x09, 0x50, 0x4c, 0x41, 0x49, 0x4e, 0x54, 0x45, 0x58, 0x54, 0x10, 0x02, 0x2a, 0x24,
+	0x0a, 0x06, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x54, 0x41, 0x4e,
+	0x44, 0x41, 0x52, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x48, 0x41, 0x53, 0x48, 0x4c, 0x49,
+	0x46, 0x45, 0x10, 0x01, 0x2a, 0x42, 0x0a, 0x08, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79,
+	0x12, 0x0b, 0x0a, 0x07, 0x42, 0x4f, 0x55, 0x4e, 0x44, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a,
+	0x05, 0x54, 0x4f, 0x52, 0x55, 0x53, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x4b, 0x4c, 0x45, 0x49,
+	0x4e, 0x5f, 0x42, 0x4f, 0x54, 0x54, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x59,
+	0x4c, 0x49, 0x4e, 0x44, 0x45, 0x52, 0x10, 0x03, 0x2a, 0x5a, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e,
+	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x01, 0x12, 0x0f, 0x0a,
+	0x0b, 0x42, 0x41, 0x44, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0x02, 0x12, 0x0d,
+	0x0a, 0x09, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x15, 0x0a,
+	0x11, 0x44, 0x45, 0x41, 0x44, 0x4c, 0x49, 0x4e, 0x45, 0x5f, 0x45, 0x58, 0x43, 0x45, 0x45, 0x44,
+	0x45, 0x44, 0x10, 0x04, 0x2a, 0x62, 0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x0f, 0x0a, 0x0b, 0x4a, 0x4f, 0x42, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10,
+	0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x4a, 0x4f, 0x42, 0x5f, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47,
+	0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x55, 0x43, 0x43, 0x45, 0x45,
+	0x44, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x4a, 0x4f, 0x42, 0x5f, 0x46, 0x41, 0x49,
+	0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x4a, 0x4f, 0x42, 0x5f, 0x43, 0x41, 0x4e,
+	0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x32, 0x9f, 0x07, 0x0a, 0x0a, 0x47, 0x61, 0x6d,
+	0x65, 0x4f, 0x66, 0x4c, 0x69, 0x66, 0x65, 0x12, 0x40, 0x0a, 0x07, 0x52, 0x75, 0x6e, 0x47, 0x61,
+	0x6d, 0x65, 0x12, 0x19, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70,
+	0x62, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e,
+	0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x47, 0x61, 0x6d,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0d, 0x52, 0x75, 0x6e,
+	0x47, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x19, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46,
+	0x72, 0x61, 0x6d, 0x65, 0x30, 0x01, 0x12, 0x55, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x12, 0x21, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c,
+	0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x67, 0x61, 0x6d, 0x65,
+	0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a,
+	0x0a, 0x47, 0x65, 0x74, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x1f, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x67,
+	0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x50, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x6e, 0x12, 0x4b, 0x0a, 0x08, 0x52, 0x75, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x12,
+	0x1e, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3a, 0x0a, 0x0a, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x19,
+	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x47, 0x61,
+	0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65,
+	0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x4a, 0x6f, 0x62, 0x12, 0x38, 0x0a, 0x06,
+	0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x12, 0x1b, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c,
+	0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65,
+	0x70, 0x62, 0x2e, 0x4a, 0x6f, 0x62, 0x12, 0x3e, 0x0a, 0x09, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x4a, 0x6f, 0x62, 0x12, 0x1e, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65,
+	0x70, 0x62, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65,
+	0x70, 0x62, 0x2e, 0x4a, 0x6f, 0x62, 0x12, 0x49, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f,
+	0x62, 0x73, 0x12, 0x1d, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70,
+	0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4a, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x22, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70,
+	0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c,
+	0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x38, 0x0a,
+	0x04, 0x53, 0x74, 0x65, 0x70, 0x12, 0x19, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x65, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x15, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x44, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c,
+	0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x4b, 0x0a,
+	0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x22,
+	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x70, 0x62, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x43, 0x5a, 0x41, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x44, 0x61, 0x74, 0x61, 0x44, 0x6f, 0x67,
+	0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x74, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x2d, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x61, 0x70, 0x70, 0x73, 0x2f, 0x67, 0x61, 0x6d,
+	0x65, 0x2d, 0x6f, 0x66, 0x2d, 0x6c, 0x69, 0x66, 0x65, 0x2f, 0x67, 0x6f, 0x2f, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gameoflife_proto_rawDescOnce sync.Once
+	file_gameoflife_proto_rawDescData = file_gameoflife_proto_rawDesc
+)
+
+func file_gameoflife_proto_rawDescGZIP() []byte {
+	file_gameoflife_proto_rawDescOnce.Do(func() {
+		file_gameoflife_proto_rawDescData = protoimpl.X.CompressGZIP(file_gameoflife_proto_rawDescData)
+	})
+	return file_gameoflife_proto_rawDescData
+}
+
+var file_gameoflife_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_gameoflife_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_gameoflife_proto_goTypes = []interface{}{
+	(BoardFormat)(0),              // 0: gameoflifepb.BoardFormat
+	(Engine)(0),                   // 1: gameoflifepb.Engine
+	(Topology)(0),                 // 2: gameoflifepb.Topology
+	(ResponseCode)(0),             // 3: gameoflifepb.ResponseCode
+	(JobState)(0),                 // 4: gameoflifepb.JobState
+	(*GameRequest)(nil),           // 5: gameoflifepb.GameRequest
+	(*RandomBoard)(nil),           // 6: gameoflifepb.RandomBoard
+	(*PatternPlacement)(nil),      // 7: gameoflifepb.PatternPlacement
+	(*Pattern)(nil),               // 8: gameoflifepb.Pattern
+	(*ListPatternsRequest)(nil),   // 9: gameoflifepb.ListPatternsRequest
+	(*ListPatternsResponse)(nil),  // 10: gameoflifepb.ListPatternsResponse
+	(*GetPatternRequest)(nil),     // 11: gameoflifepb.GetPatternRequest
+	(*Board)(nil),                 // 12: gameoflifepb.Board
+	(*Cell)(nil),                  // 13: gameoflifepb.Cell
+	(*GameResponse)(nil),          // 14: gameoflifepb.GameResponse
+	(*BatchGameRequest)(nil),      // 15: gameoflifepb.BatchGameRequest
+	(*BatchGameResponse)(nil),     // 16: gameoflifepb.BatchGameResponse
+	(*BatchGameResult)(nil),       // 17: gameoflifepb.BatchGameResult
+	(*Job)(nil),                   // 18: gameoflifepb.Job
+	(*GetJobRequest)(nil),         // 19: gameoflifepb.GetJobRequest
+	(*CancelJobRequest)(nil),      // 20: gameoflifepb.CancelJobRequest
+	(*ListJobsRequest)(nil),       // 21: gameoflifepb.ListJobsRequest
+	(*ListJobsResponse)(nil),      // 22: gameoflifepb.ListJobsResponse
+	(*Session)(nil),               // 23: gameoflifepb.Session
+	(*CreateSessionRequest)(nil),  // 24: gameoflifepb.CreateSessionRequest
+	(*StepRequest)(nil),           // 25: gameoflifepb.StepRequest
+	(*GetSessionRequest)(nil),     // 26: gameoflifepb.GetSessionRequest
+	(*DeleteSessionRequest)(nil),  // 27: gameoflifepb.DeleteSessionRequest
+	(*GenerationStats)(nil),       // 28: gameoflifepb.GenerationStats
+	(*BoundingBox)(nil),           // 29: gameoflifepb.BoundingBox
+	(*GenerationFrame)(nil),       // 30: gameoflifepb.GenerationFrame
+	(*timestamppb.Timestamp)(nil), // 31: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 32: google.protobuf.Empty
+}
+var file_gameoflife_proto_depIdxs = []int32{
+	2,  // 0: gameoflifepb.GameRequest.topology:type_name -> gameoflifepb.Topology
+	1,  // 1: gameoflifepb.GameRequest.engine:type_name -> gameoflifepb.Engine
+	12, // 2: gameoflifepb.GameRequest.structured_board:type_name -> gameoflifepb.Board
+	0,  // 3: gameoflifepb.GameRequest.format:type_name -> gameoflifepb.BoardFormat
+	7,  // 4: gameoflifepb.GameRequest.placement:type_name -> gameoflifepb.PatternPlacement
+	6,  // 5: gameoflifepb.GameRequest.random_board:type_name -> gameoflifepb.RandomBoard
+	12, // 6: gameoflifepb.Pattern.board:type_name -> gameoflifepb.Board
+	8,  // 7: gameoflifepb.ListPatternsResponse.patterns:type_name -> gameoflifepb.Pattern
+	13, // 8: gameoflifepb.Board.live_cells:type_name -> gameoflifepb.Cell
+	3,  // 9: gameoflifepb.GameResponse.code:type_name -> gameoflifepb.ResponseCode
+	12, // 10: gameoflifepb.GameResponse.structured_board:type_name -> gameoflifepb.Board
+	28, // 11: gameoflifepb.GameResponse.stats:type_name -> gameoflifepb.GenerationStats
+	5,  // 12: gameoflifepb.BatchGameRequest.requests:type_name -> gameoflifepb.GameRequest
+	17, // 13: gameoflifepb.BatchGameResponse.results:type_name -> gameoflifepb.BatchGameResult
+	14, // 14: gameoflifepb.BatchGameResult.response:type_name -> gameoflifepb.GameResponse
+	4,  // 15: gameoflifepb.Job.state:type_name -> gameoflifepb.JobState
+	5,  // 16: gameoflifepb.Job.request:type_name -> gameoflifepb.GameRequest
+	14, // 17: gameoflifepb.Job.result:type_name -> gameoflifepb.GameResponse
+	31, // 18: gameoflifepb.Job.create_time:type_name -> google.protobuf.Timestamp
+	31, // 19: gameoflifepb.Job.finish_time:type_name -> google.protobuf.Timestamp
+	18, // 20: gameoflifepb.ListJobsResponse.jobs:type_name -> gameoflifepb.Job
+	5,  // 21: gameoflifepb.Session.game:type_name -> gameoflifepb.GameRequest
+	31, // 22: gameoflifepb.Session.create_time:type_name -> google.protobuf.Timestamp
+	31, // 23: gameoflifepb.Session.update_time:type_name -> google.protobuf.Timestamp
+	5,  // 24: gameoflifepb.CreateSessionRequest.game:type_name -> gameoflifepb.GameRequest
+	29, // 25: gameoflifepb.GenerationStats.bounding_box:type_name -> gameoflifepb.BoundingBox
+	12, // 26: gameoflifepb.GenerationFrame.structured_board:type_name -> gameoflifepb.Board
+	28, // 27: gameoflifepb.GenerationFrame.stats:type_name -> gameoflifepb.GenerationStats
+	5,  // 28: gameoflifepb.GameOfLife.RunGame:input_type -> gameoflifepb.GameRequest
+	5,  // 29: gameoflifepb.GameOfLife.RunGameStream:input_type -> gameoflifepb.GameRequest
+	9,  // 30: gameoflifepb.GameOfLife.ListPatterns:input_type -> gameoflifepb.ListPatternsRequest
+	11, // 31: gameoflifepb.GameOfLife.GetPattern:input_type -> gameoflifepb.GetPatternRequest
+	15, // 32: gameoflifepb.GameOfLife.RunGames:input_type -> gameoflifepb.BatchGameRequest
+	5,  // 33: gameoflifepb.GameOfLife.SubmitGame:input_type -> gameoflifepb.GameRequest
+	19, // 34: gameoflifepb.GameOfLife.GetJob:input_type -> gameoflifepb.GetJobRequest
+	20, // 35: gameoflifepb.GameOfLife.CancelJob:input_type -> gameoflifepb.CancelJobRequest
+	21, // 36: gameoflifepb.GameOfLife.ListJobs:input_type -> gameoflifepb.ListJobsRequest
+	24, // 37: gameoflifepb.GameOfLife.CreateSession:input_type -> gameoflifepb.CreateSessionRequest
+	25, // 38: gameoflifepb.GameOfLife.Step:input_type -> gameoflifepb.StepRequest
+	26, // 39: gameoflifepb.GameOfLife.GetSession:input_type -> gameoflifepb.GetSessionRequest
+	27, // 40: gameoflifepb.GameOfLife.DeleteSession:input_type -> gameoflifepb.DeleteSessionRequest
+	14, // 41: gameoflifepb.GameOfLife.RunGame:output_type -> gameoflifepb.GameResponse
+	30, // 42: gameoflifepb.GameOfLife.RunGameStream:output_type -> gameoflifepb.GenerationFrame
+	10, // 43: gameoflifepb.GameOfLife.ListPatterns:output_type -> gameoflifepb.ListPatternsResponse
+	8,  // 44: gameoflifepb.GameOfLife.GetPattern:output_type -> gameoflifepb.Pattern
+	16, // 45: gameoflifepb.GameOfLife.RunGames:output_type -> gameoflifepb.BatchGameResponse
+	18, // 46: gameoflifepb.GameOfLife.SubmitGame:output_type -> gameoflifepb.Job
+	18, // 47: gameoflifepb.GameOfLife.GetJob:output_type -> gameoflifepb.Job
+	18, // 48: gameoflifepb.GameOfLife.CancelJob:output_type -> gameoflifepb.Job
+	22, // 49: gameoflifepb.GameOfLife.ListJobs:output_type -> gameoflifepb.ListJobsResponse
+	23, // 50: gameoflifepb.GameOfLife.CreateSession:output_type -> gameoflifepb.Session
+	23, // 51: gameoflifepb.GameOfLife.Step:output_type -> gameoflifepb.Session
+	23, // 52: gameoflifepb.GameOfLife.GetSession:output_type -> gameoflifepb.Session
+	32, // 53: gameoflifepb.GameOfLife.DeleteSession:output_type -> google.protobuf.Empty
+	41, // [41:54] is the sub-list for method output_type
+	28, // [28:41] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
+}
+
+func init() { file_gameoflife_proto_init() }
+func file_gameoflife_proto_init() {
+	if File_gameoflife_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gameoflife_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RandomBoard); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PatternPlacement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pattern); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPatternsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPatternsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPatternRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Board); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cell); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchGameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchGameResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchGameResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Job); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListJobsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListJobsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Session); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StepRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerationStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BoundingBox); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerationFrame); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -285,8 +2786,8 @@ func file_gameoflife_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_gameoflife_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   2,
+			NumEnums:      5,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -23,6 +24,25 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type GameOfLifeClient interface {
 	RunGame(ctx context.Context, in *GameRequest, opts ...grpc.CallOption) (*GameResponse, error)
+	// Streams every generation of the game, starting with the initial board
+	RunGameStream(ctx context.Context, in *GameRequest, opts ...grpc.CallOption) (GameOfLife_RunGameStreamClient, error)
+	// Lists the built-in patterns that can be run by name
+	ListPatterns(ctx context.Context, in *ListPatternsRequest, opts ...grpc.CallOption) (*ListPatternsResponse, error)
+	GetPattern(ctx context.Context, in *GetPatternRequest, opts ...grpc.CallOption) (*Pattern, error)
+	// Runs many games concurrently. A failed game does not fail the batch, but sets the status of its result.
+	RunGames(ctx context.Context, in *BatchGameRequest, opts ...grpc.CallOption) (*BatchGameResponse, error)
+	// Starts running the game in the background, and returns its job, whose id is then used to get or cancel it
+	SubmitGame(ctx context.Context, in *GameRequest, opts ...grpc.CallOption) (*Job, error)
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error)
+	// Cancels a pending or running job, which then holds the board of the last generation reached
+	CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Job, error)
+	// Lists the jobs kept by the server, without their requests and results
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	// Starts a session holding the board of a game, which is then advanced by Step without resending the board
+	CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*Session, error)
+	Step(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*Session, error)
+	GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*Session, error)
+	DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type gameOfLifeClient struct {
@@ -42,11 +62,161 @@ func (c *gameOfLifeClient) RunGame(ctx context.Context, in *GameRequest, opts ..
 	return out, nil
 }
 
+func (c *gameOfLifeClient) RunGameStream(ctx context.Context, in *GameRequest, opts ...grpc.CallOption) (GameOfLife_RunGameStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GameOfLife_ServiceDesc.Streams[0], "/gameoflifepb.GameOfLife/RunGameStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gameOfLifeRunGameStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GameOfLife_RunGameStreamClient interface {
+	Recv() (*GenerationFrame, error)
+	grpc.ClientStream
+}
+
+type gameOfLifeRunGameStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *gameOfLifeRunGameStreamClient) Recv() (*GenerationFrame, error) {
+	m := new(GenerationFrame)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gameOfLifeClient) ListPatterns(ctx context.Context, in *ListPatternsRequest, opts ...grpc.CallOption) (*ListPatternsResponse, error) {
+	out := new(ListPatternsResponse)
+	err := c.cc.Invoke(ctx, "/gameoflifepb.GameOfLife/ListPatterns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameOfLifeClient) GetPattern(ctx context.Context, in *GetPatternRequest, opts ...grpc.CallOption) (*Pattern, error) {
+	out := new(Pattern)
+	err := c.cc.Invoke(ctx, "/gameoflifepb.GameOfLife/GetPattern", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameOfLifeClient) RunGames(ctx context.Context, in *BatchGameRequest, opts ...grpc.CallOption) (*BatchGameResponse, error) {
+	out := new(BatchGameResponse)
+	err := c.cc.Invoke(ctx, "/gameoflifepb.GameOfLife/RunGames", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameOfLifeClient) SubmitGame(ctx context.Context, in *GameRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/gameoflifepb.GameOfLife/SubmitGame", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameOfLifeClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/gameoflifepb.GameOfLife/GetJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameOfLifeClient) CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/gameoflifepb.GameOfLife/CancelJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameOfLifeClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, "/gameoflifepb.GameOfLife/ListJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameOfLifeClient) CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/gameoflifepb.GameOfLife/CreateSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameOfLifeClient) Step(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/gameoflifepb.GameOfLife/Step", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameOfLifeClient) GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/gameoflifepb.GameOfLife/GetSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameOfLifeClient) DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/gameoflifepb.GameOfLife/DeleteSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GameOfLifeServer is the server API for GameOfLife service.
 // All implementations must embed UnimplementedGameOfLifeServer
 // for forward compatibility
 type GameOfLifeServer interface {
 	RunGame(context.Context, *GameRequest) (*GameResponse, error)
+	// Streams every generation of the game, starting with the initial board
+	RunGameStream(*GameRequest, GameOfLife_RunGameStreamServer) error
+	// Lists the built-in patterns that can be run by name
+	ListPatterns(context.Context, *ListPatternsRequest) (*ListPatternsResponse, error)
+	GetPattern(context.Context, *GetPatternRequest) (*Pattern, error)
+	// Runs many games concurrently. A failed game does not fail the batch, but sets the status of its result.
+	RunGames(context.Context, *BatchGameRequest) (*BatchGameResponse, error)
+	// Starts running the game in the background, and returns its job, whose id is then used to get or cancel it
+	SubmitGame(context.Context, *GameRequest) (*Job, error)
+	GetJob(context.Context, *GetJobRequest) (*Job, error)
+	// Cancels a pending or running job, which then holds the board of the last generation reached
+	CancelJob(context.Context, *CancelJobRequest) (*Job, error)
+	// Lists the jobs kept by the server, without their requests and results
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	// Starts a session holding the board of a game, which is then advanced by Step without resending the board
+	CreateSession(context.Context, *CreateSessionRequest) (*Session, error)
+	Step(context.Context, *StepRequest) (*Session, error)
+	GetSession(context.Context, *GetSessionRequest) (*Session, error)
+	DeleteSession(context.Context, *DeleteSessionRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedGameOfLifeServer()
 }
 
@@ -57,6 +227,42 @@ type UnimplementedGameOfLifeServer struct {
 func (UnimplementedGameOfLifeServer) RunGame(context.Context, *GameRequest) (*GameResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RunGame not implemented")
 }
+func (UnimplementedGameOfLifeServer) RunGameStream(*GameRequest, GameOfLife_RunGameStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method RunGameStream not implemented")
+}
+func (UnimplementedGameOfLifeServer) ListPatterns(context.Context, *ListPatternsRequest) (*ListPatternsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPatterns not implemented")
+}
+func (UnimplementedGameOfLifeServer) GetPattern(context.Context, *GetPatternRequest) (*Pattern, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPattern not implemented")
+}
+func (UnimplementedGameOfLifeServer) RunGames(context.Context, *BatchGameRequest) (*BatchGameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunGames not implemented")
+}
+func (UnimplementedGameOfLifeServer) SubmitGame(context.Context, *GameRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitGame not implemented")
+}
+func (UnimplementedGameOfLifeServer) GetJob(context.Context, *GetJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedGameOfLifeServer) CancelJob(context.Context, *CancelJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelJob not implemented")
+}
+func (UnimplementedGameOfLifeServer) ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedGameOfLifeServer) CreateSession(context.Context, *CreateSessionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSession not implemented")
+}
+func (UnimplementedGameOfLifeServer) Step(context.Context, *StepRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Step not implemented")
+}
+func (UnimplementedGameOfLifeServer) GetSession(context.Context, *GetSessionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSession not implemented")
+}
+func (UnimplementedGameOfLifeServer) DeleteSession(context.Context, *DeleteSessionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSession not implemented")
+}
 func (UnimplementedGameOfLifeServer) mustEmbedUnimplementedGameOfLifeServer() {}
 
 // UnsafeGameOfLifeServer may be embedded to opt out of forward compatibility for this service.
@@ -88,6 +294,225 @@ func _GameOfLife_RunGame_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GameOfLife_RunGameStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GameRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GameOfLifeServer).RunGameStream(m, &gameOfLifeRunGameStreamServer{stream})
+}
+
+type GameOfLife_RunGameStreamServer interface {
+	Send(*GenerationFrame) error
+	grpc.ServerStream
+}
+
+type gameOfLifeRunGameStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *gameOfLifeRunGameStreamServer) Send(m *GenerationFrame) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GameOfLife_ListPatterns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPatternsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameOfLifeServer).ListPatterns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gameoflifepb.GameOfLife/ListPatterns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameOfLifeServer).ListPatterns(ctx, req.(*ListPatternsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameOfLife_GetPattern_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPatternRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameOfLifeServer).GetPattern(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gameoflifepb.GameOfLife/GetPattern",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameOfLifeServer).GetPattern(ctx, req.(*GetPatternRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameOfLife_RunGames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameOfLifeServer).RunGames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gameoflifepb.GameOfLife/RunGames",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameOfLifeServer).RunGames(ctx, req.(*BatchGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameOfLife_SubmitGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameOfLifeServer).SubmitGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gameoflifepb.GameOfLife/SubmitGame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameOfLifeServer).SubmitGame(ctx, req.(*GameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameOfLife_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameOfLifeServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gameoflifepb.GameOfLife/GetJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameOfLifeServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameOfLife_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameOfLifeServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gameoflifepb.GameOfLife/CancelJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameOfLifeServer).CancelJob(ctx, req.(*CancelJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameOfLife_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameOfLifeServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gameoflifepb.GameOfLife/ListJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameOfLifeServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameOfLife_CreateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameOfLifeServer).CreateSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gameoflifepb.GameOfLife/CreateSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameOfLifeServer).CreateSession(ctx, req.(*CreateSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameOfLife_Step_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameOfLifeServer).Step(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gameoflifepb.GameOfLife/Step",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameOfLifeServer).Step(ctx, req.(*StepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameOfLife_GetSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameOfLifeServer).GetSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gameoflifepb.GameOfLife/GetSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameOfLifeServer).GetSession(ctx, req.(*GetSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameOfLife_DeleteSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameOfLifeServer).DeleteSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gameoflifepb.GameOfLife/DeleteSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameOfLifeServer).DeleteSession(ctx, req.(*DeleteSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GameOfLife_ServiceDesc is the grpc.ServiceDesc for GameOfLife service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -99,7 +524,57 @@ var GameOfLife_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RunGame",
 			Handler:    _GameOfLife_RunGame_Handler,
 		},
+		{
+			MethodName: "ListPatterns",
+			Handler:    _GameOfLife_ListPatterns_Handler,
+		},
+		{
+			MethodName: "GetPattern",
+			Handler:    _GameOfLife_GetPattern_Handler,
+		},
+		{
+			MethodName: "RunGames",
+			Handler:    _GameOfLife_RunGames_Handler,
+		},
+		{
+			MethodName: "SubmitGame",
+			Handler:    _GameOfLife_SubmitGame_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _GameOfLife_GetJob_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _GameOfLife_CancelJob_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _GameOfLife_ListJobs_Handler,
+		},
+		{
+			MethodName: "CreateSession",
+			Handler:    _GameOfLife_CreateSession_Handler,
+		},
+		{
+			MethodName: "Step",
+			Handler:    _GameOfLife_Step_Handler,
+		},
+		{
+			MethodName: "GetSession",
+			Handler:    _GameOfLife_GetSession_Handler,
+		},
+		{
+			MethodName: "DeleteSession",
+			Handler:    _GameOfLife_DeleteSession_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RunGameStream",
+			Handler:       _GameOfLife_RunGameStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "gameoflife.proto",
 }